@@ -14,11 +14,17 @@ import (
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/gcs"
 	ahttp "github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/http"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/metrics"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/quota"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/youtube"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/config"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/analytics"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/monitor"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/resolver"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/session"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
 )
 
@@ -39,66 +45,254 @@ func main() {
 	cfg.SetupLogger()
 
 	// Adapters
-	users := memory.NewUserRepo()
-	comments := memory.NewCommentRepo()
-	state := memory.NewStateRepo()
-	yt := youtube.New(cfg.YouTubeAPIKey)
 	clock := system.NewSystemClock()
+	channels := memory.NewChannelCache(cfg.ChannelCacheSize, cfg.ChannelCacheTTL, clock)
+	api := youtube.NewWithKeys(cfg.YouTubeAPIKeys, clock,
+		youtube.WithBaseURL(cfg.YouTubeAPIBaseURL),
+		youtube.WithTimeout(cfg.YouTubeHTTPTimeout),
+		youtube.WithChannelCache(channels),
+	)
+	tokenizer := analytics.DefaultTokenizer().WithStopwords(cfg.TermStopwords)
+	spikeConfig := analytics.DefaultSpikeConfig()
+	spikeConfig.Tokenizer = tokenizer
 
-	// Snapshot Coordinator の初期化
+	// リアクション辞書: REACTION_LEXICON_PATH が設定されていればファイルから読み込む
+	lexicon := analytics.DefaultLexicon()
+	if cfg.ReactionLexiconPath != "" {
+		data, err := os.ReadFile(cfg.ReactionLexiconPath)
+		if err != nil {
+			log.Fatalf("Reaction lexicon read failed: %v", err)
+		}
+		lexicon, err = analytics.ParseLexicon(data)
+		if err != nil {
+			log.Fatalf("Reaction lexicon parse failed: %v", err)
+		}
+		log.Printf("Reaction lexicon loaded from %s (buckets=%v)", cfg.ReactionLexiconPath, lexicon.Buckets())
+	}
+
+	// Snapshot sink の初期化
 	// GCS_BUCKET が設定されている場合は GCS 経由で永続化、空の場合は no-op
 	initCtx := context.Background()
-	var coord snapshot.Coordinator
+	var sink port.SnapshotSink // GCS 無効時は nil (snapshot / history 対象の集計は不可)
 	var listHistory *usecase.ListHistorySnapshots
 	var getHistory *usecase.GetHistorySnapshot
+	var blobs port.StateBlobStore // GCS 無効時は nil (quota ledger は永続化しない)
 	if cfg.GCSBucket != "" {
 		storageClient, err := storage.NewClient(initCtx)
 		if err != nil {
 			log.Fatalf("GCS client init failed: %v", err)
 		}
 		defer func() { _ = storageClient.Close() }()
-		sink := gcs.NewSnapshotStore(storageClient, cfg.GCSBucket)
-		coord = snapshot.NewCoordinator(sink, users, comments, state, 60*time.Second)
-		listHistory = &usecase.ListHistorySnapshots{Sink: sink}
-		getHistory = &usecase.GetHistorySnapshot{Sink: sink}
-	} else {
-		coord = &snapshot.NopCoordinator{}
+		store := gcs.NewSnapshotStore(storageClient, cfg.GCSBucket)
+		sink = store
+		listHistory = &usecase.ListHistorySnapshots{Sink: store}
+		getHistory = &usecase.GetHistorySnapshot{Sink: store}
+		blobs = gcs.NewBlobStore(storageClient, cfg.GCSBucket)
 	}
 
+	// YouTube API quota ledger: 実消費は api が Record し、quota.Client が予算残量に応じて低優先度の呼び出しを抑制する
+	// 予算はキー本数分 (各キーの project が個別に 1 日の quota を持つため)
+	ledger := quota.NewLedger(cfg.YouTubeDailyQuota*max(len(cfg.YouTubeAPIKeys), 1), clock, blobs)
+	if err := ledger.Load(initCtx); err != nil {
+		log.Printf("[WARN] quota ledger restore failed, starting from zero: %v", err)
+	}
+	api.Quota = ledger
+	// quota 超過・rate limit で API を止める circuit breaker は、予算管理の拒否で open にならないよう実 API を直接ラップする
+	breaker := quota.NewBreaker(api, clock)
+	yt := quota.Wrap(breaker, ledger)
+	yt.Channels = channels // キャッシュ済みチャンネルの解決は予算判定しない
+
+	// Session registry: 配信ごとに repo 一式・State・snapshot coordinator・monitor を分離する。
+	// 従来のルートは既定セッション、/sessions/{videoID}/... は videoID ごとのセッションを対象にする。
+	sessions := session.NewRegistry(nil)
+	sessions.Store = blobs // 追加セッションの一覧を永続化し、再起動後に開き直す
+	// チャンネル名・ハンドルは Pull から切り離して非同期に解決し、全セッションの User / Comment を backfill する
+	channelResolver := &resolver.Resolver{
+		YT: yt, Cache: channels, Clock: clock,
+		Users:    &session.Backfill{Registry: sessions},
+		Comments: &session.Backfill{Registry: sessions},
+	}
+	newSession := func(id string) (*session.Session, error) {
+		users := memory.NewUserRepo()
+		comments := memory.NewCommentRepo()
+		state := memory.NewStateRepo()
+		reactions := memory.NewReactionRepo()
+		viewers := memory.NewViewerRepo()
+		metadata := memory.NewMetadataRepo()
+		// 予約キューは blob store に永続化する (既定セッション以外はセッションごとの key)
+		reservationKey := memory.DefaultReservationKey
+		if id != session.DefaultID {
+			reservationKey += "-" + id
+		}
+		reservations := memory.NewReservationRepo(blobs, reservationKey)
+		if err := reservations.Load(initCtx); err != nil {
+			log.Printf("[WARN] reservation queue restore failed for session %s: %v", id, err)
+		}
+		watchKey := memory.DefaultWatchKey
+		if id != session.DefaultID {
+			watchKey += "-" + id
+		}
+		watches := memory.NewWatchRepo(blobs, watchKey)
+		if err := watches.Load(initCtx); err != nil {
+			log.Printf("[WARN] channel watch restore failed for session %s: %v", id, err)
+		}
+
+		var coord snapshot.Coordinator = &snapshot.NopCoordinator{}
+		if sink != nil {
+			opts := []snapshot.Option{
+				snapshot.WithReactions(reactions), snapshot.WithViewers(viewers), snapshot.WithMetadata(metadata),
+				snapshot.WithChannels(channels.NewCursor()), snapshot.WithSpikeConfig(spikeConfig),
+				// GCS sink は journal に対応しているため、throttle ごとの全量 save の代わりに差分を追記して定期的に畳み込む
+				snapshot.WithJournal(cfg.SnapshotJournalInterval, cfg.SnapshotCompactInterval),
+			}
+			if id != session.DefaultID {
+				// 起動時 Restore の対象 (current pointer) は既定セッションのみ
+				opts = append(opts, snapshot.WithoutCurrentPointer())
+			}
+			coord = snapshot.NewCoordinator(sink, users, comments, state, 60*time.Second, opts...)
+		}
+
+		// Pull のページ保存と Reset / SwitchVideo の repo の置き換えを排他する
+		storeLock := &usecase.StoreLock{}
+		ucSwitch := &usecase.SwitchVideo{YT: yt, Users: users, Comments: comments, State: state, Clock: clock, Snap: coord, Reactions: reactions, Viewers: viewers, Metadata: metadata, Lock: storeLock}
+		ucPull := &usecase.Pull{
+			YT: yt, Users: users, Comments: comments, State: state, Clock: clock, Snap: coord,
+			Reactions: reactions, Lexicon: lexicon, Resolver: channelResolver, Lock: storeLock,
+			MaxDrainPages:     cfg.PullDrainMaxPages,
+			DrainQuotaReserve: cfg.PullDrainQuotaReserve,
+			Quota:             ledger,
+		}
+		// 手動 /pull と monitor の自動 Pull は同じ scheduler を経由させ、同時実行による token の巻き戻りを防ぐ
+		pullScheduler := usecase.NewPullScheduler(ucPull)
+		ucReserve := &usecase.Reserve{YT: yt, State: state, Clock: clock, Snap: coord}
+		ucPromote := &usecase.PromoteReservation{Queue: reservations, State: state, Reserve: ucReserve, Clock: clock}
+		ucStartOrReserve := &usecase.StartOrReserve{YT: yt, Clock: clock, SwitchVideo: ucSwitch, Reserve: ucReserve}
+		ucDetect := &usecase.DetectBroadcast{
+			YT: yt, Watches: watches, State: state, StartOrReserve: ucStartOrReserve, Clock: clock,
+			Quota: ledger, Interval: cfg.ChannelWatchInterval, Sessions: sessions,
+		}
+
+		// Monitor: RESERVED 状態を監視して配信開始時に SwitchVideo を自動実行し、ACTIVE 中は自動 Pull する
+		mon := monitor.New(ucSwitch, ucPull, yt, state, clock)
+		mon.Pull = pullScheduler
+		mon.Interval = monitor.DefaultInterval
+		mon.Buffer = monitor.DefaultBuffer
+		mon.Poll = &monitor.PollPolicy{Min: cfg.PollMinInterval, Max: cfg.PollMaxInterval}
+		mon.Quota = ledger
+		mon.Breaker = breaker
+		mon.Promote = ucPromote
+		mon.Detect = ucDetect
+		// 予約の予定変更と、配信の再開によるチャットの切り替わりに追従する
+		mon.Reschedule = &usecase.Reschedule{State: state, Snap: coord}
+		mon.RefreshChat = &usecase.RefreshLiveChat{YT: yt, State: state, Snap: coord}
+		// 配信中の確認は 1 回の起床で詳細を 1 度だけ取得して共有し、サンプリングだけの取得は低優先度で予算判定する
+		mon.SampleYT = yt.LowPriorityDetails()
+		mon.SampleViewers = &usecase.SampleViewers{
+			YT: yt, State: state, Viewers: viewers, Snap: coord, Clock: clock,
+			Quota: ledger, Interval: cfg.ViewerSampleInterval, Sessions: sessions,
+		}
+		mon.RefreshMetadata = &usecase.RefreshMetadata{YT: yt, State: state, Metadata: metadata, Snap: coord, Clock: clock}
+		mon.CheckEnd = &usecase.CheckStreamEnd{YT: yt, State: state, Snap: coord, Clock: clock, Grace: cfg.StreamEndGrace}
+
+		return &session.Session{
+			ID:                 id,
+			Users:              users,
+			Comments:           comments,
+			State:              state,
+			Reactions:          reactions,
+			Viewers:            viewers,
+			Metadata:           metadata,
+			Reservations:       reservations,
+			Watches:            watches,
+			Coord:              coord,
+			Status:             &usecase.Status{Users: users, State: state, Quota: ledger, APIKeys: api.Keys, Breaker: breaker},
+			Pull:               ucPull,
+			PullNow:            pullScheduler,
+			SwitchVideo:        ucSwitch,
+			StartOrReserve:     ucStartOrReserve,
+			Reset:              &usecase.Reset{Users: users, Comments: comments, State: state, Snap: coord, Reactions: reactions, Viewers: viewers, Metadata: metadata, Clock: clock, Lock: storeLock},
+			Reserve:            ucReserve,
+			CancelReserve:      &usecase.CancelReserve{State: state, Snap: coord, Clock: clock},
+			Pause:              &usecase.Pause{State: state, Clock: clock, Snap: coord},
+			Resume:             &usecase.Resume{State: state, Clock: clock, Snap: coord},
+			AttachStream:       &usecase.AttachStream{YT: yt, State: state, Snap: coord},
+			DetachStream:       &usecase.DetachStream{State: state, Snap: coord},
+			EnqueueReservation: &usecase.EnqueueReservation{YT: yt, Queue: reservations, Clock: clock},
+			ReorderReservation: &usecase.ReorderReservation{Queue: reservations},
+			CancelReservation:  &usecase.CancelReservation{Queue: reservations, Clock: clock},
+			PromoteReservation: ucPromote,
+			WatchChannel:       &usecase.WatchChannel{Watches: watches, Clock: clock},
+			UnwatchChannel:     &usecase.UnwatchChannel{Watches: watches},
+			DetectBroadcast:    ucDetect,
+			Spikes:             &usecase.ChatSpikes{Comments: comments, State: state, YT: yt, Config: spikeConfig, Clock: clock},
+			ReactionStats:      &usecase.ReactionTimeline{Reactions: reactions, State: state, Lexicon: lexicon},
+			ViewerStats:        &usecase.ViewerTimeline{Viewers: viewers, Comments: comments, State: state},
+			Terms:              &usecase.TopTerms{Comments: comments, State: state, Sink: sink, Tokenizer: tokenizer},
+			Monitor:            mon,
+		}, nil
+	}
+	sessions.New = newSession
+
+	def, err := newSession(session.DefaultID)
+	if err != nil {
+		log.Fatalf("Default session init failed: %v", err)
+	}
+	// 件数は全セッションの合計 (並行セッションの repo も含める)
+	metrics.RegisterRepoSizes(
+		func() (n int) {
+			for _, s := range sessions.List() {
+				n += s.Users.Count()
+			}
+			return n
+		},
+		func() (n int) {
+			for _, s := range sessions.List() {
+				n += s.Comments.Count()
+			}
+			return n
+		},
+	)
 	// 起動時 Restore（失敗は warn + 続行）
-	if err := coord.Restore(initCtx); err != nil {
+	if err := def.Coord.Restore(initCtx); err != nil {
 		log.Printf("[WARN] snapshot restore failed, starting with empty state: %v", err)
 	}
-
-	// Background throttle goroutine 起動
-	coord.Start(initCtx)
-
-	// UseCases
-	ucStatus := &usecase.Status{Users: users, State: state}
-	ucSwitch := &usecase.SwitchVideo{YT: yt, Users: users, Comments: comments, State: state, Clock: clock, Snap: coord}
-	ucPull := &usecase.Pull{YT: yt, Users: users, Comments: comments, State: state, Clock: clock, Snap: coord}
-	ucReset := &usecase.Reset{Users: users, Comments: comments, State: state, Snap: coord}
-	ucReserve := &usecase.Reserve{YT: yt, State: state, Clock: clock, Snap: coord}
-	ucCancelReserve := &usecase.CancelReserve{State: state, Snap: coord}
-	ucStartOrReserve := &usecase.StartOrReserve{
-		YT:          yt,
-		Clock:       clock,
-		SwitchVideo: ucSwitch,
-		Reserve:     ucReserve,
+	sessions.Add(def)
+	if err := sessions.Reopen(initCtx); err != nil {
+		log.Printf("[WARN] session list restore failed: %v", err)
 	}
 
 	h := &ahttp.Handlers{
-		Status:         ucStatus,
-		Pull:           ucPull,
-		Reset:          ucReset,
-		Reserve:        ucReserve,
-		CancelReserve:  ucCancelReserve,
-		Users:          users,
-		Comments:       comments,
-		Coord:          coord,
-		ListHistory:    listHistory,
-		GetHistory:     getHistory,
-		StartOrReserve: ucStartOrReserve,
+		Status:             def.Status,
+		Pull:               def.Pull,
+		PullNow:            def.PullNow,
+		Pause:              def.Pause,
+		Resume:             def.Resume,
+		AttachStream:       def.AttachStream,
+		DetachStream:       def.DetachStream,
+		Reservations:       def.Reservations,
+		EnqueueReservation: def.EnqueueReservation,
+		ReorderReservation: def.ReorderReservation,
+		CancelReservation:  def.CancelReservation,
+		PromoteReservation: def.PromoteReservation,
+		Watches:            def.Watches,
+		WatchChannel:       def.WatchChannel,
+		UnwatchChannel:     def.UnwatchChannel,
+		DetectBroadcast:    def.DetectBroadcast,
+		Reset:              def.Reset,
+		Reserve:            def.Reserve,
+		CancelReserve:      def.CancelReserve,
+		Users:              def.Users,
+		Comments:           def.Comments,
+		Coord:              def.Coord,
+		ListHistory:        listHistory,
+		GetHistory:         getHistory,
+		StartOrReserve:     def.StartOrReserve,
+		VideoResolver:      ahttp.NewResolverChain(yt),
+		Spikes:             def.Spikes,
+		Reactions:          def.ReactionStats,
+		Viewers:            def.ViewerStats,
+		Terms:              def.Terms,
+		Sessions:           sessions,
 	}
 	srv := &http.Server{Addr: ":" + cfg.Port, Handler: ahttp.NewRouter(h, cfg.FrontendOrigin)}
 
@@ -106,12 +300,11 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	// Monitor goroutine: RESERVED 状態を監視して配信開始時に SwitchVideo を自動実行する。
+	// 全セッションの monitor / snapshot coordinator を起動する。以降に開いたセッションも同じ ctx で動き、
 	// signal.NotifyContext の ctx をそのまま渡すことで、シャットダウン時に自然停止する。
-	mon := monitor.New(ucSwitch, ucPull, yt, state, clock)
-	mon.Interval = monitor.DefaultInterval
-	mon.Buffer = monitor.DefaultBuffer
-	go mon.Run(ctx)
+	sessions.Start(ctx)
+	go ledger.Run(ctx, 30*time.Second)
+	go channelResolver.Run(ctx)
 	log.Printf("Monitor goroutine started (interval=%s, buffer=%s)", monitor.DefaultInterval, monitor.DefaultBuffer)
 
 	// サーバーを別ゴルーチンで起動
@@ -129,10 +322,10 @@ func main() {
 	// SIGTERM 時の snapshot flush（10 秒タイムアウト）
 	flushCtx, flushCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer flushCancel()
-	if err := coord.Flush(flushCtx); err != nil {
-		log.Printf("[WARN] snapshot flush on shutdown failed: %v", err)
+	sessions.Shutdown(flushCtx)
+	if err := ledger.Flush(flushCtx); err != nil {
+		log.Printf("[WARN] quota ledger flush on shutdown failed: %v", err)
 	}
-	coord.Stop()
 
 	// シャットダウンのタイムアウト設定（30秒）
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -0,0 +1,61 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+)
+
+// statePrefix は運用状態 blob の保存先です。
+// snapshots/ 配下に置くと List が snapshot として読もうとするため分けています。
+const statePrefix = "state/"
+
+// BlobStore は GCS を使った port.StateBlobStore 実装です。
+type BlobStore struct {
+	client *storage.Client
+	bucket string
+}
+
+// NewBlobStore は BlobStore を生成します。
+// client の Close は呼び出し元で管理してください。
+func NewBlobStore(client *storage.Client, bucket string) *BlobStore {
+	return &BlobStore{client: client, bucket: bucket}
+}
+
+// LoadBlob は state/<key>.json を読み込みます。オブジェクトが存在しない場合は (nil, nil) を返します。
+func (s *BlobStore) LoadBlob(ctx context.Context, key string) ([]byte, error) {
+	objName := statePrefix + key + ".json"
+	rc, err := s.client.Bucket(s.bucket).Object(objName).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("gcs: open state %s: %w", objName, err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("gcs: read state %s: %w", objName, err)
+	}
+	return data, nil
+}
+
+// SaveBlob は state/<key>.json を書き込みます（上書き）。
+func (s *BlobStore) SaveBlob(ctx context.Context, key string, data []byte) error {
+	objName := statePrefix + key + ".json"
+	wc := s.client.Bucket(s.bucket).Object(objName).NewWriter(ctx)
+	wc.ContentType = "application/json"
+
+	if _, err := wc.Write(data); err != nil {
+		_ = wc.Close()
+		return fmt.Errorf("gcs: write state %s: %w", objName, err)
+	}
+	if err := wc.Close(); err != nil {
+		return fmt.Errorf("gcs: close state writer %s: %w", objName, err)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,14 +19,22 @@ import (
 const (
 	snapshotPrefix = "snapshots/"
 	currentObject  = "snapshots/current.json"
+	// journalPrefix は journal chunk の保存先です (journal/<videoID>/<seq>.json)。
+	// snapshots/ 配下に置くと List が snapshot として読もうとするため分けています。
+	journalPrefix = "journal/"
 )
 
-// SnapshotStore は GCS を使った port.SnapshotSink 実装です。
+// SnapshotStore は GCS を使った port.SnapshotSink / port.JournalSink 実装です。
 type SnapshotStore struct {
 	client *storage.Client
 	bucket string
 }
 
+var (
+	_ port.SnapshotSink = (*SnapshotStore)(nil)
+	_ port.JournalSink  = (*SnapshotStore)(nil)
+)
+
 // NewSnapshotStore は SnapshotStore を生成します。
 // client の Close は呼び出し元で管理してください（SIGTERM hook 推奨）。
 func NewSnapshotStore(client *storage.Client, bucket string) *SnapshotStore {
@@ -194,3 +203,85 @@ func (s *SnapshotStore) SaveCurrent(ctx context.Context, ptr *port.CurrentPointe
 
 	return nil
 }
+
+// journalObject は chunk のオブジェクト名です。Seq を 0 埋めして名前順 = Seq 順にします。
+func journalObject(videoID string, seq int) string {
+	return fmt.Sprintf("%s%s/%010d.json", journalPrefix, videoID, seq)
+}
+
+// AppendJournal は chunk を新しいオブジェクトとして書き込みます (既存の chunk は書き換えません)。
+func (s *SnapshotStore) AppendJournal(ctx context.Context, chunk *port.JournalChunk) error {
+	data, err := json.Marshal(chunk)
+	if err != nil {
+		return fmt.Errorf("gcs: marshal journal %s/%d: %w", chunk.VideoID, chunk.Seq, err)
+	}
+
+	objName := journalObject(chunk.VideoID, chunk.Seq)
+	wc := s.client.Bucket(s.bucket).Object(objName).NewWriter(ctx)
+	wc.ContentType = "application/json"
+
+	if _, err := wc.Write(data); err != nil {
+		_ = wc.Close()
+		return fmt.Errorf("gcs: write journal %s: %w", objName, err)
+	}
+	if err := wc.Close(); err != nil {
+		return fmt.Errorf("gcs: close journal writer %s: %w", objName, err)
+	}
+	return nil
+}
+
+// LoadJournal は journal/<videoID>/ 配下の chunk を Seq の昇順で返します。
+// 読めない chunk があればエラーを返します (途中を飛ばして適用すると差分が欠けるため)。
+func (s *SnapshotStore) LoadJournal(ctx context.Context, videoID string) ([]port.JournalChunk, error) {
+	chunks := make([]port.JournalChunk, 0)
+
+	it := s.client.Bucket(s.bucket).Objects(ctx, &storage.Query{Prefix: journalPrefix + videoID + "/"})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("gcs: list journal %s: %w", videoID, err)
+		}
+
+		rc, err := s.client.Bucket(s.bucket).Object(attrs.Name).NewReader(ctx)
+		if err != nil {
+			if errors.Is(err, storage.ErrObjectNotExist) {
+				continue // compaction で削除された
+			}
+			return nil, fmt.Errorf("gcs: open journal %s: %w", attrs.Name, err)
+		}
+		data, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("gcs: read journal %s: %w", attrs.Name, err)
+		}
+
+		var chunk port.JournalChunk
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return nil, fmt.Errorf("gcs: unmarshal journal %s: %w", attrs.Name, err)
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	sort.Slice(chunks, func(i, j int) bool { return chunks[i].Seq < chunks[j].Seq })
+	return chunks, nil
+}
+
+// DeleteJournal は journal/<videoID>/ 配下の chunk をすべて削除します。
+func (s *SnapshotStore) DeleteJournal(ctx context.Context, videoID string) error {
+	it := s.client.Bucket(s.bucket).Objects(ctx, &storage.Query{Prefix: journalPrefix + videoID + "/"})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("gcs: list journal %s: %w", videoID, err)
+		}
+		if err := s.client.Bucket(s.bucket).Object(attrs.Name).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return fmt.Errorf("gcs: delete journal %s: %w", attrs.Name, err)
+		}
+	}
+}
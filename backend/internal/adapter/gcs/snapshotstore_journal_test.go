@@ -0,0 +1,71 @@
+package gcs_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+func putChunk(t *testing.T, fake *fakeGCSServer, chunk port.JournalChunk) {
+	t.Helper()
+	b, err := json.Marshal(chunk)
+	if err != nil {
+		t.Fatalf("marshal chunk: %v", err)
+	}
+	fake.put(fmt.Sprintf("journal/%s/%010d.json", chunk.VideoID, chunk.Seq), b)
+}
+
+// TestLoadJournal_ordersBySeq は videoID の chunk だけを Seq の昇順で返すことを確認します。
+func TestLoadJournal_ordersBySeq(t *testing.T) {
+	fake := newFakeGCSServer()
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	putChunk(t, fake, port.JournalChunk{VideoID: "vid1", Seq: 12, WrittenAt: now.Add(time.Minute), Comments: []domain.Comment{{ID: "c2"}}})
+	putChunk(t, fake, port.JournalChunk{VideoID: "vid1", Seq: 3, WrittenAt: now, Comments: []domain.Comment{{ID: "c1"}}})
+	putChunk(t, fake, port.JournalChunk{VideoID: "vid10", Seq: 1, WrittenAt: now})
+
+	store := newTestStore(t, srv)
+	chunks, err := store.LoadJournal(context.Background(), "vid1")
+	if err != nil {
+		t.Fatalf("LoadJournal: %v", err)
+	}
+	if len(chunks) != 2 || chunks[0].Seq != 3 || chunks[1].Seq != 12 {
+		t.Fatalf("chunks = %+v, want seq 3 then 12 of vid1 only", chunks)
+	}
+	if len(chunks[1].Comments) != 1 || chunks[1].Comments[0].ID != "c2" {
+		t.Errorf("chunk 12 comments = %+v", chunks[1].Comments)
+	}
+
+	empty, err := store.LoadJournal(context.Background(), "none")
+	if err != nil || empty == nil || len(empty) != 0 {
+		t.Errorf("LoadJournal(none) = %v, %v; want empty slice", empty, err)
+	}
+}
+
+// TestList_ignoresJournal は journal chunk が snapshot 一覧に含まれないことを確認します。
+func TestList_ignoresJournal(t *testing.T) {
+	fake := newFakeGCSServer()
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake.put("snapshots/vid1.json", marshalSnapshot(t, port.Snapshot{VideoID: "vid1", SavedAt: now, JournalSeq: 4}))
+	putChunk(t, fake, port.JournalChunk{VideoID: "vid1", Seq: 5, WrittenAt: now})
+
+	store := newTestStore(t, srv)
+	summaries, err := store.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].VideoID != "vid1" {
+		t.Errorf("summaries = %+v, want only vid1", summaries)
+	}
+}
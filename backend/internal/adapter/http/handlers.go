@@ -1,50 +1,86 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"log"
 	stdhttp "net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/metrics"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/session"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
 )
 
 type Handlers struct {
-	Status         *usecase.Status
-	Pull           *usecase.Pull
-	Reset          *usecase.Reset
-	Reserve        *usecase.Reserve
-	CancelReserve  *usecase.CancelReserve
-	StartOrReserve *usecase.StartOrReserve
-	Users          port.UserRepo
-	Comments       port.CommentRepo
-	Coord          snapshot.Coordinator
-	ListHistory    *usecase.ListHistorySnapshots
-	GetHistory     *usecase.GetHistorySnapshot
+	Status        *usecase.Status
+	Pull          *usecase.Pull
+	PullNow       *usecase.PullScheduler // 任意: 設定時は /pull を monitor と共有の single-flight 経由で実行する
+	Reset         *usecase.Reset
+	Reserve       *usecase.Reserve
+	CancelReserve *usecase.CancelReserve
+	Pause         *usecase.Pause        // 任意
+	Resume        *usecase.Resume       // 任意
+	AttachStream  *usecase.AttachStream // 任意
+	DetachStream  *usecase.DetachStream // 任意
+	// 予約キュー (任意: Reservations が nil なら /reservations は利用不可)
+	Reservations       port.ReservationRepo
+	EnqueueReservation *usecase.EnqueueReservation
+	ReorderReservation *usecase.ReorderReservation
+	CancelReservation  *usecase.CancelReservation
+	PromoteReservation *usecase.PromoteReservation
+	// チャンネル監視 (任意: Watches が nil なら /watches は利用不可)
+	Watches         port.ChannelWatchRepo
+	WatchChannel    *usecase.WatchChannel
+	UnwatchChannel  *usecase.UnwatchChannel
+	DetectBroadcast *usecase.DetectBroadcast
+	StartOrReserve  *usecase.StartOrReserve
+	VideoResolver   ResolverChain // 任意: 入力 (URL / チャンネル) → videoID の解決。nil なら動画 URL / videoID のみ
+	Users           port.UserRepo
+	Comments        port.CommentRepo
+	Coord           snapshot.Coordinator
+	ListHistory     *usecase.ListHistorySnapshots
+	GetHistory      *usecase.GetHistorySnapshot
+	Spikes          *usecase.ChatSpikes
+	Reactions       *usecase.ReactionTimeline
+	Viewers         *usecase.ViewerTimeline
+	Terms           *usecase.TopTerms
+	Sessions        *session.Registry // 任意: 設定時は /sessions 以下で複数配信を扱う
+	SessionVideoID  string            // セッション用 Handlers の対象 videoID (/switch-video は他の videoId を拒否する)
+
+	sessionMu      sync.Mutex
+	sessionRouters map[string]sessionRouter // /sessions/{videoID}/* 用にセッションごとに組み立てたルーター
 }
 
 // StatusResponse represents the response for /status endpoint
 type StatusResponse struct {
-	Status               string      `json:"status"`
-	Count                int         `json:"count"`
-	VideoID              string      `json:"videoId"`
-	LiveChatID           string      `json:"liveChatId"`
-	StartedAt            any         `json:"startedAt"`
-	EndedAt              any         `json:"endedAt"`
-	LastPulledAt         any         `json:"lastPulledAt"`
-	ReservedAt           any         `json:"reservedAt"`
-	ScheduledStartTime   any         `json:"scheduledStartTime"`
-	AutonomousMonitoring bool        `json:"autonomousMonitoring"`
-	SnapshotSavedAt      *time.Time  `json:"snapshotSavedAt,omitempty"`
-	Logs                 []LogDetail `json:"logs,omitempty"`
+	Status               string                   `json:"status"`
+	Count                int                      `json:"count"`
+	VideoID              string                   `json:"videoId"`
+	LiveChatID           string                   `json:"liveChatId"`
+	StartedAt            any                      `json:"startedAt"`
+	EndedAt              any                      `json:"endedAt"`
+	LastPulledAt         any                      `json:"lastPulledAt"`
+	ReservedAt           any                      `json:"reservedAt"`
+	ScheduledStartTime   any                      `json:"scheduledStartTime"`
+	AutonomousMonitoring bool                     `json:"autonomousMonitoring"`
+	SnapshotSavedAt      *time.Time               `json:"snapshotSavedAt,omitempty"`
+	Quota                *domain.QuotaUsage       `json:"quota,omitempty"`
+	APIKeys              []domain.APIKeyState     `json:"apiKeys,omitempty"`
+	Breaker              *domain.BreakerStatus    `json:"breaker,omitempty"` // YouTube API の circuit breaker (open なら retryAt まで休止中)
+	Transitions          []domain.StateTransition `json:"transitions,omitempty"`
+	EventStreams         []domain.EventStream     `json:"eventStreams,omitempty"`
+	Logs                 []LogDetail              `json:"logs,omitempty"`
 }
 
 // SwitchVideoResponse represents the response for /switch-video endpoint
@@ -71,6 +107,7 @@ type PullResponse struct {
 	SkippedCount          int         `json:"skippedCount"`
 	AutoReset             bool        `json:"autoReset"`
 	PollingIntervalMillis int64       `json:"pollingIntervalMillis"`
+	PagesDrained          int         `json:"pagesDrained"` // 今回取得したページ数 (追いつき時は 2 以上)
 	Logs                  []LogDetail `json:"logs,omitempty"`
 }
 
@@ -96,6 +133,69 @@ type CancelReserveResponse struct {
 	Logs   []LogDetail `json:"logs,omitempty"`
 }
 
+// PauseResumeResponse represents the response for /pause and /resume endpoints
+type PauseResumeResponse struct {
+	Status string      `json:"status"`
+	Logs   []LogDetail `json:"logs,omitempty"`
+}
+
+// EventStreamsResponse represents the response for /event/streams endpoints
+type EventStreamsResponse struct {
+	VideoID string               `json:"videoId"`
+	Streams []domain.EventStream `json:"streams"`
+	Logs    []LogDetail          `json:"logs,omitempty"`
+}
+
+// ReservationsResponse represents the response for /reservations endpoints
+type ReservationsResponse struct {
+	Reservations []domain.Reservation `json:"reservations"`
+	Status       string               `json:"status,omitempty"` // 追加直後に昇格した場合の現在の状態
+	Logs         []LogDetail          `json:"logs,omitempty"`
+}
+
+// WatchesResponse represents the response for /watches endpoints
+type WatchesResponse struct {
+	Watches  []domain.ChannelWatch `json:"watches"`
+	Detected string                `json:"detected,omitempty"` // 登録直後に検出して Reserve / SwitchVideo した videoID
+	Status   string                `json:"status,omitempty"`   // 検出した場合の現在の状態
+	Logs     []LogDetail           `json:"logs,omitempty"`
+}
+
+// SpikesResponse represents the response for /analytics/spikes endpoint
+type SpikesResponse struct {
+	VideoID string             `json:"videoId"`
+	Origin  *time.Time         `json:"origin,omitempty"`
+	Spikes  []domain.ChatSpike `json:"spikes"`
+	Logs    []LogDetail        `json:"logs,omitempty"`
+}
+
+// ReactionsResponse represents the response for /analytics/reactions endpoint
+type ReactionsResponse struct {
+	VideoID  string                  `json:"videoId"`
+	Buckets  []string                `json:"buckets"`
+	Timeline []domain.ReactionMinute `json:"timeline"`
+	Totals   map[string]int          `json:"totals"`
+	Logs     []LogDetail             `json:"logs,omitempty"`
+}
+
+// ViewersResponse represents the response for /analytics/viewers endpoint
+type ViewersResponse struct {
+	VideoID     string               `json:"videoId"`
+	Latest      *domain.ViewerSample `json:"latest,omitempty"`
+	Timeline    []domain.ViewerPoint `json:"timeline"`
+	Correlation *float64             `json:"correlation,omitempty"` // 同時視聴者数と毎分コメント数の相関係数 (-1〜1)
+	Logs        []LogDetail          `json:"logs,omitempty"`
+}
+
+// TermsResponse represents the response for /analytics/terms endpoint
+type TermsResponse struct {
+	VideoID      string                 `json:"videoId"`
+	Source       string                 `json:"source"`
+	CommentCount int                    `json:"commentCount"`
+	Terms        []domain.TermFrequency `json:"terms"`
+	Logs         []LogDetail            `json:"logs,omitempty"`
+}
+
 func collectLogs(collector *logging.Collector) []LogDetail {
 	if collector == nil {
 		return nil
@@ -108,6 +208,14 @@ func collectLogs(collector *logging.Collector) []LogDetail {
 	return logs
 }
 
+// pull は「今すぐ Pull」を実行する。PullNow があれば monitor と直列化し、実行中の Pull の結果を共有する。
+func (h *Handlers) pull(ctx context.Context) (usecase.PullOutput, error) {
+	if h.PullNow != nil {
+		return h.PullNow.Execute(ctx)
+	}
+	return h.Pull.Execute(ctx)
+}
+
 func NewRouter(h *Handlers, frontendOrigin string) stdhttp.Handler {
 	r := chi.NewRouter()
 
@@ -121,6 +229,19 @@ func NewRouter(h *Handlers, frontendOrigin string) stdhttp.Handler {
 	r.Use(CORSMiddleware(frontendOrigin))
 	r.Use(CollectorMiddleware)
 
+	// Prometheus scrape 用。text format で Default registry を出力する
+	r.Method(stdhttp.MethodGet, "/metrics", metrics.Handler())
+
+	h.routes(r)
+	if h.Sessions != nil {
+		h.sessionRoutes(r)
+	}
+	return r
+}
+
+// routes は 1 セッション分の API を r に登録する。
+// 従来のルートは既定セッションの Handlers で、/sessions/{videoID}/... はセッションごとの Handlers で登録される。
+func (h *Handlers) routes(r chi.Router) {
 	r.Get("/status", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 		log.Printf("[STATUS] Getting current status")
 		collector := collectorFromRequest(r)
@@ -143,6 +264,11 @@ func NewRouter(h *Handlers, frontendOrigin string) stdhttp.Handler {
 			ReservedAt:           out.ReservedAt,
 			ScheduledStartTime:   out.ScheduledStartTime,
 			AutonomousMonitoring: out.AutonomousMonitoring,
+			Quota:                out.Quota,
+			APIKeys:              out.APIKeys,
+			Breaker:              out.Breaker,
+			Transitions:          out.Transitions,
+			EventStreams:         out.EventStreams,
 			Logs:                 collectLogs(collector),
 		}
 		if h.Coord != nil {
@@ -188,16 +314,21 @@ func NewRouter(h *Handlers, frontendOrigin string) stdhttp.Handler {
 
 		log.Printf("[SWITCH_VIDEO] Received videoId: '%s' (length: %d)", req.VideoID, len(req.VideoID))
 
-		// URL形式の場合はvideo_idを抽出
-		log.Printf("[SWITCH_VIDEO] Calling ExtractVideoID with: '%s'", req.VideoID)
-		videoID, err := ExtractVideoID(req.VideoID)
+		// URL / チャンネルの場合は videoID に解決する
+		videoID, err := h.resolveVideoID(r.Context(), req.VideoID)
 		if err != nil {
 			log.Printf("[SWITCH_VIDEO] Invalid video ID or URL: %v", err)
-			renderBadRequest(w, r, "Invalid video ID or URL: "+err.Error())
+			renderVideoInputError(w, r, err, collector)
+			return
+		}
+
+		if h.SessionVideoID != "" && videoID != h.SessionVideoID {
+			log.Printf("[SWITCH_VIDEO] videoId %s does not match session %s", videoID, h.SessionVideoID)
+			renderBadRequest(w, r, "videoId must match the session video "+h.SessionVideoID)
 			return
 		}
 
-		log.Printf("[SWITCH_VIDEO] Successfully extracted videoID: '%s' (length: %d) from: '%s'", videoID, len(videoID), req.VideoID)
+		log.Printf("[SWITCH_VIDEO] Successfully resolved videoID: '%s' (length: %d) from: '%s'", videoID, len(videoID), req.VideoID)
 		log.Printf("[SWITCH_VIDEO] Calling StartOrReserve.Execute with videoID: '%s'", videoID)
 		out, err := h.StartOrReserve.Execute(r.Context(), usecase.StartOrReserveInput{VideoID: videoID})
 		if err != nil {
@@ -223,7 +354,7 @@ func NewRouter(h *Handlers, frontendOrigin string) stdhttp.Handler {
 		log.Printf("[PULL] Processing pull request")
 		// CollectorMiddleware が既に context に inject 済みのため、middleware 経由で取得
 		collector := collectorFromRequest(r)
-		out, err := h.Pull.Execute(r.Context())
+		out, err := h.pull(r.Context())
 		if err != nil {
 			log.Printf("[PULL] Error: %v", err)
 			renderUsecaseError(w, r, err, err.Error(), collector, StatusInternalServerError, "internal_error")
@@ -236,6 +367,7 @@ func NewRouter(h *Handlers, frontendOrigin string) stdhttp.Handler {
 			SkippedCount:          out.SkippedCount,
 			AutoReset:             out.AutoReset,
 			PollingIntervalMillis: out.PollingIntervalMillis,
+			PagesDrained:          out.PagesDrained,
 			Logs:                  collectLogs(collector),
 		}
 		render.JSON(w, r, response)
@@ -300,6 +432,140 @@ func NewRouter(h *Handlers, frontendOrigin string) stdhttp.Handler {
 		render.JSON(w, r, resp)
 	})
 
+	r.Get("/analytics/spikes", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.Spikes == nil {
+			renderInternalErrorWithCollector(w, r, "spike detection is not available", collector)
+			return
+		}
+		out, err := h.Spikes.Execute(r.Context())
+		if err != nil {
+			log.Printf("[SPIKES] Error: %v", err)
+			renderUsecaseError(w, r, err, "Failed to detect spikes: "+err.Error(), collector, StatusInternalServerError, "internal_error")
+			return
+		}
+		response := SpikesResponse{
+			VideoID: out.VideoID,
+			Spikes:  out.Spikes,
+			Logs:    collectLogs(collector),
+		}
+		if !out.Origin.IsZero() {
+			response.Origin = &out.Origin
+		}
+		render.JSON(w, r, response)
+	})
+
+	r.Get("/analytics/reactions", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.Reactions == nil {
+			renderInternalErrorWithCollector(w, r, "reaction meter is not available", collector)
+			return
+		}
+		var in usecase.ReactionTimelineInput
+		if sinceParam := r.URL.Query().Get("since"); sinceParam != "" {
+			since, err := time.Parse(time.RFC3339, sinceParam)
+			if err != nil {
+				renderBadRequestWithCollector(w, r, "since must be RFC3339 timestamp", collector)
+				return
+			}
+			in.Since = since
+		}
+		out, err := h.Reactions.Execute(r.Context(), in)
+		if err != nil {
+			log.Printf("[REACTIONS] Error: %v", err)
+			renderUsecaseError(w, r, err, "Failed to get reactions: "+err.Error(), collector, StatusInternalServerError, "internal_error")
+			return
+		}
+		render.JSON(w, r, ReactionsResponse{
+			VideoID:  out.VideoID,
+			Buckets:  out.Buckets,
+			Timeline: out.Timeline,
+			Totals:   out.Totals,
+			Logs:     collectLogs(collector),
+		})
+	})
+
+	r.Get("/analytics/viewers", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.Viewers == nil {
+			renderInternalErrorWithCollector(w, r, "viewer statistics are not available", collector)
+			return
+		}
+		var in usecase.ViewerTimelineInput
+		if sinceParam := r.URL.Query().Get("since"); sinceParam != "" {
+			since, err := time.Parse(time.RFC3339, sinceParam)
+			if err != nil {
+				renderBadRequestWithCollector(w, r, "since must be RFC3339 timestamp", collector)
+				return
+			}
+			in.Since = since
+		}
+		out, err := h.Viewers.Execute(r.Context(), in)
+		if err != nil {
+			log.Printf("[VIEWERS] Error: %v", err)
+			renderUsecaseError(w, r, err, "Failed to get viewer statistics: "+err.Error(), collector, StatusInternalServerError, "internal_error")
+			return
+		}
+		render.JSON(w, r, ViewersResponse{
+			VideoID:     out.VideoID,
+			Latest:      out.Latest,
+			Timeline:    out.Points,
+			Correlation: out.Correlation,
+			Logs:        collectLogs(collector),
+		})
+	})
+
+	r.Get("/analytics/terms", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.Terms == nil {
+			renderInternalErrorWithCollector(w, r, "term frequency is not available", collector)
+			return
+		}
+		q := r.URL.Query()
+		in := usecase.TopTermsInput{
+			VideoID: q.Get("videoId"),
+			Author:  q.Get("author"),
+		}
+		if kParam := q.Get("k"); kParam != "" {
+			k, err := strconv.Atoi(kParam)
+			if err != nil {
+				renderBadRequestWithCollector(w, r, "k must be an integer", collector)
+				return
+			}
+			in.K = k
+		}
+		for name, dst := range map[string]*time.Time{"from": &in.From, "to": &in.To} {
+			if v := q.Get(name); v != "" {
+				t, err := time.Parse(time.RFC3339, v)
+				if err != nil {
+					renderBadRequestWithCollector(w, r, name+" must be RFC3339 timestamp", collector)
+					return
+				}
+				*dst = t
+			}
+		}
+		if sw := q.Get("stopwords"); sw != "" {
+			in.Stopwords = strings.Split(sw, ",")
+		}
+		out, err := h.Terms.Execute(r.Context(), in)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				RenderNotFoundError(w, r, "snapshot not found")
+				return
+			}
+			log.Printf("[TERMS] Error: %v", err)
+			renderUsecaseError(w, r, err, "Failed to compute top terms: "+err.Error(), collector, StatusInternalServerError, "internal_error")
+			return
+		}
+		render.JSON(w, r, TermsResponse{
+			VideoID:      out.VideoID,
+			Source:       out.Source,
+			CommentCount: out.CommentCount,
+			Terms:        out.Terms,
+			Logs:         collectLogs(collector),
+		})
+	})
+
 	r.Post("/reserve", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 		log.Printf("[RESERVE] Processing reserve request")
 		collector := collectorFromRequest(r)
@@ -343,6 +609,251 @@ func NewRouter(h *Handlers, frontendOrigin string) stdhttp.Handler {
 		render.JSON(w, r, response)
 	})
 
+	r.Post("/pause", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.Pause == nil {
+			renderInternalErrorWithCollector(w, r, "pause is not available", collector)
+			return
+		}
+		out, err := h.Pause.Execute(r.Context())
+		if err != nil {
+			log.Printf("[PAUSE] Execute error: %v", err)
+			renderUsecaseError(w, r, err, "Failed to pause: "+err.Error(), collector, StatusInternalServerError, "internal_error")
+			return
+		}
+		render.JSON(w, r, PauseResumeResponse{Status: string(out.State.Status), Logs: collectLogs(collector)})
+	})
+
+	r.Post("/resume", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.Resume == nil {
+			renderInternalErrorWithCollector(w, r, "resume is not available", collector)
+			return
+		}
+		out, err := h.Resume.Execute(r.Context())
+		if err != nil {
+			log.Printf("[RESUME] Execute error: %v", err)
+			renderUsecaseError(w, r, err, "Failed to resume: "+err.Error(), collector, StatusInternalServerError, "internal_error")
+			return
+		}
+		render.JSON(w, r, PauseResumeResponse{Status: string(out.State.Status), Logs: collectLogs(collector)})
+	})
+
+	// POST /event/streams: コラボ配信などの他の配信を現在のイベントに追加し、チャットをまとめて取得する
+	r.Post("/event/streams", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.AttachStream == nil {
+			renderInternalErrorWithCollector(w, r, "event streams are not available", collector)
+			return
+		}
+		var req struct {
+			VideoID string `json:"videoId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			renderBadRequest(w, r, "Invalid JSON format")
+			return
+		}
+		if req.VideoID == "" {
+			renderBadRequest(w, r, "videoId is required")
+			return
+		}
+		videoID, err := h.resolveVideoID(r.Context(), req.VideoID)
+		if err != nil {
+			renderVideoInputError(w, r, err, collector)
+			return
+		}
+		out, err := h.AttachStream.Execute(r.Context(), usecase.EventStreamInput{VideoID: videoID})
+		if err != nil {
+			log.Printf("[EVENT] Attach error: %v", err)
+			renderUsecaseError(w, r, err, "Failed to attach stream: "+err.Error(), collector, StatusBadGateway, "bad_gateway")
+			return
+		}
+		render.JSON(w, r, eventStreamsResponse(out.State, collector))
+	})
+
+	r.Delete("/event/streams/{videoID}", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.DetachStream == nil {
+			renderInternalErrorWithCollector(w, r, "event streams are not available", collector)
+			return
+		}
+		out, err := h.DetachStream.Execute(r.Context(), usecase.EventStreamInput{VideoID: chi.URLParam(r, "videoID")})
+		if err != nil {
+			log.Printf("[EVENT] Detach error: %v", err)
+			renderUsecaseError(w, r, err, "Failed to detach stream: "+err.Error(), collector, StatusInternalServerError, "internal_error")
+			return
+		}
+		render.JSON(w, r, eventStreamsResponse(out.State, collector))
+	})
+
+	r.Get("/reservations", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.Reservations == nil {
+			renderInternalErrorWithCollector(w, r, "reservation queue is not available", collector)
+			return
+		}
+		list, err := h.Reservations.List(r.Context())
+		if err != nil {
+			log.Printf("[RESERVATIONS] List error: %v", err)
+			renderInternalErrorWithCollector(w, r, "Failed to list reservations", collector)
+			return
+		}
+		render.JSON(w, r, ReservationsResponse{Reservations: list, Logs: collectLogs(collector)})
+	})
+
+	// POST /reservations: 連続配信の予約キューに追加する (配信中でも可)。配信が無ければすぐ RESERVED に昇格する
+	r.Post("/reservations", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.EnqueueReservation == nil {
+			renderInternalErrorWithCollector(w, r, "reservation queue is not available", collector)
+			return
+		}
+		var req struct {
+			VideoID string `json:"videoId"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			renderBadRequest(w, r, "Invalid JSON format")
+			return
+		}
+		if req.VideoID == "" {
+			renderBadRequest(w, r, "videoId is required")
+			return
+		}
+		videoID, err := h.resolveVideoID(r.Context(), req.VideoID)
+		if err != nil {
+			renderVideoInputError(w, r, err, collector)
+			return
+		}
+		out, err := h.EnqueueReservation.Execute(r.Context(), usecase.ReservationInput{VideoID: videoID})
+		if err != nil {
+			log.Printf("[RESERVATIONS] Enqueue error: %v", err)
+			renderUsecaseError(w, r, err, "Failed to queue reservation: "+err.Error(), collector, StatusBadGateway, "bad_gateway")
+			return
+		}
+		resp := ReservationsResponse{Reservations: out.Reservations}
+		if h.PromoteReservation != nil {
+			// 配信が無ければ monitor の tick を待たずに昇格する (失敗しても monitor が再試行する)
+			pout, err := h.PromoteReservation.Execute(r.Context())
+			if err != nil {
+				logging.Log(r.Context(), "warn", "RESERVATIONS", "promote after enqueue failed: %v", err)
+			} else if pout.Promoted != nil {
+				resp.Status = string(pout.State.Status)
+				if list, err := h.Reservations.List(r.Context()); err == nil {
+					resp.Reservations = list
+				}
+			}
+		}
+		resp.Logs = collectLogs(collector)
+		render.JSON(w, r, resp)
+	})
+
+	// POST /reservations/{videoID}/position: 順番待ちの予約を指定位置 (0 始まり) へ移動する
+	r.Post("/reservations/{videoID}/position", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.ReorderReservation == nil {
+			renderInternalErrorWithCollector(w, r, "reservation queue is not available", collector)
+			return
+		}
+		var req struct {
+			Position *int `json:"position"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			renderBadRequest(w, r, "Invalid JSON format")
+			return
+		}
+		if req.Position == nil {
+			renderBadRequest(w, r, "position is required")
+			return
+		}
+		out, err := h.ReorderReservation.Execute(r.Context(), usecase.ReservationInput{VideoID: chi.URLParam(r, "videoID"), Position: *req.Position})
+		if err != nil {
+			renderUsecaseError(w, r, err, "Failed to reorder reservation: "+err.Error(), collector, StatusInternalServerError, "internal_error")
+			return
+		}
+		render.JSON(w, r, ReservationsResponse{Reservations: out.Reservations, Logs: collectLogs(collector)})
+	})
+
+	r.Delete("/reservations/{videoID}", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.CancelReservation == nil {
+			renderInternalErrorWithCollector(w, r, "reservation queue is not available", collector)
+			return
+		}
+		out, err := h.CancelReservation.Execute(r.Context(), usecase.ReservationInput{VideoID: chi.URLParam(r, "videoID")})
+		if err != nil {
+			renderUsecaseError(w, r, err, "Failed to cancel reservation: "+err.Error(), collector, StatusInternalServerError, "internal_error")
+			return
+		}
+		render.JSON(w, r, ReservationsResponse{Reservations: out.Reservations, Logs: collectLogs(collector)})
+	})
+
+	r.Get("/watches", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.Watches == nil {
+			renderInternalErrorWithCollector(w, r, "channel watch is not available", collector)
+			return
+		}
+		list, err := h.Watches.List(r.Context())
+		if err != nil {
+			log.Printf("[WATCH] List error: %v", err)
+			renderInternalErrorWithCollector(w, r, "Failed to list watched channels", collector)
+			return
+		}
+		render.JSON(w, r, WatchesResponse{Watches: list, Logs: collectLogs(collector)})
+	})
+
+	// POST /watches: チャンネル (channelID / @handle) を監視し、配信が無ければすぐ次の配信を探す
+	r.Post("/watches", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.WatchChannel == nil {
+			renderInternalErrorWithCollector(w, r, "channel watch is not available", collector)
+			return
+		}
+		var req struct {
+			Channel string `json:"channel"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			renderBadRequest(w, r, "Invalid JSON format")
+			return
+		}
+		out, err := h.WatchChannel.Execute(r.Context(), usecase.WatchInput{Channel: req.Channel})
+		if err != nil {
+			log.Printf("[WATCH] Watch error: %v", err)
+			renderUsecaseError(w, r, err, "Failed to watch channel: "+err.Error(), collector, StatusInternalServerError, "internal_error")
+			return
+		}
+		resp := WatchesResponse{Watches: out.Watches}
+		if h.DetectBroadcast != nil {
+			// monitor の tick を待たずに検出する (失敗しても monitor が再試行する)
+			dout, err := h.DetectBroadcast.Execute(r.Context())
+			if err != nil {
+				logging.Log(r.Context(), "warn", "WATCH", "detect after watch failed: %v", err)
+			} else if dout.Detected != nil {
+				resp.Detected = dout.Detected.VideoID
+				resp.Status = string(dout.State.Status)
+			}
+			if list, err := h.Watches.List(r.Context()); err == nil {
+				resp.Watches = list
+			}
+		}
+		resp.Logs = collectLogs(collector)
+		render.JSON(w, r, resp)
+	})
+
+	r.Delete("/watches/{channel}", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		if h.UnwatchChannel == nil {
+			renderInternalErrorWithCollector(w, r, "channel watch is not available", collector)
+			return
+		}
+		out, err := h.UnwatchChannel.Execute(r.Context(), usecase.WatchInput{Channel: chi.URLParam(r, "channel")})
+		if err != nil {
+			renderUsecaseError(w, r, err, "Failed to unwatch channel: "+err.Error(), collector, StatusInternalServerError, "internal_error")
+			return
+		}
+		render.JSON(w, r, WatchesResponse{Watches: out.Watches, Logs: collectLogs(collector)})
+	})
+
 	r.Get("/comments", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
 		collector := collectorFromRequest(r)
 		keywordsParam := r.URL.Query().Get("keywords")
@@ -388,6 +899,13 @@ func NewRouter(h *Handlers, frontendOrigin string) stdhttp.Handler {
 
 		render.JSON(w, r, comments)
 	})
+}
 
-	return r
+// eventStreamsResponse は st のイベント構成を EventStreamsResponse にする。
+func eventStreamsResponse(st domain.LiveState, collector *logging.Collector) EventStreamsResponse {
+	streams := st.EventStreams
+	if streams == nil {
+		streams = []domain.EventStream{}
+	}
+	return EventStreamsResponse{VideoID: st.VideoID, Streams: streams, Logs: collectLogs(collector)}
 }
@@ -21,36 +21,51 @@ import (
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
 )
 
-// fakeYTForReserve は /reserve endpoint テスト用の YouTubePort 実装。
-// GetVideoLiveDetails のみ意味のある値を返す。getDetailsErr が非 nil ならそれを返す。
-type fakeYTForReserve struct {
-	isLive             bool
-	scheduledStartTime time.Time
-	actualStartTime    time.Time
-	getDetailsErr      error
+// fakeYT は http_test パッケージで使用する YouTubePort の fake。
+// GetVideoLiveDetails のみ意味のある値を返し (detailsErr が非 nil ならそれを返す)、呼出回数を数える。
+type fakeYT struct {
+	details    port.VideoLiveDetails
+	detailsErr error
+
+	mu          sync.Mutex
+	detailCalls int
+}
+
+// detailsCalls は GetVideoLiveDetails の呼出回数を返す。
+func (f *fakeYT) detailsCalls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.detailCalls
 }
 
-func (f *fakeYTForReserve) GetActiveLiveChatID(_ context.Context, videoID string) (port.VideoMeta, error) {
+func (f *fakeYT) GetActiveLiveChatID(_ context.Context, videoID string) (port.VideoMeta, error) {
 	return port.VideoMeta{LiveChatID: "chat-" + videoID}, nil
 }
-func (f *fakeYTForReserve) ListLiveChatMessages(_ context.Context, _ string, _ string) ([]port.ChatMessage, string, int64, int, bool, error) {
+
+func (f *fakeYT) ListLiveChatMessages(_ context.Context, _ string, _ string) ([]port.ChatMessage, string, int64, int, bool, error) {
 	return nil, "", 0, 0, false, nil
 }
-func (f *fakeYTForReserve) GetChannelDisplayNames(_ context.Context, _ []string) (map[string]string, error) {
+
+func (f *fakeYT) GetChannelDisplayNames(_ context.Context, _ []string) (map[string]string, error) {
 	return nil, nil
 }
-func (f *fakeYTForReserve) GetChannelHandles(_ context.Context, _ []string) (map[string]string, error) {
+
+func (f *fakeYT) GetChannelHandles(_ context.Context, _ []string) (map[string]string, error) {
 	return nil, nil
 }
-func (f *fakeYTForReserve) GetVideoLiveDetails(_ context.Context, _ string) (port.VideoLiveDetails, error) {
-	if f.getDetailsErr != nil {
-		return port.VideoLiveDetails{}, f.getDetailsErr
+
+func (f *fakeYT) GetVideoLiveDetails(_ context.Context, _ string) (port.VideoLiveDetails, error) {
+	f.mu.Lock()
+	f.detailCalls++
+	f.mu.Unlock()
+	if f.detailsErr != nil {
+		return port.VideoLiveDetails{}, f.detailsErr
 	}
-	return port.VideoLiveDetails{
-		IsLiveContent:      f.isLive,
-		ScheduledStartTime: f.scheduledStartTime,
-		ActualStartTime:    f.actualStartTime,
-	}, nil
+	return f.details, nil
+}
+
+func (f *fakeYT) FindChannelBroadcasts(context.Context, string) ([]port.Broadcast, error) {
+	return nil, nil
 }
 
 // newTestServerWithReserve は Reserve / CancelReserve を含む Handlers を持つテストサーバーを返す。
@@ -484,7 +499,7 @@ func TestRouter_StatusResponseHasNoLogsField(t *testing.T) {
 // TestReserve_Success: live video を渡して RESERVED 状態になることを確認する。
 func TestReserve_Success(t *testing.T) {
 	scheduled := time.Date(2024, 6, 10, 18, 0, 0, 0, time.UTC)
-	yt := &fakeYTForReserve{isLive: true, scheduledStartTime: scheduled}
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true, ScheduledStartTime: scheduled}}
 	ts := newTestServerWithReserve(yt)
 	defer ts.Close()
 
@@ -518,7 +533,7 @@ func TestReserve_Success(t *testing.T) {
 
 // TestReserve_EmptyVideoID: videoId 空は 400 を返す。
 func TestReserve_EmptyVideoID(t *testing.T) {
-	yt := &fakeYTForReserve{isLive: true}
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true}}
 	ts := newTestServerWithReserve(yt)
 	defer ts.Close()
 
@@ -538,7 +553,7 @@ func TestReserve_EmptyVideoID(t *testing.T) {
 
 // TestReserve_ConflictWhenActive: ACTIVE 状態での Reserve は 409 を返す。
 func TestReserve_ConflictWhenActive(t *testing.T) {
-	yt := &fakeYTForReserve{isLive: true}
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true}}
 	users := memory.NewUserRepo()
 	state := memory.NewStateRepo()
 	clock := system.NewSystemClock()
@@ -578,8 +593,8 @@ func TestReserve_ConflictWhenActive(t *testing.T) {
 
 // TestReserve_VideoNotFound: 存在しない videoId は 404 を返す (httpStatusFor 経由)。
 func TestReserve_VideoNotFound(t *testing.T) {
-	yt := &fakeYTForReserve{
-		getDetailsErr: &domain.APIError{Code: domain.ErrCodeVideoNotFound, Message: "video not found"},
+	yt := &fakeYT{
+		detailsErr: &domain.APIError{Code: domain.ErrCodeVideoNotFound, Message: "video not found"},
 	}
 	ts := newTestServerWithReserve(yt)
 	defer ts.Close()
@@ -608,7 +623,7 @@ func TestReserve_VideoNotFound(t *testing.T) {
 
 // TestReserve_NotLiveContent: live 配信でない videoId は 400 を返す。
 func TestReserve_NotLiveContent(t *testing.T) {
-	yt := &fakeYTForReserve{isLive: false}
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: false}}
 	ts := newTestServerWithReserve(yt)
 	defer ts.Close()
 
@@ -629,7 +644,7 @@ func TestReserve_NotLiveContent(t *testing.T) {
 // TestCancelReserve_ConflictWhenActive: ACTIVE 状態での cancel-reserve は 409 を返す
 // (現セッション保護のためシンメトリックに拒否する設計)。
 func TestCancelReserve_ConflictWhenActive(t *testing.T) {
-	yt := &fakeYTForReserve{isLive: true}
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true}}
 	users := memory.NewUserRepo()
 	state := memory.NewStateRepo()
 	clock := system.NewSystemClock()
@@ -666,7 +681,7 @@ func TestCancelReserve_ConflictWhenActive(t *testing.T) {
 
 // TestCancelReserve_Success: RESERVED 状態から cancel-reserve すると WAITING になる。
 func TestCancelReserve_Success(t *testing.T) {
-	yt := &fakeYTForReserve{isLive: true}
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true}}
 	users := memory.NewUserRepo()
 	state := memory.NewStateRepo()
 	clock := system.NewSystemClock()
@@ -794,7 +809,7 @@ func TestSuccessResponse_ContainsLogsField(t *testing.T) {
 // TestSwitchVideo_DispatchesToReserve_WhenNotStarted: 未開始 video (actualStartTime zero) は Reserve に振り分けられる。
 func TestSwitchVideo_DispatchesToReserve_WhenNotStarted(t *testing.T) {
 	scheduled := time.Now().Add(12 * time.Hour)
-	yt := &fakeYTForReserve{isLive: true, scheduledStartTime: scheduled} // actualStartTime zero
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true, ScheduledStartTime: scheduled}} // actualStartTime zero
 	srv := newTestServerWithReserve(yt)
 	defer srv.Close()
 
@@ -822,11 +837,11 @@ func TestSwitchVideo_DispatchesToReserve_WhenNotStarted(t *testing.T) {
 
 // TestSwitchVideo_DispatchesToReserve_WhenScheduledFuture: actualStartTime あっても scheduledStartTime 未来なら Reserve。
 func TestSwitchVideo_DispatchesToReserve_WhenScheduledFuture(t *testing.T) {
-	yt := &fakeYTForReserve{
-		isLive:             true,
-		actualStartTime:    time.Now().Add(-1 * time.Minute), // 立ってる
-		scheduledStartTime: time.Now().Add(6 * time.Hour),    // 未来 (premiere ケース)
-	}
+	yt := &fakeYT{details: port.VideoLiveDetails{
+		IsLiveContent:      true,
+		ActualStartTime:    time.Now().Add(-1 * time.Minute), // 立ってる
+		ScheduledStartTime: time.Now().Add(6 * time.Hour),    // 未来 (premiere ケース)
+	}}
 	srv := newTestServerWithReserve(yt)
 	defer srv.Close()
 
@@ -851,11 +866,11 @@ func TestSwitchVideo_DispatchesToReserve_WhenScheduledFuture(t *testing.T) {
 
 // TestSwitchVideo_SwitchesDirectly_WhenStarted: actualStartTime + scheduledStartTime 過去 なら従来通り SwitchVideo に進む。
 func TestSwitchVideo_SwitchesDirectly_WhenStarted(t *testing.T) {
-	yt := &fakeYTForReserve{
-		isLive:             true,
-		actualStartTime:    time.Now().Add(-10 * time.Minute),
-		scheduledStartTime: time.Now().Add(-15 * time.Minute), // 過去
-	}
+	yt := &fakeYT{details: port.VideoLiveDetails{
+		IsLiveContent:      true,
+		ActualStartTime:    time.Now().Add(-10 * time.Minute),
+		ScheduledStartTime: time.Now().Add(-15 * time.Minute), // 過去
+	}}
 	srv := newTestServerWithReserve(yt)
 	defer srv.Close()
 
@@ -0,0 +1,82 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ahttp "github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/http"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/analytics"
+)
+
+func getSpikes(t *testing.T, srv *httptest.Server) ahttp.SpikesResponse {
+	t.Helper()
+	resp, err := stdhttp.Get(srv.URL + "/analytics/spikes")
+	if err != nil {
+		t.Fatalf("GET /analytics/spikes: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != stdhttp.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	var body ahttp.SpikesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return body
+}
+
+// TestSpikes_originMatchesSnapshot: /analytics/spikes は actualStartTime を起点にして State に保存し、
+// 配信終了後の snapshot に同梱される spike も同じ起点になる
+func TestSpikes_originMatchesSnapshot(t *testing.T) {
+	startedAt := time.Date(2026, 6, 1, 12, 5, 0, 0, time.UTC)
+	actualStart := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	state := memory.NewStateRepo()
+	_ = state.Set(context.Background(), domain.LiveState{Status: domain.StatusActive, VideoID: "v1", StartedAt: startedAt})
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true, ActualStartTime: actualStart}}
+	h := &ahttp.Handlers{Spikes: &usecase.ChatSpikes{Comments: memory.NewCommentRepo(), State: state, YT: yt, Config: analytics.DefaultSpikeConfig()}}
+	srv := httptest.NewServer(ahttp.NewRouter(h, "http://example.com"))
+	defer srv.Close()
+
+	for range 2 {
+		if body := getSpikes(t, srv); body.Origin == nil || !body.Origin.Equal(actualStart) {
+			t.Errorf("origin = %v, want actualStartTime %s", body.Origin, actualStart)
+		}
+	}
+	if yt.detailsCalls() != 1 {
+		t.Errorf("videos.list calls = %d, want 1 (origin stored in state)", yt.detailsCalls())
+	}
+	got, _ := state.Get(context.Background())
+	if !got.SpikeOrigin().Equal(actualStart) {
+		t.Errorf("state SpikeOrigin = %s, want %s (snapshot spikes use the same origin)", got.SpikeOrigin(), actualStart)
+	}
+}
+
+// TestSpikes_failedOriginLookupIsCached: actualStartTime を取得できなければ StartedAt を起点にし、
+// 再取得の間隔内はリクエストごとに videos.list を呼ばない
+func TestSpikes_failedOriginLookupIsCached(t *testing.T) {
+	startedAt := time.Date(2026, 6, 1, 12, 5, 0, 0, time.UTC)
+	state := memory.NewStateRepo()
+	_ = state.Set(context.Background(), domain.LiveState{Status: domain.StatusActive, VideoID: "v1", StartedAt: startedAt})
+	yt := &fakeYT{detailsErr: errors.New("boom")}
+	h := &ahttp.Handlers{Spikes: &usecase.ChatSpikes{Comments: memory.NewCommentRepo(), State: state, YT: yt, Config: analytics.DefaultSpikeConfig()}}
+	srv := httptest.NewServer(ahttp.NewRouter(h, "http://example.com"))
+	defer srv.Close()
+
+	for range 3 {
+		if body := getSpikes(t, srv); body.Origin == nil || !body.Origin.Equal(startedAt) {
+			t.Errorf("origin = %v, want startedAt %s", body.Origin, startedAt)
+		}
+	}
+	if yt.detailsCalls() != 1 {
+		t.Errorf("videos.list calls = %d, want 1 (negative result cached)", yt.detailsCalls())
+	}
+}
@@ -10,49 +10,52 @@ import (
 	"time"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
 )
 
-type fakeYTForURL struct{}
+// fakeYT は http パッケージのテストで使用する YouTubePort の fake。
+// GetVideoLiveDetails は live 配信が開始済みの状態を返し、SwitchVideo にディスパッチされるようにする。
+// FindChannelBroadcasts は byChannel の配信を返し、問い合わせたチャンネルを lookups に記録する。
+type fakeYT struct {
+	byChannel map[string][]port.Broadcast
+	lookups   []string
+}
 
-func (f *fakeYTForURL) GetActiveLiveChatID(ctx context.Context, videoID string) (port.VideoMeta, error) {
+func (f *fakeYT) GetActiveLiveChatID(ctx context.Context, videoID string) (port.VideoMeta, error) {
 	return port.VideoMeta{LiveChatID: "live:chat:" + videoID}, nil
 }
-
-func (f *fakeYTForURL) ListLiveChatMessages(ctx context.Context, liveChatID string, pageToken string) ([]port.ChatMessage, string, int64, int, bool, error) {
+func (f *fakeYT) ListLiveChatMessages(ctx context.Context, liveChatID string, pageToken string) ([]port.ChatMessage, string, int64, int, bool, error) {
 	return nil, "", 0, 0, false, nil
 }
-func (f *fakeYTForURL) GetChannelDisplayNames(ctx context.Context, channelIDs []string) (map[string]string, error) {
+func (f *fakeYT) GetChannelDisplayNames(ctx context.Context, channelIDs []string) (map[string]string, error) {
 	return nil, nil
 }
-func (f *fakeYTForURL) GetChannelHandles(ctx context.Context, channelIDs []string) (map[string]string, error) {
+func (f *fakeYT) GetChannelHandles(ctx context.Context, channelIDs []string) (map[string]string, error) {
 	return nil, nil
 }
-func (f *fakeYTForURL) GetVideoLiveDetails(ctx context.Context, videoID string) (port.VideoLiveDetails, error) {
-	// URL 抽出テスト用 fake: live 配信が開始済みの状態を返し SwitchVideo にディスパッチされるようにする。
+func (f *fakeYT) GetVideoLiveDetails(ctx context.Context, videoID string) (port.VideoLiveDetails, error) {
 	return port.VideoLiveDetails{
 		IsLiveContent:   true,
 		LiveChatID:      "live:chat:" + videoID,
 		ActualStartTime: time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC),
 	}, nil
 }
-
-type fakeClockForURL struct{}
-
-func (f *fakeClockForURL) Now() time.Time {
-	return time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+func (f *fakeYT) FindChannelBroadcasts(_ context.Context, channel string) ([]port.Broadcast, error) {
+	f.lookups = append(f.lookups, channel)
+	return f.byChannel[channel], nil
 }
 
 func TestSwitchVideoWithURL(t *testing.T) {
 	// セットアップ
 	users := memory.NewUserRepo()
 	state := memory.NewStateRepo()
-	yt := &fakeYTForURL{}
+	yt := &fakeYT{}
 
-	clock := &fakeClockForURL{}
+	clock := system.NewManualClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
 	ucSwitch := &usecase.SwitchVideo{YT: yt, Users: users, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 	ucReserve := &usecase.Reserve{YT: yt, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 	handlers := &Handlers{
@@ -5,9 +5,12 @@ import (
 	"log"
 	stdhttp "net/http"
 	"runtime/debug"
+	"strconv"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/metrics"
 )
 
 // LoggingMiddleware はリクエスト/レスポンスをログ出力するミドルウェア
@@ -31,9 +34,24 @@ func LoggingMiddleware(next stdhttp.Handler) stdhttp.Handler {
 		duration := time.Since(start)
 		log.Printf("[RESPONSE] %s %s -> %d (%v)",
 			r.Method, r.URL.Path, wrapped.statusCode, duration)
+
+		// route label は chi の route pattern (例: /history/snapshots/{videoID}) を使い、cardinality を抑える
+		route := routePattern(r)
+		metrics.HTTPRequests.Inc(r.Method, route, strconv.Itoa(wrapped.statusCode))
+		metrics.HTTPRequestDuration.Observe(duration.Seconds(), r.Method, route)
 	})
 }
 
+// routePattern はマッチした chi route pattern を返します。未マッチ (404) や chi 外では "unmatched"。
+func routePattern(r *stdhttp.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if p := rctx.RoutePattern(); p != "" {
+			return p
+		}
+	}
+	return "unmatched"
+}
+
 // responseWriter はステータスコードを記録するためのラッパー
 type responseWriter struct {
 	stdhttp.ResponseWriter
@@ -6,6 +6,9 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/metrics"
 )
 
 func TestCORSMiddleware(t *testing.T) {
@@ -197,6 +200,28 @@ func TestLoggingMiddleware(t *testing.T) {
 	}
 }
 
+func TestLoggingMiddleware_RecordsRouteMetrics(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(LoggingMiddleware)
+	r.Get("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	before := metrics.HTTPRequests.Value("GET", "/items/{id}", "418")
+	for _, path := range []string{"/items/a", "/items/b"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+	if got := metrics.HTTPRequests.Value("GET", "/items/{id}", "418") - before; got != 2 {
+		t.Errorf("http_requests_total{route=/items/{id}} delta = %v, want 2 (path ではなく route pattern で集計)", got)
+	}
+
+	beforeUnmatched := metrics.HTTPRequests.Value("GET", "unmatched", "404")
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/nope", nil))
+	if got := metrics.HTTPRequests.Value("GET", "unmatched", "404") - beforeUnmatched; got != 1 {
+		t.Errorf("unmatched delta = %v, want 1", got)
+	}
+}
+
 func TestCollectorMiddleware_InjectsCollector(t *testing.T) {
 	var capturedCollector any
 
@@ -0,0 +1,194 @@
+package http
+
+import (
+	"log"
+	stdhttp "net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/session"
+)
+
+// SessionSummary は /sessions の 1 セッション分の概要です。
+type SessionSummary struct {
+	ID      string `json:"id"`
+	VideoID string `json:"videoId"`
+	Status  string `json:"status"`
+	Count   int    `json:"count"`
+}
+
+// SessionsResponse represents the response for GET /sessions endpoint
+type SessionsResponse struct {
+	Sessions []SessionSummary `json:"sessions"`
+	Logs     []LogDetail      `json:"logs,omitempty"`
+}
+
+// SessionResponse represents the response for POST /sessions/{videoID} endpoint
+type SessionResponse struct {
+	SessionSummary
+	Created bool        `json:"created"`
+	Logs    []LogDetail `json:"logs,omitempty"`
+}
+
+// sessionRoutes は複数配信用の /sessions 以下を登録する。
+// /sessions/{videoID}/... は従来のルートと同じ API をそのセッションに対して提供する。
+func (h *Handlers) sessionRoutes(r chi.Router) {
+	r.Get("/sessions", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		sessions := h.Sessions.List()
+		resp := SessionsResponse{Sessions: make([]SessionSummary, 0, len(sessions))}
+		for _, s := range sessions {
+			summary, err := summarize(r, s)
+			if err != nil {
+				log.Printf("[SESSIONS] status of %s failed: %v", s.ID, err)
+				renderInternalErrorWithCollector(w, r, "Failed to get session status", collector)
+				return
+			}
+			resp.Sessions = append(resp.Sessions, summary)
+		}
+		resp.Logs = collectLogs(collector)
+		render.JSON(w, r, resp)
+	})
+
+	// POST /sessions/{videoID}: セッションを開き、配信中なら追跡を開始、配信前なら予約する (switch-video と同じ判定)
+	r.Post("/sessions/{videoID}", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		videoID, err := h.resolveVideoID(r.Context(), chi.URLParam(r, "videoID"))
+		if err != nil {
+			renderVideoInputError(w, r, err, collector)
+			return
+		}
+		s, created, err := h.Sessions.Open(r.Context(), videoID)
+		if err != nil {
+			log.Printf("[SESSIONS] open %s failed: %v", videoID, err)
+			renderUsecaseError(w, r, err, "Failed to open session: "+err.Error(), collector, StatusInternalServerError, "internal_error")
+			return
+		}
+		if created {
+			// 追加セッションは手動 /pull を前提にしないため、配信中なら monitor の自動 Pull で追跡する
+			in := usecase.StartOrReserveInput{VideoID: videoID, Autonomous: true}
+			if _, err := s.StartOrReserve.Execute(r.Context(), in); err != nil {
+				log.Printf("[SESSIONS] start %s failed: %v", videoID, err)
+				if cerr := h.Sessions.Close(r.Context(), videoID); cerr != nil {
+					log.Printf("[SESSIONS] close %s after failed start: %v", videoID, cerr)
+				}
+				renderUsecaseError(w, r, err, "Failed to start session: "+err.Error(), collector, StatusBadGateway, "bad_gateway")
+				return
+			}
+		}
+		summary, err := summarize(r, s)
+		if err != nil {
+			renderInternalErrorWithCollector(w, r, "Failed to get session status", collector)
+			return
+		}
+		log.Printf("[SESSIONS] session %s status=%s created=%v", videoID, summary.Status, created)
+		render.JSON(w, r, SessionResponse{SessionSummary: summary, Created: created, Logs: collectLogs(collector)})
+	})
+
+	r.Delete("/sessions/{videoID}", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		collector := collectorFromRequest(r)
+		id := chi.URLParam(r, "videoID")
+		if err := h.Sessions.Close(r.Context(), id); err != nil {
+			log.Printf("[SESSIONS] close %s failed: %v", id, err)
+			renderUsecaseError(w, r, err, "Failed to close session: "+err.Error(), collector, StatusInternalServerError, "internal_error")
+			return
+		}
+		h.dropSessionRouter(id)
+		log.Printf("[SESSIONS] session %s closed", id)
+		render.JSON(w, r, ResetResponse{Status: "closed", Logs: collectLogs(collector)})
+	})
+
+	r.Handle("/sessions/{videoID}/*", stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		id := chi.URLParam(r, "videoID")
+		s, ok := h.Sessions.Get(id)
+		if !ok {
+			h.dropSessionRouter(id)
+			renderError(w, r, stdhttp.StatusNotFound, "not_found", "session "+id+" not found")
+			return
+		}
+		sub := h.sessionRouter(s)
+		// 残りのパスでセッション用ルーターを引き直す (middleware は外側で適用済み)
+		chi.RouteContext(r.Context()).RoutePath = "/" + chi.URLParam(r, "*")
+		sub.ServeHTTP(w, r)
+	}))
+}
+
+// sessionRouter はセッション用に組み立てたルーターです。
+type sessionRouter struct {
+	session *session.Session
+	handler stdhttp.Handler
+}
+
+// sessionRouter は s 用のルーターを返します。リクエストごとに組み立て直さないよう、セッションごとに使い回します
+// (同じ ID で開き直されたセッションは組み立て直す)。
+func (h *Handlers) sessionRouter(s *session.Session) stdhttp.Handler {
+	h.sessionMu.Lock()
+	defer h.sessionMu.Unlock()
+	if cached, ok := h.sessionRouters[s.ID]; ok && cached.session == s {
+		return cached.handler
+	}
+	sub := chi.NewRouter()
+	h.forSession(s).routes(sub)
+	if h.sessionRouters == nil {
+		h.sessionRouters = make(map[string]sessionRouter)
+	}
+	h.sessionRouters[s.ID] = sessionRouter{session: s, handler: sub}
+	return sub
+}
+
+// dropSessionRouter は閉じたセッションのルーターを捨てます。
+func (h *Handlers) dropSessionRouter(id string) {
+	h.sessionMu.Lock()
+	delete(h.sessionRouters, id)
+	h.sessionMu.Unlock()
+}
+
+// forSession は s を対象にする Handlers を返す。履歴 (GCS) はセッション間で共有する。
+func (h *Handlers) forSession(s *session.Session) *Handlers {
+	sh := &Handlers{
+		Status:             s.Status,
+		Pull:               s.Pull,
+		PullNow:            s.PullNow,
+		Reset:              s.Reset,
+		Reserve:            s.Reserve,
+		CancelReserve:      s.CancelReserve,
+		Pause:              s.Pause,
+		Resume:             s.Resume,
+		AttachStream:       s.AttachStream,
+		DetachStream:       s.DetachStream,
+		Reservations:       s.Reservations,
+		EnqueueReservation: s.EnqueueReservation,
+		ReorderReservation: s.ReorderReservation,
+		CancelReservation:  s.CancelReservation,
+		PromoteReservation: s.PromoteReservation,
+		Watches:            s.Watches,
+		WatchChannel:       s.WatchChannel,
+		UnwatchChannel:     s.UnwatchChannel,
+		DetectBroadcast:    s.DetectBroadcast,
+		StartOrReserve:     s.StartOrReserve,
+		VideoResolver:      h.VideoResolver,
+		Users:              s.Users,
+		Comments:           s.Comments,
+		Coord:              s.Coord,
+		ListHistory:        h.ListHistory,
+		GetHistory:         h.GetHistory,
+		Spikes:             s.Spikes,
+		Reactions:          s.ReactionStats,
+		Viewers:            s.ViewerStats,
+		Terms:              s.Terms,
+	}
+	if s.ID != session.DefaultID {
+		sh.SessionVideoID = s.ID
+	}
+	return sh
+}
+
+// summarize は s の現在の状態を SessionSummary にまとめる。
+func summarize(r *stdhttp.Request, s *session.Session) (SessionSummary, error) {
+	out, err := s.Status.Execute(r.Context())
+	if err != nil {
+		return SessionSummary{}, err
+	}
+	return SessionSummary{ID: s.ID, VideoID: out.VideoID, Status: string(out.Status), Count: out.Count}, nil
+}
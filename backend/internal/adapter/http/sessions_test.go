@@ -0,0 +1,184 @@
+package http_test
+
+import (
+	"encoding/json"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ahttp "github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/http"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/session"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// newTestSession は memory repo で組み立てたテスト用セッションを返す。
+func newTestSession(yt port.YouTubePort, id string) *session.Session {
+	users := memory.NewUserRepo()
+	state := memory.NewStateRepo()
+	clock := system.NewSystemClock()
+	coord := &snapshot.NopCoordinator{}
+	ucSwitch := &usecase.SwitchVideo{YT: yt, Users: users, State: state, Clock: clock, Snap: coord}
+	ucReserve := &usecase.Reserve{YT: yt, State: state, Clock: clock, Snap: coord}
+	return &session.Session{
+		ID:             id,
+		Users:          users,
+		Comments:       memory.NewCommentRepo(),
+		State:          state,
+		Coord:          coord,
+		Status:         &usecase.Status{Users: users, State: state},
+		Pull:           &usecase.Pull{YT: yt, Users: users, State: state, Snap: coord},
+		SwitchVideo:    ucSwitch,
+		StartOrReserve: &usecase.StartOrReserve{YT: yt, Clock: clock, SwitchVideo: ucSwitch, Reserve: ucReserve},
+		Reset:          &usecase.Reset{Users: users, State: state, Snap: coord},
+		Reserve:        ucReserve,
+		CancelReserve:  &usecase.CancelReserve{State: state, Snap: coord},
+	}
+}
+
+func newTestServerWithSessions(yt port.YouTubePort) *httptest.Server {
+	reg := session.NewRegistry(func(id string) (*session.Session, error) { return newTestSession(yt, id), nil })
+	def := newTestSession(yt, session.DefaultID)
+	reg.Add(def)
+	h := &ahttp.Handlers{
+		Status:         def.Status,
+		Pull:           def.Pull,
+		Reset:          def.Reset,
+		Reserve:        def.Reserve,
+		CancelReserve:  def.CancelReserve,
+		StartOrReserve: def.StartOrReserve,
+		Users:          def.Users,
+		Comments:       def.Comments,
+		Coord:          def.Coord,
+		Sessions:       reg,
+	}
+	return httptest.NewServer(ahttp.NewRouter(h, "http://example.com"))
+}
+
+func getStatus(t *testing.T, url string) ahttp.StatusResponse {
+	t.Helper()
+	resp, err := stdhttp.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != stdhttp.StatusOK {
+		t.Fatalf("GET %s status = %d, want 200", url, resp.StatusCode)
+	}
+	var st ahttp.StatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&st); err != nil {
+		t.Fatal(err)
+	}
+	return st
+}
+
+func TestSessions_ScopedRoutesAreIsolated(t *testing.T) {
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true, ActualStartTime: time.Now().Add(-time.Minute)}}
+	srv := newTestServerWithSessions(yt)
+	defer srv.Close()
+
+	const videoID = "dQw4w9WgXcQ"
+	resp, err := stdhttp.Post(srv.URL+"/sessions/"+videoID, "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var opened ahttp.SessionResponse
+	_ = json.NewDecoder(resp.Body).Decode(&opened)
+	_ = resp.Body.Close()
+	if resp.StatusCode != stdhttp.StatusOK || !opened.Created || opened.Status != "ACTIVE" || opened.VideoID != videoID {
+		t.Fatalf("POST /sessions = %d %+v, want a new ACTIVE session", resp.StatusCode, opened)
+	}
+
+	// 追加セッションは手動 /pull を前提にしないため monitor の自動 Pull 対象になる
+	if st := getStatus(t, srv.URL+"/sessions/"+videoID+"/status"); st.VideoID != videoID || st.Status != "ACTIVE" || !st.AutonomousMonitoring {
+		t.Errorf("scoped status = %+v, want autonomous ACTIVE %s", st, videoID)
+	}
+	// 従来ルートは既定セッションを指し、他セッションの影響を受けない
+	if st := getStatus(t, srv.URL+"/status"); st.VideoID != "" || st.Count != 0 {
+		t.Errorf("default status = %+v, want the untouched default session", st)
+	}
+
+	resp, err = stdhttp.Get(srv.URL + "/sessions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var list ahttp.SessionsResponse
+	_ = json.NewDecoder(resp.Body).Decode(&list)
+	_ = resp.Body.Close()
+	if len(list.Sessions) != 2 || list.Sessions[0].ID != session.DefaultID || list.Sessions[1].ID != videoID {
+		t.Errorf("GET /sessions = %+v, want default and %s", list.Sessions, videoID)
+	}
+
+	// セッション用の switch-video は他の videoId を受け付けない
+	resp, err = stdhttp.Post(srv.URL+"/sessions/"+videoID+"/switch-video", "application/json", strings.NewReader(`{"videoId":"aaaaaaaaaaa"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != stdhttp.StatusBadRequest {
+		t.Errorf("scoped switch-video to another video status = %d, want 400", resp.StatusCode)
+	}
+
+	req, _ := stdhttp.NewRequest(stdhttp.MethodDelete, srv.URL+"/sessions/"+videoID, nil)
+	resp, err = stdhttp.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != stdhttp.StatusOK {
+		t.Errorf("DELETE /sessions/%s status = %d, want 200", videoID, resp.StatusCode)
+	}
+	resp, err = stdhttp.Get(srv.URL + "/sessions/" + videoID + "/status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != stdhttp.StatusNotFound {
+		t.Errorf("status of closed session = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestSessions_DefaultCannotBeClosed(t *testing.T) {
+	srv := newTestServerWithSessions(&fakeYT{details: port.VideoLiveDetails{IsLiveContent: true}})
+	defer srv.Close()
+
+	req, _ := stdhttp.NewRequest(stdhttp.MethodDelete, srv.URL+"/sessions/"+session.DefaultID, nil)
+	resp, err := stdhttp.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != stdhttp.StatusConflict {
+		t.Errorf("DELETE default session status = %d, want 409", resp.StatusCode)
+	}
+}
+
+func TestSessions_OpenConflictsWithDefaultSession(t *testing.T) {
+	srv := newTestServerWithSessions(&fakeYT{details: port.VideoLiveDetails{IsLiveContent: true, ActualStartTime: time.Now().Add(-time.Minute)}})
+	defer srv.Close()
+
+	const videoID = "dQw4w9WgXcQ"
+	resp, err := stdhttp.Post(srv.URL+"/switch-video", "application/json", strings.NewReader(`{"videoId":"`+videoID+`"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != stdhttp.StatusOK {
+		t.Fatalf("POST /switch-video status = %d, want 200", resp.StatusCode)
+	}
+
+	// 既定セッションが追跡中の配信は別セッションで二重に追跡しない
+	resp, err = stdhttp.Post(srv.URL+"/sessions/"+videoID, "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != stdhttp.StatusConflict {
+		t.Errorf("POST /sessions/%s status = %d, want 409", videoID, resp.StatusCode)
+	}
+}
@@ -122,14 +122,17 @@ func newHistorySummaryResponse(s port.SnapshotSummary) HistorySummaryResponse {
 // HistorySnapshotResponse は /history/snapshots/{videoID} のレスポンスです。
 // port.Snapshot の JSON shape に合わせています。
 type HistorySnapshotResponse struct {
-	VideoID      string            `json:"videoId"`
-	SavedAt      string            `json:"savedAt"` // ISO8601
-	VideoTitle   string            `json:"videoTitle,omitempty"`
-	ChannelTitle string            `json:"channelTitle,omitempty"`
-	Users        []domain.User     `json:"users"`
-	Comments     []domain.Comment  `json:"comments"`
-	State        *domain.LiveState `json:"state,omitempty"`
-	Logs         []LogDetail       `json:"logs,omitempty"`
+	VideoID      string                    `json:"videoId"`
+	SavedAt      string                    `json:"savedAt"` // ISO8601
+	VideoTitle   string                    `json:"videoTitle,omitempty"`
+	ChannelTitle string                    `json:"channelTitle,omitempty"`
+	Users        []domain.User             `json:"users"`
+	Comments     []domain.Comment          `json:"comments"`
+	State        *domain.LiveState         `json:"state,omitempty"`
+	Spikes       []domain.ChatSpike        `json:"spikes,omitempty"`
+	Reactions    []domain.ReactionMinute   `json:"reactions,omitempty"`
+	Metadata     []domain.MetadataRevision `json:"metadata,omitempty"` // タイトルなどの変更履歴 (古い順)
+	Logs         []LogDetail               `json:"logs,omitempty"`
 }
 
 // newHistorySnapshotResponse は port.Snapshot から HistorySnapshotResponse を生成します。
@@ -150,5 +153,8 @@ func newHistorySnapshotResponse(snap *port.Snapshot) HistorySnapshotResponse {
 		Users:        users,
 		Comments:     comments,
 		State:        snap.State,
+		Spikes:       snap.Spikes,
+		Reactions:    snap.Reactions,
+		Metadata:     snap.Metadata,
 	}
 }
@@ -2,6 +2,7 @@ package http
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
 	"regexp"
 	"strings"
@@ -26,13 +27,21 @@ func ExtractVideoID(input string) (string, error) {
 
 	// YouTubeドメインチェック
 	if !isYouTubeDomain(parsedURL.Host) {
-		return "", errors.New("not a YouTube URL")
+		if parsedURL.Host == "" {
+			return "", fmt.Errorf("%q is neither a video ID (11 characters) nor a URL", input)
+		}
+		return "", fmt.Errorf("not a YouTube URL (host %s)", parsedURL.Host)
+	}
+
+	// /live/<id> などの動画パスで ID が不正なら、認識した形式をエラーで伝える
+	if prefix, rest, ok := videoPath(parsedURL.Path); ok && !isValidVideoID(rest) {
+		return "", fmt.Errorf("recognised a YouTube %s URL but %q is not a valid video ID", prefix, rest)
 	}
 
 	// パスとクエリパラメータからvideo_idを抽出
 	videoID := extractVideoIDFromURL(parsedURL)
 	if videoID == "" {
-		return "", errors.New("video ID not found in URL")
+		return "", fmt.Errorf("recognised a YouTube URL but found no video ID in it (path %s)", parsedURL.Path)
 	}
 
 	return videoID, nil
@@ -87,13 +96,23 @@ func extractVideoIDFromURL(parsedURL *url.URL) string {
 		}
 	}
 
-	// 3. /embed/形式
-	if strings.HasPrefix(parsedURL.Path, "/embed/") {
-		videoID := strings.TrimPrefix(parsedURL.Path, "/embed/")
-		if isValidVideoID(videoID) {
-			return videoID
-		}
+	// 3. /embed/, /live/, /shorts/ 形式
+	if _, videoID, ok := videoPath(parsedURL.Path); ok && isValidVideoID(videoID) {
+		return videoID
 	}
 
 	return ""
+}
+
+// videoPathPrefixes は path 直下に video_id を置く YouTube の URL 形式です。
+var videoPathPrefixes = []string{"/embed/", "/live/", "/shorts/"}
+
+// videoPath は path が /embed/<id> などの動画パスなら、その形式と <id> 部分を返します。
+func videoPath(path string) (prefix, videoID string, ok bool) {
+	for _, p := range videoPathPrefixes {
+		if rest, found := strings.CutPrefix(path, p); found {
+			return p, strings.TrimSuffix(rest, "/"), true
+		}
+	}
+	return "", "", false
 }
\ No newline at end of file
@@ -59,6 +59,24 @@ func TestExtractVideoID(t *testing.T) {
 			expected: "invalid-url",
 			hasError: false,
 		},
+		{
+			name:     "ライブ配信URL（/live/）",
+			input:    "https://www.youtube.com/live/Qw3tyIFqKrg?feature=share",
+			expected: "Qw3tyIFqKrg",
+			hasError: false,
+		},
+		{
+			name:     "ショート動画URL（/shorts/）",
+			input:    "https://youtube.com/shorts/bIRpAmqwbvs",
+			expected: "bIRpAmqwbvs",
+			hasError: false,
+		},
+		{
+			name:     "/live/ のIDが不正",
+			input:    "https://www.youtube.com/live/short",
+			expected: "",
+			hasError: true,
+		},
 		{
 			name:     "YouTube以外のURL",
 			input:    "https://example.com/watch?v=Qw3tyIFqKrg",
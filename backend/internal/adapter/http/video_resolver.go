@@ -0,0 +1,147 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdhttp "net/http"
+	"net/url"
+	"strings"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// VideoResolver は操作者の入力 (videoID / URL / チャンネル) を videoID に解決する chain の 1 段です。
+// 入力を認識しなければ ok=false を返して次の段に任せ、認識したが解決できなければ err を返します。
+type VideoResolver interface {
+	ResolveVideo(ctx context.Context, input string) (videoID string, ok bool, err error)
+}
+
+// ResolverChain は VideoResolver を先頭から順に試し、最初に認識した段の結果を返します。
+type ResolverChain []VideoResolver
+
+// NewResolverChain は動画 URL / videoID を解決し、yt が非 nil ならチャンネル・@handle を
+// 現在の配信 (無ければ次の配信予定) に解決する chain を返します。
+func NewResolverChain(yt port.YouTubePort) ResolverChain {
+	chain := ResolverChain{VideoURLResolver{}}
+	if yt != nil {
+		chain = append(chain, &ChannelLiveResolver{YT: yt})
+	}
+	return chain
+}
+
+// Resolve は input を videoID に解決します。どの段も認識しなければ invalid_argument を返します。
+func (c ResolverChain) Resolve(ctx context.Context, input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: "input is empty"}
+	}
+	for _, r := range c {
+		videoID, ok, err := r.ResolveVideo(ctx, input)
+		if !ok {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return videoID, nil
+	}
+	if channel, ok := channelRef(input); ok {
+		return "", &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: fmt.Sprintf("recognised channel %s, but resolving channels to a live stream is not available", channel)}
+	}
+	return "", &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: fmt.Sprintf("unrecognised input %q: expected a video ID, a YouTube video URL (watch / youtu.be / embed / live / shorts), a channel ID, an @handle or a channel URL", input)}
+}
+
+// VideoURLResolver は videoID そのものと動画 URL を ExtractVideoID で解決します。チャンネルを指す入力は次の段に任せます。
+type VideoURLResolver struct{}
+
+func (VideoURLResolver) ResolveVideo(_ context.Context, input string) (string, bool, error) {
+	if _, ok := channelRef(input); ok {
+		return "", false, nil
+	}
+	videoID, err := ExtractVideoID(input)
+	if err != nil {
+		return "", true, &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: err.Error()}
+	}
+	return videoID, true, nil
+}
+
+// ChannelLiveResolver はチャンネル (UC... / @handle / チャンネル URL) をその配信中の配信、
+// 無ければ最も早い配信予定の videoID に解決します。
+type ChannelLiveResolver struct {
+	YT port.YouTubePort
+}
+
+func (r *ChannelLiveResolver) ResolveVideo(ctx context.Context, input string) (string, bool, error) {
+	channel, ok := channelRef(input)
+	if !ok {
+		return "", false, nil
+	}
+	broadcasts, err := r.YT.FindChannelBroadcasts(ctx, channel)
+	if err != nil {
+		return "", true, fmt.Errorf("recognised channel %s but could not look up its streams: %w", channel, err)
+	}
+	if len(broadcasts) == 0 {
+		return "", true, &domain.APIError{Code: domain.ErrCodeVideoNotFound, Message: fmt.Sprintf("recognised channel %s but it has no live or upcoming stream", channel)}
+	}
+	// FindChannelBroadcasts は配信中 → 配信予定の早い順に並ぶ
+	b := broadcasts[0]
+	kind := "upcoming"
+	if b.Live {
+		kind = "live"
+	}
+	logging.Log(ctx, "info", "VIDEO_INPUT", "resolved channel %s to %s stream %s (%q)", channel, kind, b.VideoID, b.Title)
+	return b.VideoID, true, nil
+}
+
+// channelRef は input がチャンネルを指していれば channelID (UC...) または @handle を返します。
+// 対応する形式: "@handle", "UC...", youtube.com/@handle[/live など], youtube.com/channel/UC...[/live など]
+func channelRef(input string) (string, bool) {
+	if isHandle(input) || isChannelID(input) {
+		return input, true
+	}
+	u, err := url.Parse(input)
+	if err != nil || !isYouTubeDomain(u.Host) || u.Host == "youtu.be" {
+		return "", false
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case isHandle(segments[0]):
+		return segments[0], true
+	case segments[0] == "channel" && len(segments) > 1 && isChannelID(segments[1]):
+		return segments[1], true
+	}
+	return "", false
+}
+
+// isHandle は s が @handle の形式かを返します。
+func isHandle(s string) bool {
+	return len(s) > 1 && strings.HasPrefix(s, "@") && !strings.ContainsAny(s, " /?#")
+}
+
+// isChannelID は s が channelID (UC で始まる 24 文字) の形式かを返します。
+func isChannelID(s string) bool {
+	return len(s) == 24 && strings.HasPrefix(s, "UC") && !strings.ContainsAny(s, " /?#.")
+}
+
+// resolveVideoID は VideoResolver (未設定なら動画 URL / videoID のみの chain) で input を videoID に解決します。
+func (h *Handlers) resolveVideoID(ctx context.Context, input string) (string, error) {
+	chain := h.VideoResolver
+	if chain == nil {
+		chain = NewResolverChain(nil)
+	}
+	return chain.Resolve(ctx, input)
+}
+
+// renderVideoInputError は解決できなかった入力のエラーを返します。
+// 入力不正は 400、配信の無いチャンネルは 404、チャンネル検索の API 失敗はその分類 (未分類は 502) です。
+func renderVideoInputError(w stdhttp.ResponseWriter, r *stdhttp.Request, err error, collector *logging.Collector) {
+	var apiErr *domain.APIError
+	if errors.As(err, &apiErr) && apiErr.Code == domain.ErrCodeInvalidArgument {
+		renderBadRequestWithCollector(w, r, "Invalid video ID or URL: "+apiErr.Message, collector)
+		return
+	}
+	renderUsecaseError(w, r, err, "Invalid video ID or URL: "+err.Error(), collector, StatusBadGateway, "bad_gateway")
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+func TestResolverChain_ResolvesVideosAndChannels(t *testing.T) {
+	yt := &fakeYT{byChannel: map[string][]port.Broadcast{
+		"@alice":                   {{VideoID: "liveNow0001", Live: true}, {VideoID: "nextUp00001"}},
+		"UC0123456789abcdefghijkl": {{VideoID: "nextUp00002"}},
+	}}
+	chain := NewResolverChain(yt)
+
+	for _, tc := range []struct {
+		input string
+		want  string
+	}{
+		{"Qw3tyIFqKrg", "Qw3tyIFqKrg"},
+		{"https://www.youtube.com/live/Qw3tyIFqKrg", "Qw3tyIFqKrg"},
+		{"@alice", "liveNow0001"},
+		{"https://www.youtube.com/@alice/live", "liveNow0001"},
+		{"https://youtube.com/channel/UC0123456789abcdefghijkl/live", "nextUp00002"},
+		{" UC0123456789abcdefghijkl ", "nextUp00002"},
+	} {
+		got, err := chain.Resolve(context.Background(), tc.input)
+		if err != nil || got != tc.want {
+			t.Errorf("Resolve(%q) = %q, %v; want %q", tc.input, got, err, tc.want)
+		}
+	}
+	// 動画 URL はチャンネル検索 (quota) を使わない
+	if len(yt.lookups) != 4 {
+		t.Errorf("channel lookups = %v, want only the 4 channel inputs", yt.lookups)
+	}
+}
+
+func TestResolverChain_ErrorsDescribeWhatWasRecognised(t *testing.T) {
+	yt := &fakeYT{byChannel: map[string][]port.Broadcast{}}
+	for _, tc := range []struct {
+		name  string
+		chain ResolverChain
+		input string
+		code  domain.APIErrorCode
+		want  string
+	}{
+		{"no stream", NewResolverChain(yt), "@quiet", domain.ErrCodeVideoNotFound, "recognised channel @quiet but it has no live or upcoming stream"},
+		{"channel lookup disabled", NewResolverChain(nil), "https://www.youtube.com/@alice/live", domain.ErrCodeInvalidArgument, "recognised channel @alice"},
+		{"bad live id", NewResolverChain(yt), "https://www.youtube.com/live/abc", domain.ErrCodeInvalidArgument, "recognised a YouTube /live/ URL"},
+		{"other host", NewResolverChain(yt), "https://example.com/watch?v=Qw3tyIFqKrg", domain.ErrCodeInvalidArgument, "not a YouTube URL (host example.com)"},
+		{"playlist", NewResolverChain(yt), "https://www.youtube.com/playlist?list=PL1", domain.ErrCodeInvalidArgument, "found no video ID"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.chain.Resolve(context.Background(), tc.input)
+			var apiErr *domain.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != tc.code || !strings.Contains(apiErr.Message, tc.want) {
+				t.Errorf("Resolve(%q) err = %v, want %s containing %q", tc.input, err, tc.code, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+// BlobStore はメモリ上の port.StateBlobStore 実装です (プロセス内でのみ保持します)。
+type BlobStore struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+// NewBlobStore は空の BlobStore を返します。
+func NewBlobStore() *BlobStore {
+	return &BlobStore{blobs: make(map[string][]byte)}
+}
+
+// LoadBlob は key の内容のコピーを返します。存在しない場合は (nil, nil) を返します。
+func (s *BlobStore) LoadBlob(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, nil
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// SaveBlob は data のコピーを key に上書き保存します。
+func (s *BlobStore) SaveBlob(_ context.Context, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = append([]byte(nil), data...)
+	return nil
+}
@@ -0,0 +1,43 @@
+package memory
+
+// changeSet は書き込み時に追加・更新された要素の key を記録します (snapshot の journal 用)。
+// 一度も take されていなければ記録しないため、journal を使わない構成では記録のコストがかかりません。
+// 所有する repo の mu を保持して呼ぶこと。
+type changeSet[K comparable] struct {
+	tracking bool
+	keys     []K // 記録順
+	seen     map[K]struct{}
+	reset    bool
+}
+
+// mark は key の要素が追加・更新されたことを記録します。
+func (s *changeSet[K]) mark(key K) {
+	if !s.tracking {
+		return
+	}
+	if _, ok := s.seen[key]; ok {
+		return
+	}
+	if s.seen == nil {
+		s.seen = make(map[K]struct{})
+	}
+	s.seen[key] = struct{}{}
+	s.keys = append(s.keys, key)
+}
+
+// markReset は Clear / LoadFrom で内容が置き換えられたことを記録します。
+func (s *changeSet[K]) markReset() {
+	if !s.tracking {
+		return
+	}
+	s.keys, s.seen = nil, nil
+	s.reset = true
+}
+
+// take は記録した key と reset を返して記録を空にし、以後の変更の記録を始めます。
+func (s *changeSet[K]) take() (keys []K, reset bool) {
+	keys, reset = s.keys, s.reset
+	s.tracking = true
+	s.keys, s.seen, s.reset = nil, nil, false
+	return keys, reset
+}
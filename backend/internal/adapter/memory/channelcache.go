@@ -0,0 +1,190 @@
+package memory
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+const (
+	// DefaultChannelCacheCapacity は ChannelCache の既定の最大エントリ数です。
+	DefaultChannelCacheCapacity = 10000
+	// DefaultChannelCacheTTL は ChannelCache の既定の有効期限です (表示名・ハンドル変更の反映猶予)。
+	DefaultChannelCacheTTL = 24 * time.Hour
+)
+
+// ChannelCache は LRU + TTL のチャンネルメタデータキャッシュです。
+type ChannelCache struct {
+	capacity int
+	ttl      time.Duration
+	clock    port.Clock // nil なら time.Now
+
+	mu      sync.Mutex
+	order   *list.List               // 先頭が最近使われたエントリ
+	entries map[string]*list.Element // channelID -> order 要素 (Value は domain.ChannelInfo)
+	cursors []*ChannelCacheCursor    // journal 用: Put を記録する consumer ごとの cursor
+}
+
+// NewChannelCache は ChannelCache を生成します。capacity / ttl が 0 以下なら既定値を使います。
+func NewChannelCache(capacity int, ttl time.Duration, clock port.Clock) *ChannelCache {
+	if capacity <= 0 {
+		capacity = DefaultChannelCacheCapacity
+	}
+	if ttl <= 0 {
+		ttl = DefaultChannelCacheTTL
+	}
+	return &ChannelCache{
+		capacity: capacity,
+		ttl:      ttl,
+		clock:    clock,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+var (
+	_ port.ChannelCache          = (*ChannelCache)(nil)
+	_ port.ChannelSnapshotSource = (*ChannelCache)(nil)
+
+	_ port.ChannelSnapshotSource            = (*ChannelCacheCursor)(nil)
+	_ port.ChangeSource[domain.ChannelInfo] = (*ChannelCacheCursor)(nil)
+)
+
+// Get は有効期限内のエントリを返し、LRU の先頭に移動します。期限切れのエントリは削除します。
+func (c *ChannelCache) Get(channelID string) (domain.ChannelInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[channelID]
+	if !ok {
+		return domain.ChannelInfo{}, false
+	}
+	info := el.Value.(domain.ChannelInfo)
+	if c.expired(info) {
+		c.order.Remove(el)
+		delete(c.entries, channelID)
+		return domain.ChannelInfo{}, false
+	}
+	c.order.MoveToFront(el)
+	return info, true
+}
+
+// Put はエントリを登録・更新します。FetchedAt が zero なら現在時刻を設定します。
+func (c *ChannelCache) Put(info domain.ChannelInfo) {
+	if info.ChannelID == "" {
+		return
+	}
+	if info.FetchedAt.IsZero() {
+		info.FetchedAt = c.now()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.put(info)
+	for _, cur := range c.cursors {
+		cur.changed.mark(info.ChannelID)
+	}
+}
+
+// Len は保持しているエントリ数 (期限切れ未掃除分を含む) を返します。
+func (c *ChannelCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Dump は有効期限内のエントリを最近使われた順で返します。
+func (c *ChannelCache) Dump() []domain.ChannelInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	infos := make([]domain.ChannelInfo, 0, len(c.entries))
+	for el := c.order.Front(); el != nil; el = el.Next() {
+		info := el.Value.(domain.ChannelInfo)
+		if !c.expired(info) {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+// LoadFrom は infos を既存エントリにマージします。期限切れのものと、
+// 既存エントリより古い FetchedAt のものは取り込みません。
+// infos は Dump と同じく最近使われた順を想定し、先頭が LRU の先頭になるよう末尾から登録します。
+func (c *ChannelCache) LoadFrom(infos []domain.ChannelInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := len(infos) - 1; i >= 0; i-- {
+		info := infos[i]
+		if info.ChannelID == "" || c.expired(info) {
+			continue
+		}
+		if el, ok := c.entries[info.ChannelID]; ok && el.Value.(domain.ChannelInfo).FetchedAt.After(info.FetchedAt) {
+			continue
+		}
+		c.put(info)
+	}
+}
+
+// ChannelCacheCursor は ChannelCache の変更を consumer ごとに取り出す cursor です (snapshot の journal 用)。
+// キャッシュはセッションを跨いで共有されるため、coordinator ごとに NewCursor で作り、
+// ある coordinator の TakeChanges が他の coordinator の差分を消費しないようにします。
+type ChannelCacheCursor struct {
+	*ChannelCache
+	changed changeSet[string] // 前回の TakeChanges 以降に Put された channelID (ChannelCache.mu で保護)
+}
+
+// NewCursor は c の変更を記録する cursor を作ります。Dump / LoadFrom などは c をそのまま使います。
+func (c *ChannelCache) NewCursor() *ChannelCacheCursor {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cur := &ChannelCacheCursor{ChannelCache: c}
+	c.cursors = append(c.cursors, cur)
+	return cur
+}
+
+// TakeChanges は前回の呼び出し以降に Put されたエントリのうち、残っているものを返します。
+// LoadFrom でのマージは記録しません (取り込んだ snapshot 側に既にあるため)。Reset は常に false です。
+func (cur *ChannelCacheCursor) TakeChanges() port.Changes[domain.ChannelInfo] {
+	c := cur.ChannelCache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ids, _ := cur.changed.take()
+	infos := make([]domain.ChannelInfo, 0, len(ids))
+	for _, id := range ids {
+		if el, ok := c.entries[id]; ok {
+			infos = append(infos, el.Value.(domain.ChannelInfo))
+		}
+	}
+	return port.Changes[domain.ChannelInfo]{Items: infos}
+}
+
+// put は c.mu を保持して呼ぶこと。
+func (c *ChannelCache) put(info domain.ChannelInfo) {
+	if el, ok := c.entries[info.ChannelID]; ok {
+		el.Value = info
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[info.ChannelID] = c.order.PushFront(info)
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(domain.ChannelInfo).ChannelID)
+	}
+}
+
+func (c *ChannelCache) expired(info domain.ChannelInfo) bool {
+	return c.now().Sub(info.FetchedAt) >= c.ttl
+}
+
+func (c *ChannelCache) now() time.Time {
+	if c.clock != nil {
+		return c.clock.Now()
+	}
+	return time.Now()
+}
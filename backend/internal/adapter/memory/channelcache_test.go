@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+func TestChannelCache_LRUEviction(t *testing.T) {
+	clock := system.NewManualClock(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC))
+	cache := NewChannelCache(2, time.Hour, clock)
+
+	cache.Put(domain.ChannelInfo{ChannelID: "UC1", Title: "one"})
+	cache.Put(domain.ChannelInfo{ChannelID: "UC2", Title: "two"})
+	if _, ok := cache.Get("UC1"); !ok { // UC1 を最近使用にする
+		t.Fatal("UC1 should be cached")
+	}
+	cache.Put(domain.ChannelInfo{ChannelID: "UC3", Title: "three"})
+
+	if _, ok := cache.Get("UC2"); ok {
+		t.Error("UC2 should be evicted as least recently used")
+	}
+	if _, ok := cache.Get("UC1"); !ok {
+		t.Error("UC1 should survive eviction")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len = %d, want 2", cache.Len())
+	}
+}
+
+func TestChannelCache_TTL(t *testing.T) {
+	clock := system.NewManualClock(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC))
+	cache := NewChannelCache(10, time.Hour, clock)
+	cache.Put(domain.ChannelInfo{ChannelID: "UC1", Title: "one"})
+
+	clock.Advance(59 * time.Minute)
+	if info, ok := cache.Get("UC1"); !ok || !info.FetchedAt.Equal(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Get before TTL = %+v, %v", info, ok)
+	}
+	clock.Advance(time.Minute)
+	if _, ok := cache.Get("UC1"); ok {
+		t.Error("entry should expire after TTL")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expired entry should be removed on Get, Len = %d", cache.Len())
+	}
+}
+
+func TestChannelCache_DumpAndLoadFromMerge(t *testing.T) {
+	base := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := system.NewManualClock(base)
+	cache := NewChannelCache(10, time.Hour, clock)
+	cache.Put(domain.ChannelInfo{ChannelID: "UC1", Title: "fresh", FetchedAt: base})
+
+	cache.LoadFrom([]domain.ChannelInfo{
+		{ChannelID: "UC1", Title: "stale", FetchedAt: base.Add(-10 * time.Minute)}, // 既存より古い → 無視
+		{ChannelID: "UC2", Title: "two", Handle: "@two", FetchedAt: base.Add(-30 * time.Minute)},
+		{ChannelID: "UC3", Title: "expired", FetchedAt: base.Add(-2 * time.Hour)}, // 期限切れ → 無視
+	})
+
+	if info, _ := cache.Get("UC1"); info.Title != "fresh" {
+		t.Errorf("UC1 title = %q, want fresh (newer entry kept)", info.Title)
+	}
+	if info, ok := cache.Get("UC2"); !ok || info.Handle != "@two" {
+		t.Errorf("UC2 = %+v, %v", info, ok)
+	}
+	if _, ok := cache.Get("UC3"); ok {
+		t.Error("expired snapshot entry should not be loaded")
+	}
+
+	clock.Advance(45 * time.Minute) // UC2 (fetched -30m) は期限切れ
+	dump := cache.Dump()
+	if len(dump) != 1 || dump[0].ChannelID != "UC1" {
+		t.Errorf("Dump = %+v, want only UC1", dump)
+	}
+}
+
+// TestChannelCache_CursorsTakeIndependently はセッションごとの cursor が互いの差分を消費しないことを確認します
+func TestChannelCache_CursorsTakeIndependently(t *testing.T) {
+	cache := NewChannelCache(10, time.Hour, nil)
+	a, b := cache.NewCursor(), cache.NewCursor()
+	a.TakeChanges() // 記録を始める
+	b.TakeChanges()
+
+	cache.Put(domain.ChannelInfo{ChannelID: "UC1", Title: "one"})
+	cache.LoadFrom([]domain.ChannelInfo{{ChannelID: "UC2", Title: "two", FetchedAt: time.Now()}}) // 復元分は記録しない
+
+	if got := a.TakeChanges().Items; len(got) != 1 || got[0].ChannelID != "UC1" {
+		t.Errorf("cursor a = %+v, want UC1", got)
+	}
+	if got := b.TakeChanges().Items; len(got) != 1 || got[0].ChannelID != "UC1" {
+		t.Errorf("cursor b = %+v, want UC1 even after a took it", got)
+	}
+	if got := a.TakeChanges().Items; len(got) != 0 {
+		t.Errorf("cursor a after take = %+v, want empty", got)
+	}
+}
+
+func TestChannelCache_Concurrent(t *testing.T) {
+	cache := NewChannelCache(100, time.Hour, nil)
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				id := fmt.Sprintf("UC%d", (g*200+i)%150)
+				cache.Put(domain.ChannelInfo{ChannelID: id, Title: id})
+				cache.Get(id)
+				if i%50 == 0 {
+					cache.Dump()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if cache.Len() > 100 {
+		t.Errorf("Len = %d, want <= capacity", cache.Len())
+	}
+}
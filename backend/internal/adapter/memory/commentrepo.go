@@ -6,12 +6,15 @@ import (
 	"sync"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
 )
 
 // CommentRepo はコメントをメモリ内に保存するリポジトリです。
 type CommentRepo struct {
 	mu       sync.RWMutex
 	comments map[string]domain.Comment // ID -> Comment
+
+	changed changeSet[string] // journal 用: 前回の TakeChanges 以降に追加・更新されたコメントID
 }
 
 // NewCommentRepo は新しいCommentRepoを作成します。
@@ -32,6 +35,7 @@ func (r *CommentRepo) Add(comment domain.Comment) error {
 	}
 
 	r.comments[comment.ID] = comment
+	r.changed.mark(comment.ID)
 	return nil
 }
 
@@ -63,11 +67,27 @@ func (r *CommentRepo) SearchByKeywords(keywords []string) []domain.Comment {
 	return results
 }
 
+// ListSortedByPublishedAt は全コメントを時系列順（古い順）で返します
+func (r *CommentRepo) ListSortedByPublishedAt() []domain.Comment {
+	r.mu.RLock()
+	results := make([]domain.Comment, 0, len(r.comments))
+	for _, comment := range r.comments {
+		results = append(results, comment)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].PublishedAt.Before(results[j].PublishedAt)
+	})
+	return results
+}
+
 // Clear は全コメントを削除します
 func (r *CommentRepo) Clear() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.comments = make(map[string]domain.Comment)
+	r.changed.markReset()
 }
 
 // Count は保存されているコメント数を返します
@@ -77,6 +97,34 @@ func (r *CommentRepo) Count() int {
 	return len(r.comments)
 }
 
+// UpdateAuthor は channelID の全コメントの displayName / handle を更新し、変更件数を返します (空文字は更新しない)。
+func (r *CommentRepo) UpdateAuthor(channelID, displayName, handle string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	updated := 0
+	for id, c := range r.comments {
+		if c.ChannelID != channelID {
+			continue
+		}
+		changed := false
+		if displayName != "" && c.DisplayName != displayName {
+			c.DisplayName = displayName
+			changed = true
+		}
+		if handle != "" && c.Handle != handle {
+			c.Handle = handle
+			changed = true
+		}
+		if changed {
+			r.comments[id] = c
+			r.changed.mark(id)
+			updated++
+		}
+	}
+	return updated
+}
+
 // Dump は現在の全 Comment state を返します（snapshot 用）。
 func (r *CommentRepo) Dump() []domain.Comment {
 	r.mu.RLock()
@@ -98,4 +146,20 @@ func (r *CommentRepo) LoadFrom(comments []domain.Comment) {
 	for _, c := range comments {
 		r.comments[c.ID] = c
 	}
+	r.changed.markReset()
+}
+
+// TakeChanges は前回の呼び出し以降に追加・更新されたコメントを返します (snapshot の journal 用)。
+func (r *CommentRepo) TakeChanges() port.Changes[domain.Comment] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids, reset := r.changed.take()
+	comments := make([]domain.Comment, 0, len(ids))
+	for _, id := range ids {
+		if c, ok := r.comments[id]; ok {
+			comments = append(comments, c)
+		}
+	}
+	return port.Changes[domain.Comment]{Items: comments, Reset: reset}
 }
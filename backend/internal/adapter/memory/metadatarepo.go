@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// MetadataRepo は配信メタデータの変更履歴をメモリ内に保持するリポジトリです。
+type MetadataRepo struct {
+	mu        sync.RWMutex
+	revisions []domain.MetadataRevision // 古い順、最大 domain.MaxMetadataRevisions 件
+
+	added changeSet[domain.MetadataRevision] // journal 用: 前回の TakeChanges 以降に追加した履歴
+}
+
+// NewMetadataRepo は新しいMetadataRepoを作成します。
+func NewMetadataRepo() *MetadataRepo {
+	return &MetadataRepo{}
+}
+
+// Record は rev が直前の履歴と異なるときだけ追加し、追加したかを返します。
+func (r *MetadataRepo) Record(rev domain.MetadataRevision) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n := len(r.revisions); n > 0 && r.revisions[n-1].SameContent(rev) {
+		return false
+	}
+	r.revisions = append(r.revisions, rev)
+	r.added.mark(rev)
+	if len(r.revisions) > domain.MaxMetadataRevisions {
+		r.revisions = slices.Clone(r.revisions[len(r.revisions)-domain.MaxMetadataRevisions:])
+	}
+	return true
+}
+
+// Revisions は履歴を古い順で返します。
+func (r *MetadataRepo) Revisions() []domain.MetadataRevision {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]domain.MetadataRevision{}, r.revisions...)
+}
+
+// Clear は全履歴を削除します。
+func (r *MetadataRepo) Clear() {
+	r.mu.Lock()
+	r.revisions = nil
+	r.added.markReset()
+	r.mu.Unlock()
+}
+
+// Dump は現在の全履歴を返します（snapshot 用）。
+func (r *MetadataRepo) Dump() []domain.MetadataRevision {
+	return r.Revisions()
+}
+
+// LoadFrom は snapshot から復元した履歴で上書きします。
+func (r *MetadataRepo) LoadFrom(revisions []domain.MetadataRevision) {
+	r.mu.Lock()
+	r.revisions = slices.Clone(revisions)
+	r.added.markReset()
+	r.mu.Unlock()
+}
+
+// TakeChanges は前回の呼び出し以降に追加した履歴を返します (snapshot の journal 用)。
+// 上限を超えて捨てた古い履歴は削除として扱わず、復元時に同じ上限で切り詰めます。
+func (r *MetadataRepo) TakeChanges() port.Changes[domain.MetadataRevision] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	revisions, reset := r.added.take()
+	return port.Changes[domain.MetadataRevision]{Items: revisions, Reset: reset}
+}
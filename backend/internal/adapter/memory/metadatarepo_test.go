@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+func TestMetadataRepo_RecordsOnlyChanges(t *testing.T) {
+	repo := NewMetadataRepo()
+	base := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	if !repo.Record(domain.MetadataRevision{At: base, Title: "【雑談】", DescriptionHash: "a"}) {
+		t.Fatal("first Record = false, want true")
+	}
+	if repo.Record(domain.MetadataRevision{At: base.Add(time.Minute), Title: "【雑談】", DescriptionHash: "a"}) {
+		t.Error("Record of unchanged metadata = true, want false")
+	}
+	if !repo.Record(domain.MetadataRevision{At: base.Add(2 * time.Minute), Title: "【歌枠】", DescriptionHash: "a"}) {
+		t.Error("Record of changed title = false, want true")
+	}
+	revs := repo.Revisions()
+	if len(revs) != 2 || revs[1].Title != "【歌枠】" || !revs[1].At.Equal(base.Add(2*time.Minute)) {
+		t.Errorf("revisions = %+v", revs)
+	}
+
+	for i := range domain.MaxMetadataRevisions + 5 {
+		repo.Record(domain.MetadataRevision{At: base.Add(time.Duration(i) * time.Hour), DescriptionHash: string(rune('a' + i%2))})
+	}
+	if got := len(repo.Revisions()); got != domain.MaxMetadataRevisions {
+		t.Errorf("len(revisions) = %d, want capped at %d", got, domain.MaxMetadataRevisions)
+	}
+
+	repo.Clear()
+	if got := repo.Revisions(); got == nil || len(got) != 0 {
+		t.Errorf("Revisions() after Clear = %v, want empty slice", got)
+	}
+}
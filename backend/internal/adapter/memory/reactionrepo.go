@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// ReactionRepo はリアクションの分単位カウンタをメモリ内に保持するリポジトリです。
+type ReactionRepo struct {
+	mu      sync.RWMutex
+	minutes map[int64]map[string]int // unix minute -> bucket -> count
+
+	changed changeSet[int64] // journal 用: 前回の TakeChanges 以降に加算された unix minute
+}
+
+// NewReactionRepo は新しいReactionRepoを作成します。
+func NewReactionRepo() *ReactionRepo {
+	return &ReactionRepo{minutes: make(map[int64]map[string]int)}
+}
+
+// Record は at を分単位に切り捨てた枠の各 bucket を 1 加算します。
+func (r *ReactionRepo) Record(at time.Time, buckets []string) {
+	if len(buckets) == 0 {
+		return
+	}
+	key := at.Unix() / 60
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts, ok := r.minutes[key]
+	if !ok {
+		counts = make(map[string]int, len(buckets))
+		r.minutes[key] = counts
+	}
+	for _, b := range buckets {
+		counts[b]++
+	}
+	r.changed.mark(key)
+}
+
+// Timeline は分単位カウンタを時系列順（古い順）で返します。
+func (r *ReactionRepo) Timeline() []domain.ReactionMinute {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]int64, 0, len(r.minutes))
+	for k := range r.minutes {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	timeline := make([]domain.ReactionMinute, 0, len(keys))
+	for _, k := range keys {
+		counts := make(map[string]int, len(r.minutes[k]))
+		for b, n := range r.minutes[k] {
+			counts[b] = n
+		}
+		timeline = append(timeline, domain.ReactionMinute{Minute: time.Unix(k*60, 0).UTC(), Counts: counts})
+	}
+	return timeline
+}
+
+// Clear は全カウンタを削除します。
+func (r *ReactionRepo) Clear() {
+	r.mu.Lock()
+	r.minutes = make(map[int64]map[string]int)
+	r.changed.markReset()
+	r.mu.Unlock()
+}
+
+// Dump は現在の全カウンタを返します（snapshot 用）。
+func (r *ReactionRepo) Dump() []domain.ReactionMinute {
+	return r.Timeline()
+}
+
+// LoadFrom は snapshot から復元したカウンタで上書きします。
+func (r *ReactionRepo) LoadFrom(minutes []domain.ReactionMinute) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.minutes = make(map[int64]map[string]int, len(minutes))
+	for _, m := range minutes {
+		counts := make(map[string]int, len(m.Counts))
+		for b, n := range m.Counts {
+			counts[b] = n
+		}
+		r.minutes[m.Minute.Unix()/60] = counts
+	}
+	r.changed.markReset()
+}
+
+// TakeChanges は前回の呼び出し以降に加算された分のカウンタ (取り出し時点の値) を返します (snapshot の journal 用)。
+func (r *ReactionRepo) TakeChanges() port.Changes[domain.ReactionMinute] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys, reset := r.changed.take()
+	minutes := make([]domain.ReactionMinute, 0, len(keys))
+	for _, k := range keys {
+		if counts, ok := r.minutes[k]; ok {
+			minutes = append(minutes, domain.ReactionMinute{Minute: time.Unix(k*60, 0).UTC(), Counts: maps.Clone(counts)})
+		}
+	}
+	return port.Changes[domain.ReactionMinute]{Items: minutes, Reset: reset}
+}
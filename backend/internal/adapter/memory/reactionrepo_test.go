@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+func TestReactionRepo_RecordAndTimeline(t *testing.T) {
+	repo := NewReactionRepo()
+	base := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	repo.Record(base.Add(90*time.Second), []string{"laugh"})
+	repo.Record(base.Add(10*time.Second), []string{"laugh", "applause"})
+	repo.Record(base.Add(50*time.Second), []string{"laugh"})
+	repo.Record(base.Add(70*time.Second), nil) // 該当なしは無視
+
+	timeline := repo.Timeline()
+	if len(timeline) != 2 {
+		t.Fatalf("len(timeline) = %d, want 2: %+v", len(timeline), timeline)
+	}
+	if !timeline[0].Minute.Equal(base) || timeline[0].Counts["laugh"] != 2 || timeline[0].Counts["applause"] != 1 {
+		t.Errorf("timeline[0] = %+v", timeline[0])
+	}
+	if !timeline[1].Minute.Equal(base.Add(time.Minute)) || timeline[1].Counts["laugh"] != 1 {
+		t.Errorf("timeline[1] = %+v", timeline[1])
+	}
+
+	repo.Clear()
+	if got := repo.Timeline(); got == nil || len(got) != 0 {
+		t.Errorf("Timeline() after Clear = %v, want empty slice", got)
+	}
+}
+
+func TestReactionRepo_DumpLoadFrom(t *testing.T) {
+	src := NewReactionRepo()
+	at := time.Date(2026, 6, 1, 12, 3, 20, 0, time.UTC)
+	src.Record(at, []string{"kawaii"})
+
+	dst := NewReactionRepo()
+	dst.Record(at.Add(time.Hour), []string{"laugh"}) // LoadFrom で上書きされる
+	dst.LoadFrom(src.Dump())
+
+	got := dst.Timeline()
+	want := []domain.ReactionMinute{{Minute: at.Truncate(time.Minute), Counts: map[string]int{"kawaii": 1}}}
+	if len(got) != 1 || !got[0].Minute.Equal(want[0].Minute) || got[0].Counts["kawaii"] != 1 || len(got[0].Counts) != 1 {
+		t.Errorf("Timeline() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// DefaultReservationKey は既定の予約キューの StateBlobStore 上の保存 key です。
+const DefaultReservationKey = "reservation-queue"
+
+// ReservationRepo は予約キューをメモリに保持し、store があれば保存のたびに永続化します。
+type ReservationRepo struct {
+	store port.StateBlobStore // nil なら永続化しない
+	key   string
+
+	writeMu sync.Mutex // Save / Update の読み出し〜永続化を直列化する
+	mu      sync.RWMutex
+	list    []domain.Reservation
+}
+
+// NewReservationRepo は store の key に永続化する ReservationRepo を返します (store は nil 可)。
+func NewReservationRepo(store port.StateBlobStore, key string) *ReservationRepo {
+	return &ReservationRepo{store: store, key: key}
+}
+
+// Load は永続化済みの予約キューを復元します (起動時用)。
+func (r *ReservationRepo) Load(ctx context.Context) error {
+	if r.store == nil {
+		return nil
+	}
+	data, err := r.store.LoadBlob(ctx, r.key)
+	if err != nil {
+		return fmt.Errorf("reservations: load: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+	var list []domain.Reservation
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("reservations: unmarshal: %w", err)
+	}
+	r.mu.Lock()
+	r.list = list
+	r.mu.Unlock()
+	return nil
+}
+
+// List は予約キューを並び順で返します。
+func (r *ReservationRepo) List(_ context.Context) ([]domain.Reservation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.list == nil {
+		return []domain.Reservation{}, nil
+	}
+	return slices.Clone(r.list), nil
+}
+
+// Save は予約キューを置き換え、store があれば永続化します。永続化に失敗してもメモリ上は更新済みです。
+func (r *ReservationRepo) Save(ctx context.Context, list []domain.Reservation) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	return r.saveLocked(ctx, list)
+}
+
+// Update は現在の予約キューを fn で置き換えて保存します (port.ReservationRepo)。
+func (r *ReservationRepo) Update(ctx context.Context, fn func(list []domain.Reservation) ([]domain.Reservation, error)) ([]domain.Reservation, error) {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	cur, _ := r.List(ctx)
+	next, err := fn(cur)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.saveLocked(ctx, next); err != nil {
+		return next, err
+	}
+	return slices.Clone(next), nil
+}
+
+// saveLocked は Save / Update の本体です。r.writeMu を保持して呼ぶこと。
+func (r *ReservationRepo) saveLocked(ctx context.Context, list []domain.Reservation) error {
+	r.mu.Lock()
+	r.list = slices.Clone(list)
+	r.mu.Unlock()
+	if r.store == nil {
+		return nil
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("reservations: marshal: %w", err)
+	}
+	if err := r.store.SaveBlob(ctx, r.key, data); err != nil {
+		return fmt.Errorf("reservations: save: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+func TestReservationRepo_PersistsAcrossRestart(t *testing.T) {
+	ctx := context.Background()
+	store := NewBlobStore()
+	at := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	list := []domain.Reservation{
+		{VideoID: "a", ScheduledStartTime: at, Status: domain.ReservationQueued},
+		{VideoID: "b", Status: domain.ReservationCanceled},
+	}
+	if err := NewReservationRepo(store, DefaultReservationKey).Save(ctx, list); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewReservationRepo(store, DefaultReservationKey)
+	if err := restored.Load(ctx); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := restored.List(ctx)
+	if len(got) != 2 || got[0].VideoID != "a" || !got[0].ScheduledStartTime.Equal(at) || got[1].Status != domain.ReservationCanceled {
+		t.Errorf("restored = %+v, want the saved queue", got)
+	}
+
+	// 別 key のキューは独立している
+	other := NewReservationRepo(store, DefaultReservationKey+"-x")
+	_ = other.Load(ctx)
+	if got, _ := other.List(ctx); len(got) != 0 {
+		t.Errorf("other key = %+v, want empty", got)
+	}
+}
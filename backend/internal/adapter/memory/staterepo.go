@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
@@ -23,7 +24,23 @@ func (r *StateRepo) Get(ctx context.Context) (domain.LiveState, error) {
 
 func (r *StateRepo) Set(ctx context.Context, st domain.LiveState) error {
 	r.mu.Lock()
+	st.Version = r.cur.Version + 1
 	r.cur = st
 	r.mu.Unlock()
 	return nil
 }
+
+// CompareAndSet は st.Version が現在の Version と一致するときだけ保存します。
+func (r *StateRepo) CompareAndSet(ctx context.Context, st domain.LiveState) (domain.LiveState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if st.Version != r.cur.Version {
+		return r.cur, &domain.APIError{
+			Code:    domain.ErrCodeConflict,
+			Message: fmt.Sprintf("live state changed concurrently (version %d, now %d)", st.Version, r.cur.Version),
+		}
+	}
+	st.Version++
+	r.cur = st
+	return st, nil
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+func TestStateRepo_VersionAdvancesOnEveryWrite(t *testing.T) {
+	ctx := context.Background()
+	r := NewStateRepo()
+
+	_ = r.Set(ctx, domain.LiveState{Status: domain.StatusActive, Version: 99}) // 呼び出し側の Version は無視
+	st, _ := r.Get(ctx)
+	if st.Version != 1 {
+		t.Fatalf("Version after Set = %d, want 1", st.Version)
+	}
+
+	st.NextPageToken = "tok"
+	saved, err := r.CompareAndSet(ctx, st)
+	if err != nil || saved.Version != 2 {
+		t.Fatalf("CompareAndSet = %+v, %v", saved, err)
+	}
+}
+
+func TestStateRepo_CompareAndSetRejectsStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	r := NewStateRepo()
+	_ = r.Set(ctx, domain.LiveState{Status: domain.StatusActive})
+
+	stale, _ := r.Get(ctx)
+	_ = r.Set(ctx, domain.LiveState{Status: domain.StatusWaiting}) // Reset が割り込む
+
+	stale.NextPageToken = "tok"
+	_, err := r.CompareAndSet(ctx, stale)
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeConflict {
+		t.Fatalf("err = %v, want conflict", err)
+	}
+	if cur, _ := r.Get(ctx); cur.Status != domain.StatusWaiting || cur.NextPageToken != "" {
+		t.Errorf("stale write must not land: %+v", cur)
+	}
+}
+
+func TestStateRepo_ConcurrentCompareAndSetOneWinner(t *testing.T) {
+	ctx := context.Background()
+	r := NewStateRepo()
+	_ = r.Set(ctx, domain.LiveState{})
+	base, _ := r.Get(ctx)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	wins := 0
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := r.CompareAndSet(ctx, base); err == nil {
+				mu.Lock()
+				wins++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if wins != 1 {
+		t.Errorf("wins = %d, want exactly 1", wins)
+	}
+}
@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -13,6 +14,9 @@ type UserRepo struct {
 	mu            sync.RWMutex
 	usersByID     map[string]domain.User // channelID -> User with join time
 	processedMsgs map[string]bool        // messageID -> processed flag for deduplication
+
+	changedUsers changeSet[string] // journal 用: 前回の TakeChanges 以降に変わった channelID
+	changedMsgs  changeSet[string] // journal 用: 前回の TakeChanges 以降に処理済みになった messageID
 }
 
 func NewUserRepo() *UserRepo {
@@ -33,6 +37,8 @@ func (r *UserRepo) Clear() {
 	r.mu.Lock()
 	r.usersByID = make(map[string]domain.User)
 	r.processedMsgs = make(map[string]bool)
+	r.changedUsers.markReset()
+	r.changedMsgs.markReset()
 	r.mu.Unlock()
 }
 
@@ -59,6 +65,7 @@ func (r *UserRepo) UpsertWithJoinTime(channelID string, displayName string, join
 			LatestCommentedAt: joinedAt, // 最新コメント時刻（初回なので同じ）
 		}
 	}
+	r.changedUsers.mark(channelID)
 
 	return nil
 }
@@ -95,6 +102,8 @@ func (r *UserRepo) UpsertWithMessageUpdated(channelID string, displayName string
 
 	// メッセージIDを処理済みとして記録
 	r.processedMsgs[messageID] = true
+	r.changedUsers.mark(channelID)
+	r.changedMsgs.mark(messageID)
 
 	return true, nil
 }
@@ -105,6 +114,40 @@ func (r *UserRepo) UpsertWithMessage(channelID string, displayName string, joine
 	return err
 }
 
+// UpdateProfile は channelID のユーザーの displayName / handle を更新します (空文字は更新しない)。
+func (r *UserRepo) UpdateProfile(channelID, displayName, handle string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.usersByID[channelID]
+	if !exists {
+		return false
+	}
+	if displayName != "" {
+		user.DisplayName = displayName
+	}
+	if handle != "" {
+		user.Handle = handle
+	}
+	r.usersByID[channelID] = user
+	r.changedUsers.mark(channelID)
+	return true
+}
+
+// RecordStream は channelID のユーザーの Streams に videoID を追加します (記録済みなら何もしない)。
+func (r *UserRepo) RecordStream(channelID, videoID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.usersByID[channelID]
+	if !exists || slices.Contains(user.Streams, videoID) {
+		return
+	}
+	user.Streams = append(slices.Clone(user.Streams), videoID)
+	r.usersByID[channelID] = user
+	r.changedUsers.mark(channelID)
+}
+
 // Dump は現在の全 User state と処理済みメッセージID一覧を返します（snapshot 用）。
 func (r *UserRepo) Dump() port.UserSnapshot {
 	r.mu.RLock()
@@ -137,6 +180,24 @@ func (r *UserRepo) LoadFrom(snap port.UserSnapshot) {
 	for _, id := range snap.ProcessedMsgs {
 		r.processedMsgs[id] = true
 	}
+	r.changedUsers.markReset()
+	r.changedMsgs.markReset()
+}
+
+// TakeChanges は前回の呼び出し以降に追加・更新されたユーザーと処理済みメッセージIDを返します (snapshot の journal 用)。
+func (r *UserRepo) TakeChanges() port.UserChanges {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids, resetUsers := r.changedUsers.take()
+	msgs, resetMsgs := r.changedMsgs.take()
+	users := make([]domain.User, 0, len(ids))
+	for _, id := range ids {
+		if u, ok := r.usersByID[id]; ok {
+			users = append(users, u)
+		}
+	}
+	return port.UserChanges{Users: users, ProcessedMsgs: msgs, Reset: resetUsers || resetMsgs}
 }
 
 // ListUsersSortedByJoinTime は User構造体の配列を参加時間順（早い順）で返します。
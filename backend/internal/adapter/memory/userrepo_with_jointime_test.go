@@ -298,3 +298,34 @@ func TestUserRepo_UpsertWithMessage_LatestCommentedAt(t *testing.T) {
 		t.Errorf("Expected FirstCommentedAt to remain %v, got %v", firstTime, user.FirstCommentedAt)
 	}
 }
+
+// TestUserRepo_TakeChanges は TakeChanges が前回以降に変わったユーザーと処理済みメッセージIDだけを返すことを確認します
+func TestUserRepo_TakeChanges(t *testing.T) {
+	repo := NewUserRepo()
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	_, _ = repo.UpsertWithMessageUpdated("UC1", "User1", now, "m1")
+
+	// 最初の呼び出しまでは記録しない
+	if changes := repo.TakeChanges(); len(changes.Users) != 0 || changes.Reset {
+		t.Errorf("first TakeChanges = %+v, want empty", changes)
+	}
+
+	_, _ = repo.UpsertWithMessageUpdated("UC2", "User2", now, "m2")
+	_, _ = repo.UpsertWithMessageUpdated("UC2", "User2", now.Add(time.Minute), "m3")
+	repo.UpdateProfile("UC1", "", "@user1")
+	changes := repo.TakeChanges()
+	if len(changes.Users) != 2 || len(changes.ProcessedMsgs) != 2 || changes.Reset {
+		t.Fatalf("TakeChanges = %+v, want UC2 and UC1 with m2 / m3", changes)
+	}
+	if changes.Users[0].ChannelID != "UC2" || changes.Users[0].CommentCount != 2 || changes.Users[1].Handle != "@user1" {
+		t.Errorf("users = %+v, want the values at take time", changes.Users)
+	}
+	if changes := repo.TakeChanges(); len(changes.Users) != 0 || len(changes.ProcessedMsgs) != 0 {
+		t.Errorf("TakeChanges after take = %+v, want empty", changes)
+	}
+
+	repo.Clear()
+	if changes := repo.TakeChanges(); !changes.Reset {
+		t.Errorf("TakeChanges after Clear = %+v, want Reset", changes)
+	}
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// ViewerRepo は視聴者数のサンプルをメモリ内に保持するリポジトリです。
+type ViewerRepo struct {
+	mu      sync.RWMutex
+	samples []domain.ViewerSample // At の古い順
+
+	added changeSet[domain.ViewerSample] // journal 用: 前回の TakeChanges 以降に追加したサンプル
+}
+
+// NewViewerRepo は新しいViewerRepoを作成します。
+func NewViewerRepo() *ViewerRepo {
+	return &ViewerRepo{}
+}
+
+// Record はサンプルを追加します。At が前後しても時系列順を保ちます。
+func (r *ViewerRepo) Record(sample domain.ViewerSample) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := len(r.samples)
+	for i > 0 && r.samples[i-1].At.After(sample.At) {
+		i--
+	}
+	r.samples = slices.Insert(r.samples, i, sample)
+	r.added.mark(sample)
+}
+
+// Samples はサンプルを時系列順（古い順）で返します。
+func (r *ViewerRepo) Samples() []domain.ViewerSample {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]domain.ViewerSample{}, r.samples...)
+}
+
+// Clear は全サンプルを削除します。
+func (r *ViewerRepo) Clear() {
+	r.mu.Lock()
+	r.samples = nil
+	r.added.markReset()
+	r.mu.Unlock()
+}
+
+// Dump は現在の全サンプルを返します（snapshot 用）。
+func (r *ViewerRepo) Dump() []domain.ViewerSample {
+	return r.Samples()
+}
+
+// LoadFrom は snapshot から復元したサンプルで上書きします。
+func (r *ViewerRepo) LoadFrom(samples []domain.ViewerSample) {
+	sorted := slices.Clone(samples)
+	slices.SortStableFunc(sorted, func(a, b domain.ViewerSample) int { return a.At.Compare(b.At) })
+	r.mu.Lock()
+	r.samples = sorted
+	r.added.markReset()
+	r.mu.Unlock()
+}
+
+// TakeChanges は前回の呼び出し以降に追加したサンプルを返します (snapshot の journal 用)。
+func (r *ViewerRepo) TakeChanges() port.Changes[domain.ViewerSample] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	samples, reset := r.added.take()
+	return port.Changes[domain.ViewerSample]{Items: samples, Reset: reset}
+}
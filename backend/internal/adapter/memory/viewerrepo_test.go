@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+func TestViewerRepo_RecordKeepsOrderAndRestores(t *testing.T) {
+	repo := NewViewerRepo()
+	base := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	if got := repo.Samples(); got == nil || len(got) != 0 {
+		t.Fatalf("Samples() on empty repo = %v, want empty slice", got)
+	}
+	repo.Record(domain.ViewerSample{At: base.Add(2 * time.Minute), ConcurrentViewers: 30})
+	repo.Record(domain.ViewerSample{At: base, ConcurrentViewers: 10})
+	repo.Record(domain.ViewerSample{At: base.Add(time.Minute), ConcurrentViewers: 20})
+
+	samples := repo.Samples()
+	if len(samples) != 3 || samples[0].ConcurrentViewers != 10 || samples[2].ConcurrentViewers != 30 {
+		t.Fatalf("samples = %+v, want ordered by At", samples)
+	}
+
+	restored := NewViewerRepo()
+	restored.LoadFrom([]domain.ViewerSample{samples[2], samples[0], samples[1]})
+	if got := restored.Dump(); len(got) != 3 || !got[0].At.Equal(base) || !got[2].At.Equal(base.Add(2*time.Minute)) {
+		t.Errorf("restored = %+v, want ordered by At", got)
+	}
+
+	repo.Clear()
+	if got := repo.Samples(); len(got) != 0 {
+		t.Errorf("Samples() after Clear = %v, want empty", got)
+	}
+}
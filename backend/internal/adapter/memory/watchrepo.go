@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// DefaultWatchKey は既定のチャンネル監視一覧の StateBlobStore 上の保存 key です。
+const DefaultWatchKey = "channel-watches"
+
+// WatchRepo はチャンネル監視一覧をメモリに保持し、store があれば保存のたびに永続化します。
+type WatchRepo struct {
+	store port.StateBlobStore // nil なら永続化しない
+	key   string
+
+	writeMu sync.Mutex // Save / Update の読み出し〜永続化を直列化する
+	mu      sync.RWMutex
+	list    []domain.ChannelWatch
+}
+
+// NewWatchRepo は store の key に永続化する WatchRepo を返します (store は nil 可)。
+func NewWatchRepo(store port.StateBlobStore, key string) *WatchRepo {
+	return &WatchRepo{store: store, key: key}
+}
+
+// Load は永続化済みの監視一覧を復元します (起動時用)。
+func (r *WatchRepo) Load(ctx context.Context) error {
+	if r.store == nil {
+		return nil
+	}
+	data, err := r.store.LoadBlob(ctx, r.key)
+	if err != nil {
+		return fmt.Errorf("watches: load: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+	var list []domain.ChannelWatch
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("watches: unmarshal: %w", err)
+	}
+	r.mu.Lock()
+	r.list = list
+	r.mu.Unlock()
+	return nil
+}
+
+// List は監視中のチャンネルを登録順で返します。
+func (r *WatchRepo) List(_ context.Context) ([]domain.ChannelWatch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.list == nil {
+		return []domain.ChannelWatch{}, nil
+	}
+	return slices.Clone(r.list), nil
+}
+
+// Save は監視一覧を置き換え、store があれば永続化します。永続化に失敗してもメモリ上は更新済みです。
+func (r *WatchRepo) Save(ctx context.Context, list []domain.ChannelWatch) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	return r.saveLocked(ctx, list)
+}
+
+// Update は現在の監視一覧を fn で置き換えて保存します (port.ChannelWatchRepo)。
+func (r *WatchRepo) Update(ctx context.Context, fn func(list []domain.ChannelWatch) ([]domain.ChannelWatch, error)) ([]domain.ChannelWatch, error) {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	cur, _ := r.List(ctx)
+	next, err := fn(cur)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.saveLocked(ctx, next); err != nil {
+		return next, err
+	}
+	return slices.Clone(next), nil
+}
+
+// saveLocked は Save / Update の本体です。r.writeMu を保持して呼ぶこと。
+func (r *WatchRepo) saveLocked(ctx context.Context, list []domain.ChannelWatch) error {
+	r.mu.Lock()
+	r.list = slices.Clone(list)
+	r.mu.Unlock()
+	if r.store == nil {
+		return nil
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("watches: marshal: %w", err)
+	}
+	if err := r.store.SaveBlob(ctx, r.key, data); err != nil {
+		return fmt.Errorf("watches: save: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+func TestWatchRepo_PersistsAcrossRestart(t *testing.T) {
+	ctx := context.Background()
+	store := NewBlobStore()
+	at := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	list := []domain.ChannelWatch{
+		{Channel: "@alice", AddedAt: at, LastVideoID: "v1"},
+		{Channel: "UCbob", AddedAt: at},
+	}
+	if err := NewWatchRepo(store, DefaultWatchKey).Save(ctx, list); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewWatchRepo(store, DefaultWatchKey)
+	if err := restored.Load(ctx); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := restored.List(ctx)
+	if len(got) != 2 || got[0].Channel != "@alice" || got[0].LastVideoID != "v1" || !got[1].AddedAt.Equal(at) {
+		t.Errorf("restored = %+v, want the saved watches", got)
+	}
+}
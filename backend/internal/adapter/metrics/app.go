@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	stdhttp "net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// アプリケーションが公開するメトリクス一覧。各層はこれらを直接更新する。
+var (
+	// HTTP (http.LoggingMiddleware)
+	HTTPRequests = Default.NewCounterVec("http_requests_total",
+		"HTTP requests by method, chi route pattern and status code.", "method", "route", "status")
+	HTTPRequestDuration = Default.NewHistogramVec("http_request_duration_seconds",
+		"HTTP request latency by method and chi route pattern.", nil, "method", "route")
+
+	// YouTube Data API (youtube.API)
+	YouTubeCalls = Default.NewCounterVec("youtube_api_calls_total",
+		"YouTube Data API calls by API method and result code (ok or domain.APIErrorCode, error for unclassified).", "method", "code")
+	YouTubeRetries = Default.NewCounterVec("youtube_api_retries_total",
+		"YouTube Data API retries after transient errors by API method.", "method")
+	YouTubeQuotaUnits = Default.NewCounterVec("youtube_api_quota_units_total",
+		"Estimated YouTube Data API quota units consumed by API method.", "method")
+
+	// Pull usecase
+	PullMessages = Default.NewCounterVec("pull_messages_total",
+		"Chat messages processed by Pull (result=added|skipped).", "result")
+	PullCoalesced = Default.NewCounterVec("pull_coalesced_total",
+		"Pull requests that shared an in-flight Pull instead of starting a new one (usecase.PullScheduler).")
+
+	// Monitor
+	MonitorTicks = Default.NewCounterVec("monitor_ticks_total",
+		"Monitor tick outcomes by action (the step the monitor ran) and outcome (ok|error).", "action", "outcome")
+
+	// Snapshot coordinator
+	SnapshotSaveDuration = Default.NewHistogramVec("snapshot_save_duration_seconds",
+		"Snapshot save latency (sink.Save + SaveCurrent).", nil)
+	SnapshotSaveFailures = Default.NewCounterVec("snapshot_save_failures_total",
+		"Snapshot save failures.")
+	SnapshotJournalWrites = Default.NewCounterVec("snapshot_journal_writes_total",
+		"Snapshot journal chunks appended between compactions (result=ok|error).", "result")
+)
+
+// RegisterRepoSizes は in-memory repo の件数を gauge として登録します (main で 1 回呼ぶ)。
+// users / comments は全セッションの合計を返す関数を渡します。
+func RegisterRepoSizes(users, comments func() int) {
+	Default.NewGaugeFunc("repo_users", "Users currently held in memory.", func() float64 { return float64(users()) })
+	Default.NewGaugeFunc("repo_comments", "Comments currently held in memory.", func() float64 { return float64(comments()) })
+}
+
+// Handler は Default registry を Prometheus の exposition format で返す handler です。
+func Handler() stdhttp.Handler {
+	return promhttp.HandlerFor(Default.Gatherer(), promhttp.HandlerOpts{})
+}
@@ -0,0 +1,129 @@
+// Package metrics はアプリケーションの counter / histogram / gauge を Prometheus client
+// (github.com/prometheus/client_golang) で保持し、text exposition format で出力する。
+// logging と同様にパッケージレベルの Default registry を各層から直接更新する。
+package metrics
+
+import (
+	"io"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// DefaultDurationBuckets は処理時間 (秒) 用の既定 bucket 境界です。
+var DefaultDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Registry はメトリクスを保持する Prometheus registry です。
+type Registry struct {
+	reg *prometheus.Registry
+
+	mu     sync.Mutex
+	gauges map[string]prometheus.Collector // NewGaugeFunc で登録した gauge (同名の再登録で置き換えるため)
+}
+
+// NewRegistry は空の Registry を作成します。
+func NewRegistry() *Registry {
+	return &Registry{reg: prometheus.NewRegistry(), gauges: make(map[string]prometheus.Collector)}
+}
+
+// Default はアプリケーション全体で共有する registry です (/metrics が出力する対象)。
+var Default = NewRegistry()
+
+// Gatherer は登録済みメトリクスの収集元を返します (/metrics の handler 用)。
+func (r *Registry) Gatherer() prometheus.Gatherer { return r.reg }
+
+// WriteText は登録済みメトリクスを Prometheus text format で w に書き出します。
+func (r *Registry) WriteText(w io.Writer) error {
+	families, err := r.reg.Gather()
+	if err != nil {
+		return err
+	}
+	enc := expfmt.NewEncoder(w, expfmt.NewFormat(expfmt.TypeTextPlain))
+	for _, mf := range families {
+		if err := enc.Encode(mf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CounterVec は label 組ごとに単調増加する値を保持します。
+type CounterVec struct {
+	vec *prometheus.CounterVec
+}
+
+// NewCounterVec は counter を作成して r に登録します。
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	vec := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)
+	r.reg.MustRegister(vec)
+	return &CounterVec{vec: vec}
+}
+
+// Inc は label 組の値を 1 加算します。labelValues は登録時の labels と同じ順・同じ個数で渡します。
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.vec.WithLabelValues(labelValues...).Inc()
+}
+
+// Add は label 組の値を v 加算します (負の値は無視します)。
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		return
+	}
+	c.vec.WithLabelValues(labelValues...).Add(v)
+}
+
+// Value は label 組の現在値を返します (テスト用)。
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	var m dto.Metric
+	if err := c.vec.WithLabelValues(labelValues...).Write(&m); err != nil {
+		return 0
+	}
+	return m.GetCounter().GetValue()
+}
+
+// HistogramVec は label 組ごとに観測値の分布 (bucket / sum / count) を保持します。
+type HistogramVec struct {
+	vec *prometheus.HistogramVec
+}
+
+// NewHistogramVec は histogram を作成して r に登録します。buckets は昇順で渡します (nil は DefaultDurationBuckets)。
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultDurationBuckets
+	}
+	vec := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labels)
+	r.reg.MustRegister(vec)
+	return &HistogramVec{vec: vec}
+}
+
+// Observe は label 組に観測値 v を記録します。
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	h.vec.WithLabelValues(labelValues...).Observe(v)
+}
+
+// Count は label 組の観測数を返します (テスト用)。
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	metric, ok := h.vec.WithLabelValues(labelValues...).(prometheus.Metric)
+	if !ok {
+		return 0
+	}
+	var m dto.Metric
+	if err := metric.Write(&m); err != nil {
+		return 0
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+// NewGaugeFunc は出力時に fn を呼んで現在値を取得する gauge (repo 件数など) を r に登録します。同名の gauge は置き換えます。
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) {
+	g := prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: name, Help: help}, fn)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.gauges[name]; ok {
+		r.reg.Unregister(old)
+	}
+	r.reg.MustRegister(g)
+	r.gauges[name] = g
+}
@@ -0,0 +1,60 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/metrics"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+	r := metrics.NewRegistry()
+	c := r.NewCounterVec("calls_total", "API calls.", "method", "code")
+	c.Inc("videos.list", "ok")
+	c.Add(2, "videos.list", "ok")
+	c.Inc("channels.list", `quota"exceeded`)
+	c.Add(-1, "videos.list", "ok") // 負の値は無視
+
+	h := r.NewHistogramVec("save_seconds", "Save latency.", []float64{0.1, 1})
+	h.Observe(0.05)
+	h.Observe(0.5)
+	h.Observe(3)
+
+	r.NewGaugeFunc("repo_users", "Users.", func() float64 { return 1 })
+	r.NewGaugeFunc("repo_users", "Users.", func() float64 { return 42 }) // 同名は置き換える
+
+	var b strings.Builder
+	if err := r.WriteText(&b); err != nil {
+		t.Fatalf("WriteText error: %v", err)
+	}
+	want := `# HELP calls_total API calls.
+# TYPE calls_total counter
+calls_total{code="ok",method="videos.list"} 3
+calls_total{code="quota\"exceeded",method="channels.list"} 1
+# HELP repo_users Users.
+# TYPE repo_users gauge
+repo_users 42
+# HELP save_seconds Save latency.
+# TYPE save_seconds histogram
+save_seconds_bucket{le="0.1"} 1
+save_seconds_bucket{le="1"} 2
+save_seconds_bucket{le="+Inf"} 3
+save_seconds_sum 3.55
+save_seconds_count 3
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteText output mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCounterVec_Value(t *testing.T) {
+	r := metrics.NewRegistry()
+	c := r.NewCounterVec("x_total", "x.", "result")
+	c.Inc("added")
+	if got := c.Value("added"); got != 1 {
+		t.Errorf("Value(added) = %v, want 1", got)
+	}
+	if got := c.Value("skipped"); got != 0 {
+		t.Errorf("Value(skipped) = %v, want 0", got)
+	}
+}
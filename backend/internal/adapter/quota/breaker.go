@@ -0,0 +1,167 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// rate limit で open にしたときの待ち時間。連続して rate limit になるたびに倍にします。
+const (
+	rateLimitBackoffMin = 1 * time.Minute
+	rateLimitBackoffMax = 30 * time.Minute
+)
+
+// Breaker は port.YouTubePort をラップする circuit breaker です。
+// API が quota 超過・rate limit を返したら open にして呼び出しを止め (API を叩かずに同じエラーコードを返す)、
+// RetryAt (quota 超過なら太平洋時間 0:00 のリセット、rate limit なら指数 backoff) を過ぎたら
+// 1 回だけ試行 (half-open) し、成功すれば closed に戻します。
+// 予算管理による拒否で open にならないよう、Client (Ledger) の内側で実 API を直接ラップしてください。
+type Breaker struct {
+	inner port.YouTubePort
+	clock port.Clock
+
+	mu         sync.Mutex
+	state      domain.BreakerState
+	reason     domain.APIErrorCode
+	message    string
+	openedAt   time.Time
+	retryAt    time.Time
+	probing    bool // half-open の試行中 (他の呼び出しは open と同じく拒否する)
+	rateStreak int  // 連続した rate limit による open の回数 (backoff 計算用)
+	trips      int
+}
+
+// NewBreaker は inner を circuit breaker 付きの YouTubePort にします。
+func NewBreaker(inner port.YouTubePort, clock port.Clock) *Breaker {
+	return &Breaker{inner: inner, clock: clock, state: domain.BreakerClosed}
+}
+
+var (
+	_ port.YouTubePort     = (*Breaker)(nil)
+	_ port.BreakerReporter = (*Breaker)(nil)
+)
+
+// BreakerStatus は現在の状態を返します。
+func (b *Breaker) BreakerStatus() domain.BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st := domain.BreakerStatus{State: b.state, Trips: b.trips}
+	if b.state == domain.BreakerClosed {
+		return st
+	}
+	openedAt, retryAt := b.openedAt, b.retryAt
+	st.Reason = b.reason
+	st.Message = b.message
+	st.OpenedAt = &openedAt
+	st.RetryAt = &retryAt
+	return st
+}
+
+// acquire は呼び出してよいかを判定します。RetryAt を過ぎていれば最初の 1 回を half-open の試行として通します。
+func (b *Breaker) acquire() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == domain.BreakerClosed {
+		return nil
+	}
+	if !b.probing && !b.clock.Now().Before(b.retryAt) {
+		b.state = domain.BreakerHalfOpen
+		b.probing = true
+		return nil
+	}
+	return &domain.APIError{
+		Code:    b.reason,
+		Message: fmt.Sprintf("circuit open: YouTube API paused until %s (%s)", b.retryAt.Format(time.RFC3339), b.message),
+	}
+}
+
+// record は呼び出し結果で状態を更新します。
+func (b *Breaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	probe := b.probing
+	b.probing = false
+
+	var apiErr *domain.APIError
+	switch {
+	case errors.As(err, &apiErr) && (apiErr.Code == domain.ErrCodeQuotaExceeded || apiErr.Code == domain.ErrCodeRateLimited):
+		b.trip(ctx, apiErr)
+	case probe && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)):
+		// 試行が結果を得られなかった: open に戻し、次の呼び出しで試行し直す
+		b.state = domain.BreakerOpen
+	case b.state != domain.BreakerClosed:
+		// API から quota / rate limit 以外の応答が返った = 復帰
+		logging.Log(ctx, "info", "YOUTUBE_API", "circuit closed: YouTube API reachable again (was %s since %s)", b.reason, b.openedAt.Format(time.RFC3339))
+		b.state = domain.BreakerClosed
+		b.reason = ""
+		b.message = ""
+		b.rateStreak = 0
+	}
+}
+
+// trip は apiErr で open にします。b.mu を保持して呼ぶこと。
+func (b *Breaker) trip(ctx context.Context, apiErr *domain.APIError) {
+	now := b.clock.Now()
+	if b.state == domain.BreakerClosed {
+		b.openedAt = now
+		b.trips++
+	}
+	b.state = domain.BreakerOpen
+	b.reason = apiErr.Code
+	b.message = apiErr.Message
+	if apiErr.Code == domain.ErrCodeQuotaExceeded {
+		b.retryAt = domain.NextQuotaReset(now)
+	} else {
+		backoff := rateLimitBackoffMin << min(b.rateStreak, 8)
+		b.rateStreak++
+		b.retryAt = now.Add(min(backoff, rateLimitBackoffMax))
+	}
+	logging.Log(ctx, "warn", "YOUTUBE_API", "circuit open: %s, pausing YouTube API calls until %s", apiErr.Code, b.retryAt.Format(time.RFC3339))
+}
+
+// call は fn を breaker 越しに実行します。
+func call[T any](ctx context.Context, b *Breaker, fn func() (T, error)) (T, error) {
+	if err := b.acquire(); err != nil {
+		var zero T
+		return zero, err
+	}
+	v, err := fn()
+	b.record(ctx, err)
+	return v, err
+}
+
+func (b *Breaker) GetActiveLiveChatID(ctx context.Context, videoID string) (port.VideoMeta, error) {
+	return call(ctx, b, func() (port.VideoMeta, error) { return b.inner.GetActiveLiveChatID(ctx, videoID) })
+}
+
+func (b *Breaker) ListLiveChatMessages(ctx context.Context, liveChatID string, pageToken string) ([]port.ChatMessage, string, int64, int, bool, error) {
+	if err := b.acquire(); err != nil {
+		return nil, "", 0, 0, false, err
+	}
+	items, next, pollMs, skipped, ended, err := b.inner.ListLiveChatMessages(ctx, liveChatID, pageToken)
+	b.record(ctx, err)
+	return items, next, pollMs, skipped, ended, err
+}
+
+func (b *Breaker) GetChannelDisplayNames(ctx context.Context, channelIDs []string) (map[string]string, error) {
+	return call(ctx, b, func() (map[string]string, error) { return b.inner.GetChannelDisplayNames(ctx, channelIDs) })
+}
+
+func (b *Breaker) GetChannelHandles(ctx context.Context, channelIDs []string) (map[string]string, error) {
+	return call(ctx, b, func() (map[string]string, error) { return b.inner.GetChannelHandles(ctx, channelIDs) })
+}
+
+func (b *Breaker) GetVideoLiveDetails(ctx context.Context, videoID string) (port.VideoLiveDetails, error) {
+	return call(ctx, b, func() (port.VideoLiveDetails, error) { return b.inner.GetVideoLiveDetails(ctx, videoID) })
+}
+
+func (b *Breaker) FindChannelBroadcasts(ctx context.Context, channel string) ([]port.Broadcast, error) {
+	return call(ctx, b, func() ([]port.Broadcast, error) { return b.inner.FindChannelBroadcasts(ctx, channel) })
+}
@@ -0,0 +1,111 @@
+package quota_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/quota"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// errYT は err を返し、呼ばれた回数を数える fake。
+type errYT struct {
+	port.YouTubePort
+	err   error
+	calls int
+}
+
+func (f *errYT) GetVideoLiveDetails(context.Context, string) (port.VideoLiveDetails, error) {
+	f.calls++
+	return port.VideoLiveDetails{}, f.err
+}
+
+// GetChannelDisplayNames は youtube.API と同じく、失敗時も解決済み分を err と一緒に返す。
+func (f *errYT) GetChannelDisplayNames(context.Context, []string) (map[string]string, error) {
+	f.calls++
+	return map[string]string{"UC1": "cached"}, f.err
+}
+
+func TestBreaker_QuotaOpensUntilPacificMidnight(t *testing.T) {
+	ctx := context.Background()
+	clock := system.NewManualClock(pacificNoon)
+	inner := &errYT{err: &domain.APIError{Code: domain.ErrCodeQuotaExceeded, Message: "quota"}}
+	b := quota.NewBreaker(inner, clock)
+
+	if st := b.BreakerStatus(); st.State != domain.BreakerClosed || st.RetryAt != nil {
+		t.Fatalf("initial status = %+v, want closed", st)
+	}
+	_, _ = b.GetVideoLiveDetails(ctx, "v")
+	st := b.BreakerStatus()
+	reset := domain.NextQuotaReset(pacificNoon)
+	if st.State != domain.BreakerOpen || st.Reason != domain.ErrCodeQuotaExceeded || st.RetryAt == nil || !st.RetryAt.Equal(reset) || st.Trips != 1 {
+		t.Fatalf("status after quota error = %+v, want open until %s", st, reset)
+	}
+
+	// open 中は API を呼ばずに同じコードで失敗する
+	_, err := b.GetVideoLiveDetails(ctx, "v")
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeQuotaExceeded || inner.calls != 1 {
+		t.Errorf("call while open = %v (calls %d), want quota_exceeded without calling the API", err, inner.calls)
+	}
+
+	// リセット後は 1 回試行し、成功すれば closed に戻る
+	clock.Set(reset)
+	inner.err = nil
+	if _, err := b.GetVideoLiveDetails(ctx, "v"); err != nil || inner.calls != 2 {
+		t.Fatalf("probe = %v (calls %d), want the call to go through", err, inner.calls)
+	}
+	if st := b.BreakerStatus(); st.State != domain.BreakerClosed || st.Reason != "" {
+		t.Errorf("status after probe = %+v, want closed", st)
+	}
+}
+
+func TestBreaker_RateLimitBacksOff(t *testing.T) {
+	ctx := context.Background()
+	clock := system.NewManualClock(pacificNoon)
+	inner := &errYT{err: &domain.APIError{Code: domain.ErrCodeRateLimited, Message: "slow down"}}
+	b := quota.NewBreaker(inner, clock)
+
+	_, _ = b.GetVideoLiveDetails(ctx, "v")
+	if st := b.BreakerStatus(); st.RetryAt == nil || !st.RetryAt.Equal(pacificNoon.Add(time.Minute)) {
+		t.Fatalf("status = %+v, want retry after 1m", st)
+	}
+	// 試行が再び rate limit なら待ち時間を倍にする
+	clock.Set(pacificNoon.Add(time.Minute))
+	_, _ = b.GetVideoLiveDetails(ctx, "v")
+	st := b.BreakerStatus()
+	if st.State != domain.BreakerOpen || !st.RetryAt.Equal(clock.Now().Add(2*time.Minute)) || st.Trips != 1 {
+		t.Errorf("status after failed probe = %+v, want open for 2m more in the same trip", st)
+	}
+	// 他のエラー (API には届いている) なら closed に戻る
+	clock.Advance(2 * time.Minute)
+	inner.err = &domain.APIError{Code: domain.ErrCodeVideoNotFound, Message: "gone"}
+	_, _ = b.GetVideoLiveDetails(ctx, "v")
+	if st := b.BreakerStatus(); st.State != domain.BreakerClosed {
+		t.Errorf("status after video_not_found = %+v, want closed", st)
+	}
+}
+
+func TestBreaker_ChannelLookupErrorKeepsOpen(t *testing.T) {
+	ctx := context.Background()
+	clock := system.NewManualClock(pacificNoon)
+	inner := &errYT{err: &domain.APIError{Code: domain.ErrCodeRateLimited, Message: "slow down"}}
+	b := quota.NewBreaker(inner, clock)
+
+	if names, _ := b.GetChannelDisplayNames(ctx, []string{"UC1", "UC2"}); len(names) != 1 {
+		t.Errorf("names = %v, want the partial result passed through", names)
+	}
+	if st := b.BreakerStatus(); st.State != domain.BreakerOpen {
+		t.Fatalf("status after channel lookup rate limit = %+v, want open", st)
+	}
+	// half-open の試行がチャンネル解決でも、まだ rate limit なら closed に戻さず backoff を延ばす
+	clock.Set(pacificNoon.Add(time.Minute))
+	_, _ = b.GetChannelDisplayNames(ctx, []string{"UC1", "UC2"})
+	if st := b.BreakerStatus(); st.State != domain.BreakerOpen || !st.RetryAt.Equal(clock.Now().Add(2*time.Minute)) {
+		t.Errorf("status after failed lookup probe = %+v, want open for 2m more", st)
+	}
+}
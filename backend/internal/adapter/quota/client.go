@@ -0,0 +1,147 @@
+package quota
+
+import (
+	"context"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// channelsBatchSize は channels.list 1 回で解決できる channelId 数です。
+const channelsBatchSize = 50
+
+// Client は port.YouTubePort をラップし、呼び出し前に Ledger で予算を確認します。
+// 実際の消費 (リトライ含む attempt 数) は youtube.API が Ledger.Record で記録するため、
+// ここでは見積もりコストで可否判定だけを行います。
+type Client struct {
+	inner  port.YouTubePort
+	ledger *Ledger
+
+	// Channels は任意: inner と同じチャンネルキャッシュを渡すと、channels.list はキャッシュに無い ID 分だけ予算判定し、
+	// 全件キャッシュ済みなら inner を呼ばずにキャッシュから返す (予算の残量にかかわらず返せる)
+	Channels port.ChannelCache
+}
+
+// Wrap は inner を予算管理付きの YouTubePort にします。
+func Wrap(inner port.YouTubePort, ledger *Ledger) *Client {
+	return &Client{inner: inner, ledger: ledger}
+}
+
+var _ port.YouTubePort = (*Client)(nil)
+
+// GetActiveLiveChatID は videos.list (Normal) です。
+func (c *Client) GetActiveLiveChatID(ctx context.Context, videoID string) (port.VideoMeta, error) {
+	if err := c.ledger.Allow("videos.list", domain.QuotaCost["videos.list"], PriorityNormal); err != nil {
+		return port.VideoMeta{}, err
+	}
+	return c.inner.GetActiveLiveChatID(ctx, videoID)
+}
+
+// ListLiveChatMessages はチャットポーリング (High) で、予算が尽きるまで許可します。
+func (c *Client) ListLiveChatMessages(ctx context.Context, liveChatID string, pageToken string) ([]port.ChatMessage, string, int64, int, bool, error) {
+	if err := c.ledger.Allow("liveChatMessages.list", domain.QuotaCost["liveChatMessages.list"], PriorityHigh); err != nil {
+		return nil, "", 0, 0, false, err
+	}
+	return c.inner.ListLiveChatMessages(ctx, liveChatID, pageToken)
+}
+
+// GetChannelDisplayNames は表示名解決 (Low) です。拒否時はキャッシュ済み分だけをエラーと一緒に返し、
+// 残りは呼び出し側が @ 除去でフォールバックします。
+func (c *Client) GetChannelDisplayNames(ctx context.Context, channelIDs []string) (map[string]string, error) {
+	cached, misses := c.splitCached(channelIDs)
+	if len(misses) == 0 {
+		return channelNames(cached), nil
+	}
+	if err := c.ledger.Allow("channels.list", channelsCost(misses), PriorityLow); err != nil {
+		return channelNames(cached), err
+	}
+	return c.inner.GetChannelDisplayNames(ctx, channelIDs)
+}
+
+// GetChannelHandles はハンドル解決 (Low) です。
+func (c *Client) GetChannelHandles(ctx context.Context, channelIDs []string) (map[string]string, error) {
+	cached, misses := c.splitCached(channelIDs)
+	if len(misses) == 0 {
+		return channelHandles(cached), nil
+	}
+	if err := c.ledger.Allow("channels.list", channelsCost(misses), PriorityLow); err != nil {
+		return channelHandles(cached), err
+	}
+	return c.inner.GetChannelHandles(ctx, channelIDs)
+}
+
+// splitCached は channelIDs をキャッシュ済みの情報と未キャッシュの ID に分けます (Channels が nil なら全件未キャッシュ)。
+func (c *Client) splitCached(channelIDs []string) (map[string]domain.ChannelInfo, []string) {
+	if c.Channels == nil {
+		return nil, channelIDs
+	}
+	cached := make(map[string]domain.ChannelInfo, len(channelIDs))
+	var misses []string
+	for _, id := range channelIDs {
+		if info, ok := c.Channels.Get(id); ok {
+			cached[id] = info
+		} else {
+			misses = append(misses, id)
+		}
+	}
+	return cached, misses
+}
+
+func channelNames(infos map[string]domain.ChannelInfo) map[string]string {
+	names := make(map[string]string, len(infos))
+	for id, info := range infos {
+		names[id] = info.Title
+	}
+	return names
+}
+
+// channelHandles はハンドルを持つチャンネルだけを返します (youtube.API.GetChannelHandles と同じ)。
+func channelHandles(infos map[string]domain.ChannelInfo) map[string]string {
+	handles := make(map[string]string, len(infos))
+	for id, info := range infos {
+		if info.Handle != "" {
+			handles[id] = info.Handle
+		}
+	}
+	return handles
+}
+
+// GetVideoLiveDetails は予約監視・配信中の確認 (Normal) です。
+func (c *Client) GetVideoLiveDetails(ctx context.Context, videoID string) (port.VideoLiveDetails, error) {
+	return c.videoLiveDetails(ctx, videoID, PriorityNormal)
+}
+
+// LowPriorityDetails は GetVideoLiveDetails を Low で予算判定する fetcher を返します。
+// 視聴者数のサンプリングなど、予算が厳しければ見送ってよい取得に使います。
+func (c *Client) LowPriorityDetails() *LowPriorityDetails {
+	return &LowPriorityDetails{c: c}
+}
+
+// LowPriorityDetails は Client.LowPriorityDetails が返す fetcher です。
+type LowPriorityDetails struct{ c *Client }
+
+// GetVideoLiveDetails はサンプリング用の videos.list (Low) です。
+func (l *LowPriorityDetails) GetVideoLiveDetails(ctx context.Context, videoID string) (port.VideoLiveDetails, error) {
+	return l.c.videoLiveDetails(ctx, videoID, PriorityLow)
+}
+
+func (c *Client) videoLiveDetails(ctx context.Context, videoID string, priority Priority) (port.VideoLiveDetails, error) {
+	if err := c.ledger.Allow("videos.list", domain.QuotaCost["videos.list"], priority); err != nil {
+		return port.VideoLiveDetails{}, err
+	}
+	return c.inner.GetVideoLiveDetails(ctx, videoID)
+}
+
+// FindChannelBroadcasts はチャンネル監視の配信検出 (Low) です。拒否時は次回の検出に回します。
+// channels.list + playlistItems.list + videos.list の 3 回分を、専用の key (domain.FindBroadcastsMethod) で判定します。
+func (c *Client) FindChannelBroadcasts(ctx context.Context, channel string) ([]port.Broadcast, error) {
+	if err := c.ledger.Allow(domain.FindBroadcastsMethod, domain.FindBroadcastsCost(), PriorityLow); err != nil {
+		return nil, err
+	}
+	return c.inner.FindChannelBroadcasts(ctx, channel)
+}
+
+// channelsCost は channelIDs を解決する最大コストです。
+func channelsCost(channelIDs []string) int {
+	return (len(channelIDs) + channelsBatchSize - 1) / channelsBatchSize * domain.QuotaCost["channels.list"]
+}
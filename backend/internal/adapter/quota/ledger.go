@@ -0,0 +1,215 @@
+// Package quota は YouTube Data API の quota 消費を記録し、1 日の予算を超えないよう
+// 優先度の低い呼び出しから抑制する port.YouTubePort ラッパーを提供する。
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// ledgerKey は StateBlobStore 上の保存 key です。
+const ledgerKey = "quota-ledger"
+
+// Priority は予算逼迫時にどの呼び出しを残すかの優先度です。
+type Priority int
+
+const (
+	// PriorityLow はハンドル・表示名解決や視聴者数のサンプリングなど、失敗してもフォールバック・見送りできる呼び出しです。
+	PriorityLow Priority = iota
+	// PriorityNormal は予約監視・メタデータ取得 (videos.list) です。
+	PriorityNormal
+	// PriorityHigh はチャットポーリング (liveChatMessages.list) です。
+	PriorityHigh
+)
+
+// 予算に対する残量の割合がこれを下回ると、その優先度の呼び出しを拒否します。
+// High は残量が 1 回分のコストを下回るまで許可します。
+const (
+	lowReserveRatio    = 0.20 // 残り 20% 未満で Low を拒否
+	normalReserveRatio = 0.05 // 残り 5% 未満で Normal を拒否
+)
+
+// Ledger は当日の quota 消費 (推定) を記録します。日付は太平洋時間で切り替わります。
+type Ledger struct {
+	budget int
+	clock  port.Clock
+	store  port.StateBlobStore // nil なら永続化しない
+
+	mu       sync.Mutex
+	day      string
+	used     int
+	byMethod map[string]int
+	refused  map[string]int
+	dirty    bool
+}
+
+// ledgerState は永続化形式です。
+type ledgerState struct {
+	Day      string         `json:"day"`
+	Used     int            `json:"used"`
+	ByMethod map[string]int `json:"byMethod"`
+	Refused  map[string]int `json:"refused,omitempty"`
+}
+
+// NewLedger は Ledger を生成します。budget <= 0 は domain.DefaultDailyQuota として扱います。
+func NewLedger(budget int, clock port.Clock, store port.StateBlobStore) *Ledger {
+	if budget <= 0 {
+		budget = domain.DefaultDailyQuota
+	}
+	return &Ledger{
+		budget:   budget,
+		clock:    clock,
+		store:    store,
+		day:      domain.QuotaDay(clock.Now()),
+		byMethod: make(map[string]int),
+		refused:  make(map[string]int),
+	}
+}
+
+// Load は永続化済みの当日分を復元します。日付が変わっていれば何もしません。
+func (l *Ledger) Load(ctx context.Context) error {
+	if l.store == nil {
+		return nil
+	}
+	data, err := l.store.LoadBlob(ctx, ledgerKey)
+	if err != nil {
+		return fmt.Errorf("quota: load ledger: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+	var st ledgerState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return fmt.Errorf("quota: unmarshal ledger: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rollover()
+	if st.Day != l.day {
+		return nil
+	}
+	l.used = st.Used
+	l.byMethod = make(map[string]int, len(st.ByMethod))
+	maps.Copy(l.byMethod, st.ByMethod)
+	l.refused = make(map[string]int, len(st.Refused))
+	maps.Copy(l.refused, st.Refused)
+	return nil
+}
+
+// Flush は未保存の消費記録を永続化します。
+func (l *Ledger) Flush(ctx context.Context) error {
+	if l.store == nil {
+		return nil
+	}
+	l.mu.Lock()
+	if !l.dirty {
+		l.mu.Unlock()
+		return nil
+	}
+	st := ledgerState{Day: l.day, Used: l.used, ByMethod: maps.Clone(l.byMethod), Refused: maps.Clone(l.refused)}
+	l.dirty = false
+	l.mu.Unlock()
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		return fmt.Errorf("quota: marshal ledger: %w", err)
+	}
+	if err := l.store.SaveBlob(ctx, ledgerKey, data); err != nil {
+		l.mu.Lock()
+		l.dirty = true // 次回再試行
+		l.mu.Unlock()
+		return fmt.Errorf("quota: save ledger: %w", err)
+	}
+	return nil
+}
+
+// Run は ctx.Done まで interval ごとに Flush します。
+// シャットダウン時の最終 Flush は呼び出し側で行ってください (snapshot の Flush と同じ扱い)。
+func (l *Ledger) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.Flush(ctx); err != nil {
+				log.Printf("[WARN] quota: periodic flush failed: %v", err)
+			}
+		}
+	}
+}
+
+// Record は実際に発行した API 呼び出し 1 attempt 分の消費を記録します (youtube.API から呼ばれる)。
+func (l *Ledger) Record(method string, units int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rollover()
+	l.used += units
+	l.byMethod[method] += units
+	l.dirty = true
+}
+
+// Allow は units を消費する priority の呼び出しを今発行してよいかを判定します。
+// 拒否した場合は refused に計上し、domain.ErrCodeQuotaExceeded の APIError を返します。
+func (l *Ledger) Allow(method string, units int, priority Priority) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rollover()
+
+	remaining := l.budget - l.used
+	var reserve int
+	switch priority {
+	case PriorityLow:
+		reserve = int(float64(l.budget) * lowReserveRatio)
+	case PriorityNormal:
+		reserve = int(float64(l.budget) * normalReserveRatio)
+	}
+	if remaining-units >= reserve {
+		return nil
+	}
+
+	l.refused[method]++
+	l.dirty = true
+	return &domain.APIError{
+		Code:    domain.ErrCodeQuotaExceeded,
+		Message: fmt.Sprintf("local quota budget: %s deferred (used %d/%d, resets %s)", method, l.used, l.budget, domain.NextQuotaReset(l.clock.Now()).Format(time.RFC3339)),
+	}
+}
+
+// Usage は当日の消費状況を返します。
+func (l *Ledger) Usage() domain.QuotaUsage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rollover()
+	return domain.QuotaUsage{
+		Day:      l.day,
+		Used:     l.used,
+		Budget:   l.budget,
+		ResetAt:  domain.NextQuotaReset(l.clock.Now()),
+		ByMethod: maps.Clone(l.byMethod),
+		Refused:  maps.Clone(l.refused),
+	}
+}
+
+// rollover は太平洋時間の日付が変わっていればカウンタをリセットします。l.mu を保持して呼ぶこと。
+func (l *Ledger) rollover() {
+	day := domain.QuotaDay(l.clock.Now())
+	if day == l.day {
+		return
+	}
+	l.day = day
+	l.used = 0
+	l.byMethod = make(map[string]int)
+	l.refused = make(map[string]int)
+	l.dirty = true
+}
@@ -0,0 +1,195 @@
+package quota_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/quota"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// pacificNoon は 2026-06-01 12:00 PDT です。
+var pacificNoon = time.Date(2026, 6, 1, 19, 0, 0, 0, time.UTC)
+
+func TestLedger_AllowByPriority(t *testing.T) {
+	l := quota.NewLedger(100, system.NewManualClock(pacificNoon), nil)
+	l.Record("liveChatMessages.list", 80) // 残り 20
+
+	// Low は残り 20% (=20) を割る呼び出しを拒否
+	err := l.Allow("channels.list", 1, quota.PriorityLow)
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeQuotaExceeded {
+		t.Fatalf("Low Allow err = %v, want quota_exceeded", err)
+	}
+	if err := l.Allow("videos.list", 1, quota.PriorityNormal); err != nil {
+		t.Errorf("Normal Allow err = %v, want nil", err)
+	}
+	if err := l.Allow("liveChatMessages.list", 5, quota.PriorityHigh); err != nil {
+		t.Errorf("High Allow err = %v, want nil", err)
+	}
+
+	l.Record("liveChatMessages.list", 18) // 残り 2
+	if err := l.Allow("videos.list", 1, quota.PriorityNormal); err == nil {
+		t.Error("Normal Allow should be refused below 5% reserve")
+	}
+	if err := l.Allow("liveChatMessages.list", 5, quota.PriorityHigh); err == nil {
+		t.Error("High Allow should be refused when budget is exhausted")
+	}
+
+	usage := l.Usage()
+	if usage.Used != 98 || usage.ByMethod["liveChatMessages.list"] != 98 {
+		t.Errorf("usage = %+v", usage)
+	}
+	if usage.Refused["channels.list"] != 1 || usage.Refused["videos.list"] != 1 || usage.Refused["liveChatMessages.list"] != 1 {
+		t.Errorf("refused = %v", usage.Refused)
+	}
+}
+
+func TestLedger_ResetsAtPacificMidnight(t *testing.T) {
+	clock := system.NewManualClock(pacificNoon)
+	l := quota.NewLedger(100, clock, nil)
+	l.Record("videos.list", 10)
+
+	clock.Set(domain.NextQuotaReset(pacificNoon))
+	usage := l.Usage()
+	if usage.Used != 0 || usage.Day != "2026-06-02" {
+		t.Errorf("usage after reset = %+v", usage)
+	}
+}
+
+func TestLedger_PersistsAcrossRestart(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewBlobStore()
+	clock := system.NewManualClock(pacificNoon)
+
+	l := quota.NewLedger(100, clock, store)
+	l.Record("liveChatMessages.list", 25)
+	if err := l.Flush(ctx); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+
+	restarted := quota.NewLedger(100, clock, store)
+	if err := restarted.Load(ctx); err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if got := restarted.Usage().Used; got != 25 {
+		t.Errorf("Used after restart = %d, want 25", got)
+	}
+
+	// 翌日の起動では前日分を引き継がない
+	nextDay := quota.NewLedger(100, system.NewManualClock(pacificNoon.Add(24*time.Hour)), store)
+	if err := nextDay.Load(ctx); err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if got := nextDay.Usage().Used; got != 0 {
+		t.Errorf("Used on next day = %d, want 0", got)
+	}
+}
+
+type countingYT struct {
+	port.YouTubePort
+	handleCalls  int
+	detailsCalls int
+}
+
+func (c *countingYT) GetVideoLiveDetails(context.Context, string) (port.VideoLiveDetails, error) {
+	c.detailsCalls++
+	return port.VideoLiveDetails{}, nil
+}
+
+func (c *countingYT) GetChannelHandles(ctx context.Context, channelIDs []string) (map[string]string, error) {
+	c.handleCalls++
+	return map[string]string{}, nil
+}
+
+func TestClient_DefersLowPriorityCalls(t *testing.T) {
+	l := quota.NewLedger(100, system.NewManualClock(pacificNoon), nil)
+	inner := &countingYT{}
+	yt := quota.Wrap(inner, l)
+
+	if _, err := yt.GetChannelHandles(context.Background(), []string{"ch1"}); err != nil {
+		t.Fatalf("GetChannelHandles err = %v", err)
+	}
+	l.Record("liveChatMessages.list", 85)
+	if _, err := yt.GetChannelHandles(context.Background(), []string{"ch1"}); err == nil {
+		t.Error("expected low priority call to be refused")
+	}
+	if inner.handleCalls != 1 {
+		t.Errorf("inner calls = %d, want 1", inner.handleCalls)
+	}
+}
+
+func TestClient_LowPriorityDetailsDeferredBeforeNormal(t *testing.T) {
+	l := quota.NewLedger(100, system.NewManualClock(pacificNoon), nil)
+	inner := &countingYT{}
+	yt := quota.Wrap(inner, l)
+	l.Record("liveChatMessages.list", 85)
+
+	// 予算が厳しいとき、サンプリング用の取得は見送り、配信中の確認は通す
+	if _, err := yt.LowPriorityDetails().GetVideoLiveDetails(context.Background(), "v"); err == nil {
+		t.Error("expected low priority details to be refused")
+	}
+	if _, err := yt.GetVideoLiveDetails(context.Background(), "v"); err != nil {
+		t.Errorf("normal priority details err = %v", err)
+	}
+	if inner.detailsCalls != 1 {
+		t.Errorf("inner calls = %d, want 1", inner.detailsCalls)
+	}
+}
+
+func TestClient_FindBroadcastsCountsRefusalUnderOwnKey(t *testing.T) {
+	l := quota.NewLedger(100, system.NewManualClock(pacificNoon), nil)
+	yt := quota.Wrap(&countingYT{}, l)
+	l.Record("liveChatMessages.list", 85)
+
+	// 3 回分をまとめて判定し、拒否は playlistItems.list ではなく配信検出の key に数える
+	if _, err := yt.FindChannelBroadcasts(context.Background(), "@alice"); err == nil {
+		t.Error("expected broadcast detection to be refused")
+	}
+	refused := l.Usage().Refused
+	if refused[domain.FindBroadcastsMethod] != 1 || refused["playlistItems.list"] != 0 {
+		t.Errorf("refused = %v, want only %s", refused, domain.FindBroadcastsMethod)
+	}
+}
+
+func TestClient_CachedChannelsBypassBudget(t *testing.T) {
+	l := quota.NewLedger(100, system.NewManualClock(pacificNoon), nil)
+	inner := &countingYT{}
+	cache := memory.NewChannelCache(0, 0, nil)
+	cache.Put(domain.ChannelInfo{ChannelID: "ch1", Title: "Alice", Handle: "@alice"})
+	yt := quota.Wrap(inner, l)
+	yt.Channels = cache
+	l.Record("liveChatMessages.list", 85) // Low の予備枠を割っている
+
+	handles, err := yt.GetChannelHandles(context.Background(), []string{"ch1"})
+	if err != nil {
+		t.Fatalf("cached GetChannelHandles err = %v", err)
+	}
+	if handles["ch1"] != "@alice" {
+		t.Errorf("handles = %v", handles)
+	}
+	names, err := yt.GetChannelDisplayNames(context.Background(), []string{"ch1"})
+	if err != nil || names["ch1"] != "Alice" {
+		t.Errorf("cached GetChannelDisplayNames = %v, %v", names, err)
+	}
+
+	// 未キャッシュの ID を含む場合は拒否されるが、キャッシュ済み分は返す
+	handles, err = yt.GetChannelHandles(context.Background(), []string{"ch1", "ch2"})
+	if err == nil {
+		t.Error("expected uncached lookup to be refused")
+	}
+	if handles["ch1"] != "@alice" {
+		t.Errorf("partial handles = %v", handles)
+	}
+	if inner.handleCalls != 0 {
+		t.Errorf("inner calls = %d, want 0", inner.handleCalls)
+	}
+	if refused := l.Usage().Refused["channels.list"]; refused != 1 {
+		t.Errorf("refused = %d, want 1", refused)
+	}
+}
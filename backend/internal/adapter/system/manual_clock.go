@@ -0,0 +1,37 @@
+package system
+
+import (
+	"sync"
+	"time"
+)
+
+// ManualClock は Set / Advance で与えた時刻を返す Clock 実装です (テストや時刻を固定した再現用)。
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManualClock は now を返す ManualClock を生成します。
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set は現在時刻を t にします。
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Advance は現在時刻を d 進めます。
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
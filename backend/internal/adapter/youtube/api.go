@@ -4,35 +4,91 @@ import (
 	"context"
 	"errors"
 	"log"
+	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/metrics"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
 	"google.golang.org/api/googleapi"
-	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 )
 
 type API struct {
-	APIKey             string
-	channelNameCache   map[string]string // channelID -> チャンネル名キャッシュ
-	channelHandleCache map[string]string // channelID -> ハンドル(@username)キャッシュ
+	APIKey   string            // 単一キー運用 (Keys 未設定時に使う)
+	Keys     *KeyPool          // 任意: 複数キーのローテーション。設定時は APIKey より優先
+	Quota    QuotaRecorder     // 任意: 実際の quota 消費の記録先 (quota.Ledger)
+	Channels port.ChannelCache // 任意: channelID -> 名前・ハンドル・アバターのキャッシュ (未設定なら毎回 channels.list を呼ぶ)
+
+	// 接続設定 (Option で指定)
+	httpClient   *http.Client
+	baseURL      string
+	timeout      time.Duration
+	retryBackoff time.Duration
+
+	mu       sync.Mutex
+	services map[string]*youtube.Service // API キー -> 生成済み service
 }
 
-func New(apiKey string) *API {
-	return &API{
-		APIKey:             apiKey,
-		channelNameCache:   make(map[string]string),
-		channelHandleCache: make(map[string]string),
+// QuotaRecorder は実際に発行した API 呼び出し (attempt 単位) の quota 消費を受け取ります。
+type QuotaRecorder interface {
+	Record(method string, units int)
+}
+
+func New(apiKey string, opts ...Option) *API {
+	a := &API{APIKey: apiKey}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// NewWithKeys は keys をローテーションする API を生成します。
+// quota 超過・キー不正となったキーは次の quota リセット (太平洋時間 0:00) まで使いません。
+func NewWithKeys(keys []string, clock port.Clock, opts ...Option) *API {
+	a := New("", opts...)
+	a.Keys = NewKeyPool(keys, clock)
+	return a
+}
+
+// hasKey は呼び出しに使えるキーが 1 本以上設定されているかを返します。
+func (a *API) hasKey() bool {
+	if a.Keys != nil {
+		return a.Keys.Len() > 0
+	}
+	return a.APIKey != ""
+}
+
+// acquireKey は今回の呼び出しに使うキーを返します。
+func (a *API) acquireKey() (string, error) {
+	if a.Keys != nil {
+		return a.Keys.Acquire()
+	}
+	if a.APIKey == "" {
+		return "", errors.New("youtube api key is required")
 	}
+	return a.APIKey, nil
+}
+
+// reportKey は key での呼び出し結果をキープールに記録し、別のキーで再試行すべきなら true を返します。
+// err は classifyAPIError 済みのものを渡します。
+func (a *API) reportKey(ctx context.Context, key string, err error) bool {
+	if a.Keys == nil || !a.Keys.Report(key, err) {
+		return false
+	}
+	logging.Log(ctx, "warn", "YOUTUBE_API", "API key %s is unavailable until quota reset (%v), failing over", maskKey(key), err)
+	return true
 }
 
 // reasonToCode は googleapi.Error の Errors[0].Reason を domain.APIErrorCode にマッピングする。
 // YouTube Data API v3 が返す reason 文字列を機械可読コードに変換する。
 var reasonToCode = map[string]domain.APIErrorCode{
 	"quotaExceeded":         domain.ErrCodeQuotaExceeded,
+	"rateLimitExceeded":     domain.ErrCodeRateLimited,
 	"userRateLimitExceeded": domain.ErrCodeRateLimited,
 	"videoNotFound":         domain.ErrCodeVideoNotFound,
 	"liveChatEnded":         domain.ErrCodeLiveChatEnded,
@@ -40,6 +96,13 @@ var reasonToCode = map[string]domain.APIErrorCode{
 	"liveChatNotActive":     domain.ErrCodeLiveChatEnded,
 	// API key 不正は HTTP 400 で返ることが手動 e2e で観測済 (reason は大文字)
 	"API_KEY_INVALID": domain.ErrCodeAuthFailed,
+	"keyInvalid":      domain.ErrCodeAuthFailed,
+}
+
+// keyInvalidReasons は API キー自体が不正であることを示す reason です (KeyPool がキーを休ませる対象)。
+var keyInvalidReasons = map[string]bool{
+	"API_KEY_INVALID": true,
+	"keyInvalid":      true,
 }
 
 // extractReason は googleapi.Error から reason を抽出する。
@@ -79,7 +142,7 @@ func classifyAPIError(err error) error {
 
 	reason := extractReason(gErr)
 
-	// 401/403 で reason 不明な場合は auth_failed にフォールバック
+	// 401/403 で reason 不明な場合は auth_failed にフォールバック (キーの cooldown 対象にはしない: rejectsKey 参照)
 	if gErr.Code == 401 || gErr.Code == 403 {
 		if code, ok := reasonToCode[reason]; ok {
 			return &domain.APIError{Code: code, Message: gErr.Message, Wrapped: err}
@@ -100,6 +163,29 @@ func classifyAPIError(err error) error {
 	return err
 }
 
+// observeCall は API 呼び出し 1 attempt 分の結果と quota 消費をメトリクスと Quota に記録します。
+// エラー応答でも quota は消費されるため attempt ごとに呼びます。err は classifyAPIError 済みのものを渡します。
+func (a *API) observeCall(method string, err error) {
+	units := domain.QuotaCost[method]
+	metrics.YouTubeQuotaUnits.Add(float64(units), method)
+	metrics.YouTubeCalls.Inc(method, resultCode(err))
+	if a.Quota != nil {
+		a.Quota.Record(method, units)
+	}
+}
+
+// resultCode は呼び出し結果のメトリクス label です (成功は ok、未分類エラーは error)。
+func resultCode(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	var apiErr *domain.APIError
+	if errors.As(err, &apiErr) {
+		return string(apiErr.Code)
+	}
+	return "error"
+}
+
 // isLiveChatEnded はエラーがライブチャットの終了または無効化を示すかどうかを判定します。
 // "forbidden" は rate limit や quota 超過でも返されるため、終了判定には使用しない。
 func isLiveChatEnded(err error) bool {
@@ -162,7 +248,7 @@ func isTransientError(err error) bool {
 // fetchVideo は videos.list を共通呼び出しする。
 // videoID 不在 / API key 不在 / 動画未取得は domain error / generic error で返す。
 func (a *API) fetchVideo(ctx context.Context, videoID string, parts []string) (*youtube.Video, error) {
-	if a.APIKey == "" {
+	if !a.hasKey() {
 		log.Printf("[YOUTUBE_API] Error: API key is empty")
 		return nil, errors.New("youtube api key is required")
 	}
@@ -172,16 +258,29 @@ func (a *API) fetchVideo(ctx context.Context, videoID string, parts []string) (*
 		return nil, errors.New("video ID is required")
 	}
 
-	service, err := youtube.NewService(ctx, option.WithAPIKey(a.APIKey))
-	if err != nil {
-		log.Printf("[YOUTUBE_API] Failed to create YouTube service: %v", err)
-		return nil, err
-	}
+	var response *youtube.VideoListResponse
+	for {
+		key, err := a.acquireKey()
+		if err != nil {
+			return nil, err
+		}
+		service, err := a.service(ctx, key)
+		if err != nil {
+			log.Printf("[YOUTUBE_API] Failed to create YouTube service: %v", err)
+			return nil, err
+		}
 
-	response, err := service.Videos.List(parts).Id(videoID).Do()
-	if err != nil {
-		log.Printf("[YOUTUBE_API] Failed to get video details: %v", err)
-		return nil, classifyAPIError(err)
+		response, err = service.Videos.List(parts).Id(videoID).Context(ctx).Do()
+		err = classifyAPIError(err)
+		a.observeCall("videos.list", err)
+		if a.reportKey(ctx, key, err) {
+			continue
+		}
+		if err != nil {
+			log.Printf("[YOUTUBE_API] Failed to get video details: %v", err)
+			return nil, err
+		}
+		break
 	}
 
 	if len(response.Items) == 0 {
@@ -230,7 +329,7 @@ func (a *API) GetActiveLiveChatID(ctx context.Context, videoID string) (port.Vid
 func (a *API) ListLiveChatMessages(ctx context.Context, liveChatID string, pageToken string) (items []port.ChatMessage, nextPageToken string, pollingIntervalMillis int64, skippedCount int, isEnded bool, err error) {
 	log.Printf("[YOUTUBE_API] ListLiveChatMessages called with liveChatID: %s", liveChatID)
 
-	if a.APIKey == "" {
+	if !a.hasKey() {
 		log.Printf("[YOUTUBE_API] Error: API key is empty")
 		return nil, "", 0, 0, false, errors.New("youtube api key is required")
 	}
@@ -241,33 +340,60 @@ func (a *API) ListLiveChatMessages(ctx context.Context, liveChatID string, pageT
 	}
 
 	// YouTube Data API v3を使用してライブチャットメッセージを取得
-	service, err := youtube.NewService(ctx, option.WithAPIKey(a.APIKey))
-	if err != nil {
-		log.Printf("[YOUTUBE_API] Failed to create YouTube service: %v", err)
-		return nil, "", 0, 0, false, err
-	}
+	newCall := func(key string) (*youtube.LiveChatMessagesListCall, error) {
+		service, err := a.service(ctx, key)
+		if err != nil {
+			log.Printf("[YOUTUBE_API] Failed to create YouTube service: %v", err)
+			return nil, err
+		}
+
+		// Live Chat APIは1回の呼び出しで増分取得を行う設計
+		// 無限ループを避けるため、1ページのみ取得
+		call := service.LiveChatMessages.List(liveChatID, []string{"snippet", "authorDetails"}).Context(ctx)
 
-	// Live Chat APIは1回の呼び出しで増分取得を行う設計
-	// 無限ループを避けるため、1ページのみ取得
-	call := service.LiveChatMessages.List(liveChatID, []string{"snippet", "authorDetails"})
+		// ページトークンを設定（初回は空）
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
 
-	// ページトークンを設定（初回は空）
-	if pageToken != "" {
-		call = call.PageToken(pageToken)
+		// Live Chat API仕様: デフォルト200、最大2000
+		// 最大値に設定してより多くのコメントを一度に取得
+		return call.MaxResults(port.LiveChatMaxResults), nil
 	}
 
-	// Live Chat API仕様: デフォルト200、最大2000
-	// 最大値に設定してより多くのコメントを一度に取得
-	call = call.MaxResults(2000)
+	key, err := a.acquireKey()
+	if err != nil {
+		return nil, "", 0, 0, false, err
+	}
+	call, err := newCall(key)
+	if err != nil {
+		return nil, "", 0, 0, false, err
+	}
 
 	const maxAttempts = 4
 	var response *youtube.LiveChatMessageListResponse
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		response, err = call.Do()
+		classified := classifyAPIError(err)
+		a.observeCall("liveChatMessages.list", classified)
 		if err == nil {
+			a.reportKey(ctx, key, nil)
 			break
 		}
 
+		// キー起因の失敗は次のキーで即再試行する (retry 回数は消費しない)。
+		// 各キーは一度 cooldown に入ると Acquire されないため、ループはキー数で有界。
+		if a.reportKey(ctx, key, classified) {
+			if key, err = a.acquireKey(); err != nil {
+				return nil, "", 0, 0, false, err
+			}
+			if call, err = newCall(key); err != nil {
+				return nil, "", 0, 0, false, err
+			}
+			attempt--
+			continue
+		}
+
 		logging.Log(ctx, "warn", "YOUTUBE_API", "API call failed (attempt %d/%d): %v", attempt, maxAttempts, err)
 
 		if isLiveChatEnded(err) {
@@ -276,8 +402,9 @@ func (a *API) ListLiveChatMessages(ctx context.Context, liveChatID string, pageT
 		}
 
 		if attempt < maxAttempts && isTransientError(err) {
-			backoff := time.Duration(1<<uint(attempt-1)) * time.Second // 1s, 2s, 4s
+			backoff := a.backoff(attempt) // 既定 1s, 2s, 4s
 			logging.Log(ctx, "warn", "YOUTUBE_API", "Retrying after %v...", backoff)
+			metrics.YouTubeRetries.Inc("liveChatMessages.list")
 			select {
 			case <-time.After(backoff):
 				continue
@@ -324,65 +451,40 @@ func (a *API) ListLiveChatMessages(ctx context.Context, liveChatID string, pageT
 	return messages, response.NextPageToken, int64(response.PollingIntervalMillis), skippedCount, false, nil
 }
 
+// GetChannelDisplayNames は channelIDs に対応する表示名マップを返す。
+// channels.list が失敗した場合は解決できた分のマップと最初のエラーを返す。
 func (a *API) GetChannelDisplayNames(ctx context.Context, channelIDs []string) (map[string]string, error) {
 	result := make(map[string]string)
-	if len(channelIDs) == 0 {
-		return result, nil
-	}
-
-	// キャッシュ済みを返し、未キャッシュを収集
-	var uncached []string
-	for _, id := range channelIDs {
-		if name, ok := a.channelNameCache[id]; ok {
-			result[id] = name
-		} else {
-			uncached = append(uncached, id)
-		}
-	}
-
-	if len(uncached) == 0 || a.APIKey == "" {
-		return result, nil
-	}
-
-	// YouTube Channels API: 1リクエストあたり最大50件
-	const batchSize = 50
-	for i := 0; i < len(uncached); i += batchSize {
-		end := min(i+batchSize, len(uncached))
-		batch := uncached[i:end]
-
-		service, err := youtube.NewService(ctx, option.WithAPIKey(a.APIKey))
-		if err != nil {
-			logging.Log(ctx, "warn", "YOUTUBE_API", "Failed to create service for channel names: %v", err)
-			continue
-		}
-
-		call := service.Channels.List([]string{"snippet"}).Id(strings.Join(batch, ","))
-		response, err := call.Do()
-		if err != nil {
-			logging.Log(ctx, "warn", "YOUTUBE_API", "Failed to get channel names: %v", err)
-			continue
-		}
-
-		for _, item := range response.Items {
-			name := item.Snippet.Title
-			a.channelNameCache[item.Id] = name
-			result[item.Id] = name
-
-			// ハンドル(CustomUrl)も同時にキャッシュ
-			a.channelHandleCache[item.Id] = item.Snippet.CustomUrl
-		}
+	infos, err := a.resolveChannels(ctx, channelIDs)
+	for id, info := range infos {
+		result[id] = info.Title
 	}
-
-	logging.Log(ctx, "info", "YOUTUBE_API", "Resolved %d/%d channel display names (cache size: %d)", len(result), len(channelIDs), len(a.channelNameCache))
-	return result, nil
+	logging.Log(ctx, "info", "YOUTUBE_API", "Resolved %d/%d channel display names", len(result), len(channelIDs))
+	return result, err
 }
 
 // GetChannelHandles は channelIDs に対応するハンドル(@username)マップを返す。
-// GetChannelDisplayNames で既に解決済みの @ チャンネルはキャッシュから返す。
-// 未キャッシュのチャンネルは Channels API を1回呼び出し、以降はキャッシュする。
+// GetChannelDisplayNames で既に解決済みのチャンネルはキャッシュから返す。
 // ハンドルが存在しない/空のチャンネルはマップに含まれない。
+// channels.list が失敗した場合は解決できた分のマップと最初のエラーを返す。
 func (a *API) GetChannelHandles(ctx context.Context, channelIDs []string) (map[string]string, error) {
 	result := make(map[string]string)
+	infos, err := a.resolveChannels(ctx, channelIDs)
+	for id, info := range infos {
+		if info.Handle != "" {
+			result[id] = info.Handle
+		}
+	}
+	logging.Log(ctx, "info", "YOUTUBE_API", "Resolved %d/%d channel handles", len(result), len(channelIDs))
+	return result, err
+}
+
+// resolveChannels は channelIDs のメタデータをキャッシュから返し、未キャッシュ分だけ
+// Channels API を呼び出してキャッシュします。取得に失敗したバッチは結果に含めず、最初のエラーを返します
+// (circuit breaker が quota 超過・rate limit を数えられるように、部分的な結果と一緒に返す)。
+// quota 超過・rate limit のときは残りのバッチも同じく失敗するため呼び出しません。
+func (a *API) resolveChannels(ctx context.Context, channelIDs []string) (map[string]domain.ChannelInfo, error) {
+	result := make(map[string]domain.ChannelInfo, len(channelIDs))
 	if len(channelIDs) == 0 {
 		return result, nil
 	}
@@ -390,57 +492,87 @@ func (a *API) GetChannelHandles(ctx context.Context, channelIDs []string) (map[s
 	// キャッシュ済みを返し、未キャッシュを収集
 	var uncached []string
 	for _, id := range channelIDs {
-		if handle, ok := a.channelHandleCache[id]; ok {
-			if handle != "" {
-				result[id] = handle
+		if a.Channels != nil {
+			if info, ok := a.Channels.Get(id); ok {
+				result[id] = info
+				continue
 			}
-		} else {
-			uncached = append(uncached, id)
 		}
+		uncached = append(uncached, id)
 	}
 
-	if len(uncached) == 0 || a.APIKey == "" {
+	if len(uncached) == 0 || !a.hasKey() {
 		return result, nil
 	}
 
 	// YouTube Channels API: 1リクエストあたり最大50件
 	const batchSize = 50
+	var firstErr error
 	for i := 0; i < len(uncached); i += batchSize {
 		end := min(i+batchSize, len(uncached))
 		batch := uncached[i:end]
 
-		service, err := youtube.NewService(ctx, option.WithAPIKey(a.APIKey))
-		if err != nil {
-			logging.Log(ctx, "warn", "YOUTUBE_API", "Failed to create service for channel handles: %v", err)
-			continue
-		}
-
-		call := service.Channels.List([]string{"snippet"}).Id(strings.Join(batch, ","))
-		response, err := call.Do()
+		response, err := a.listChannels(ctx, batch)
 		if err != nil {
-			logging.Log(ctx, "warn", "YOUTUBE_API", "Failed to get channel handles: %v", err)
+			logging.Log(ctx, "warn", "YOUTUBE_API", "Failed to get channels: %v", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			var apiErr *domain.APIError
+			if errors.As(err, &apiErr) && (apiErr.Code == domain.ErrCodeQuotaExceeded || apiErr.Code == domain.ErrCodeRateLimited) {
+				break
+			}
 			continue
 		}
 
 		for _, item := range response.Items {
-			handle := item.Snippet.CustomUrl
-			// 名前もキャッシュ（GetChannelDisplayNames との重複呼び出しを避けるため）
-			a.channelNameCache[item.Id] = item.Snippet.Title
-			a.channelHandleCache[item.Id] = handle
-			if handle != "" {
-				result[item.Id] = handle
+			if item.Snippet == nil {
+				continue
+			}
+			info := domain.ChannelInfo{
+				ChannelID: item.Id,
+				Title:     item.Snippet.Title,
+				Handle:    item.Snippet.CustomUrl,
+			}
+			if th := item.Snippet.Thumbnails; th != nil && th.Default != nil {
+				info.AvatarURL = th.Default.Url
 			}
+			if a.Channels != nil {
+				a.Channels.Put(info)
+			}
+			result[item.Id] = info
 		}
 	}
+	return result, firstErr
+}
+
+// listChannels は channels.list (snippet) を 1 バッチ (最大 50 件) 分呼び出します。
+func (a *API) listChannels(ctx context.Context, channelIDs []string) (*youtube.ChannelListResponse, error) {
+	for {
+		key, err := a.acquireKey()
+		if err != nil {
+			return nil, err
+		}
+		service, err := a.service(ctx, key)
+		if err != nil {
+			return nil, err
+		}
 
-	logging.Log(ctx, "info", "YOUTUBE_API", "Resolved %d/%d channel handles (cache size: %d)", len(result), len(channelIDs), len(a.channelHandleCache))
-	return result, nil
+		response, err := service.Channels.List([]string{"snippet"}).Id(strings.Join(channelIDs, ",")).Context(ctx).Do()
+		err = classifyAPIError(err)
+		a.observeCall("channels.list", err)
+		if a.reportKey(ctx, key, err) {
+			continue
+		}
+		return response, err
+	}
 }
 
 // GetVideoLiveDetails は指定 videoID の liveStreamingDetails を取得します。
 // activeLiveChatId が空でもエラーにせず返します (予約監視用)。
 func (a *API) GetVideoLiveDetails(ctx context.Context, videoID string) (port.VideoLiveDetails, error) {
-	video, err := a.fetchVideo(ctx, videoID, []string{"liveStreamingDetails", "snippet"})
+	// statistics を足しても videos.list のコストは 1 unit のまま
+	video, err := a.fetchVideo(ctx, videoID, []string{"liveStreamingDetails", "snippet", "statistics"})
 	if err != nil {
 		return port.VideoLiveDetails{}, err
 	}
@@ -450,24 +582,182 @@ func (a *API) GetVideoLiveDetails(ctx context.Context, videoID string) (port.Vid
 	}
 	if video.Snippet != nil {
 		details.Title = video.Snippet.Title
+		details.Description = video.Snippet.Description
 		details.ChannelTitle = video.Snippet.ChannelTitle
 	}
+	if video.Statistics != nil {
+		details.LikeCount = int64(video.Statistics.LikeCount)
+		details.ViewCount = int64(video.Statistics.ViewCount)
+	}
 	if video.LiveStreamingDetails != nil {
 		details.LiveChatID = video.LiveStreamingDetails.ActiveLiveChatId
-		if s := video.LiveStreamingDetails.ScheduledStartTime; s != "" {
-			if t, perr := time.Parse(time.RFC3339, s); perr == nil {
-				details.ScheduledStartTime = t
-			} else {
-				logging.Log(ctx, "warn", "YOUTUBE_API", "Failed to parse scheduledStartTime %q for video %s: %v", s, videoID, perr)
-			}
+		details.ScheduledStartTime = parseLiveTime(ctx, video.LiveStreamingDetails.ScheduledStartTime, "scheduledStartTime", videoID)
+		details.ActualStartTime = parseLiveTime(ctx, video.LiveStreamingDetails.ActualStartTime, "actualStartTime", videoID)
+		details.ActualEndTime = parseLiveTime(ctx, video.LiveStreamingDetails.ActualEndTime, "actualEndTime", videoID)
+		details.ConcurrentViewers = int64(video.LiveStreamingDetails.ConcurrentViewers)
+	}
+	return details, nil
+}
+
+// broadcastScanSize は FindChannelBroadcasts が調べる直近のアップロード件数です。
+// 配信予定・配信中の枠はアップロード一覧の先頭付近に並ぶため、少数で足ります。
+const broadcastScanSize = 10
+
+// FindChannelBroadcasts は channel (channelID または @handle) の配信中・配信予定のライブ配信を返します。
+// search.list (100 units) を避け、channels.list → playlistItems.list (アップロード一覧) → videos.list の
+// 計 3 units で直近のアップロードから liveBroadcastContent が live / upcoming のものを探します。
+func (a *API) FindChannelBroadcasts(ctx context.Context, channel string) ([]port.Broadcast, error) {
+	if !a.hasKey() {
+		log.Printf("[YOUTUBE_API] Error: API key is empty")
+		return nil, errors.New("youtube api key is required")
+	}
+	if channel == "" {
+		return nil, errors.New("channel is required")
+	}
+
+	uploads, err := a.uploadsPlaylist(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+	videoIDs, err := a.listPlaylistVideoIDs(ctx, uploads)
+	if err != nil {
+		return nil, err
+	}
+	if len(videoIDs) == 0 {
+		return []port.Broadcast{}, nil
+	}
+	videos, err := a.listVideos(ctx, videoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	broadcasts := []port.Broadcast{}
+	for _, v := range videos {
+		if v.Snippet == nil || v.LiveStreamingDetails == nil {
+			continue
+		}
+		live := v.Snippet.LiveBroadcastContent == "live"
+		if !live && v.Snippet.LiveBroadcastContent != "upcoming" {
+			continue
+		}
+		broadcasts = append(broadcasts, port.Broadcast{
+			VideoID:            v.Id,
+			Title:              v.Snippet.Title,
+			ScheduledStartTime: parseLiveTime(ctx, v.LiveStreamingDetails.ScheduledStartTime, "scheduledStartTime", v.Id),
+			ActualStartTime:    parseLiveTime(ctx, v.LiveStreamingDetails.ActualStartTime, "actualStartTime", v.Id),
+			Live:               live,
+		})
+	}
+	sort.SliceStable(broadcasts, func(i, j int) bool {
+		bi, bj := broadcasts[i], broadcasts[j]
+		if bi.Live != bj.Live {
+			return bi.Live
+		}
+		return bi.ScheduledStartTime.Before(bj.ScheduledStartTime)
+	})
+	logging.Log(ctx, "info", "YOUTUBE_API", "Found %d live/upcoming broadcasts for channel %s", len(broadcasts), channel)
+	return broadcasts, nil
+}
+
+// uploadsPlaylist は channel のアップロード一覧 playlist ID を channels.list (contentDetails) で取得します。
+func (a *API) uploadsPlaylist(ctx context.Context, channel string) (string, error) {
+	for {
+		key, err := a.acquireKey()
+		if err != nil {
+			return "", err
+		}
+		service, err := a.service(ctx, key)
+		if err != nil {
+			return "", err
+		}
+
+		call := service.Channels.List([]string{"contentDetails"}).Context(ctx)
+		if strings.HasPrefix(channel, "@") {
+			call = call.ForHandle(channel)
+		} else {
+			call = call.Id(channel)
+		}
+		response, err := call.Do()
+		err = classifyAPIError(err)
+		a.observeCall("channels.list", err)
+		if a.reportKey(ctx, key, err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if len(response.Items) == 0 || response.Items[0].ContentDetails == nil || response.Items[0].ContentDetails.RelatedPlaylists == nil {
+			return "", &domain.APIError{Code: domain.ErrCodeVideoNotFound, Message: "channel not found: " + channel}
+		}
+		return response.Items[0].ContentDetails.RelatedPlaylists.Uploads, nil
+	}
+}
+
+// listPlaylistVideoIDs は playlistItems.list で playlist 先頭 broadcastScanSize 件の videoID を返します。
+func (a *API) listPlaylistVideoIDs(ctx context.Context, playlistID string) ([]string, error) {
+	for {
+		key, err := a.acquireKey()
+		if err != nil {
+			return nil, err
+		}
+		service, err := a.service(ctx, key)
+		if err != nil {
+			return nil, err
 		}
-		if s := video.LiveStreamingDetails.ActualStartTime; s != "" {
-			if t, perr := time.Parse(time.RFC3339, s); perr == nil {
-				details.ActualStartTime = t
-			} else {
-				logging.Log(ctx, "warn", "YOUTUBE_API", "Failed to parse actualStartTime %q for video %s: %v", s, videoID, perr)
+
+		response, err := service.PlaylistItems.List([]string{"contentDetails"}).PlaylistId(playlistID).MaxResults(broadcastScanSize).Context(ctx).Do()
+		err = classifyAPIError(err)
+		a.observeCall("playlistItems.list", err)
+		if a.reportKey(ctx, key, err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]string, 0, len(response.Items))
+		for _, item := range response.Items {
+			if item.ContentDetails != nil && item.ContentDetails.VideoId != "" {
+				ids = append(ids, item.ContentDetails.VideoId)
 			}
 		}
+		return ids, nil
 	}
-	return details, nil
+}
+
+// listVideos は videos.list (snippet, liveStreamingDetails) を videoIDs (最大 50 件) 分まとめて呼び出します。
+func (a *API) listVideos(ctx context.Context, videoIDs []string) ([]*youtube.Video, error) {
+	for {
+		key, err := a.acquireKey()
+		if err != nil {
+			return nil, err
+		}
+		service, err := a.service(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := service.Videos.List([]string{"liveStreamingDetails", "snippet"}).Id(strings.Join(videoIDs, ",")).Context(ctx).Do()
+		err = classifyAPIError(err)
+		a.observeCall("videos.list", err)
+		if a.reportKey(ctx, key, err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return response.Items, nil
+	}
+}
+
+// parseLiveTime は liveStreamingDetails の RFC3339 時刻を解析します。空・解析失敗は zero です。
+func parseLiveTime(ctx context.Context, s, field, videoID string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		logging.Log(ctx, "warn", "YOUTUBE_API", "Failed to parse %s %q for video %s: %v", field, s, videoID, err)
+		return time.Time{}
+	}
+	return t
 }
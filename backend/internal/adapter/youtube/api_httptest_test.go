@@ -0,0 +1,266 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+// standIn は YouTube Data API の httptest スタンドインです。
+// path ごとの handler を差し替え、受け取った key クエリを記録します。
+type standIn struct {
+	mu       sync.Mutex
+	keys     []string
+	handlers map[string]http.HandlerFunc
+}
+
+func newStandIn(t *testing.T) (*standIn, *httptest.Server) {
+	t.Helper()
+	s := &standIn{handlers: make(map[string]http.HandlerFunc)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.keys = append(s.keys, r.URL.Query().Get("key"))
+		h := s.handlers[r.URL.Path]
+		s.mu.Unlock()
+		if h == nil {
+			http.NotFound(w, r)
+			return
+		}
+		h(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return s, srv
+}
+
+func (s *standIn) handle(path string, h http.HandlerFunc) {
+	s.mu.Lock()
+	s.handlers[path] = h
+	s.mu.Unlock()
+}
+
+func (s *standIn) seenKeys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.keys...)
+}
+
+// writeAPIError は googleapi 形式のエラー応答を返します。
+func writeAPIError(w http.ResponseWriter, code int, reason string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	fmt.Fprintf(w, `{"error":{"code":%d,"message":"%s","errors":[{"reason":"%s","message":"%s"}]}}`, code, reason, reason, reason)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+const liveChatPath = "/youtube/v3/liveChat/messages"
+
+func TestAPI_HTTPTest_RetriesTransientErrors(t *testing.T) {
+	s, srv := newStandIn(t)
+	calls := 0
+	s.handle(liveChatPath, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			writeAPIError(w, http.StatusServiceUnavailable, "backendError")
+			return
+		}
+		writeJSON(w, map[string]any{
+			"nextPageToken":         "next-1",
+			"pollingIntervalMillis": 5000,
+			"items": []map[string]any{{
+				"id":            "m1",
+				"snippet":       map[string]any{"displayMessage": "こんにちは", "publishedAt": "2026-06-01T10:00:00Z"},
+				"authorDetails": map[string]any{"channelId": "UC1", "displayName": "User1"},
+			}},
+		})
+	})
+
+	api := New("k-1", WithBaseURL(srv.URL), WithRetryBackoff(time.Millisecond))
+	items, next, pollMs, _, ended, err := api.ListLiveChatMessages(context.Background(), "chat-1", "")
+	if err != nil || ended {
+		t.Fatalf("ListLiveChatMessages: ended=%v err=%v", ended, err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3 (2 retries)", calls)
+	}
+	if len(items) != 1 || items[0].Message != "こんにちは" || items[0].ChannelID != "UC1" {
+		t.Errorf("items = %+v", items)
+	}
+	if next != "next-1" || pollMs != 5000 {
+		t.Errorf("next=%q pollMs=%d", next, pollMs)
+	}
+	for _, k := range s.seenKeys() {
+		if k != "k-1" {
+			t.Errorf("key query = %q, want k-1", k)
+		}
+	}
+	if len(api.services) != 1 {
+		t.Errorf("services = %d, want 1 (service reused across attempts)", len(api.services))
+	}
+}
+
+func TestAPI_HTTPTest_LiveChatEnded(t *testing.T) {
+	s, srv := newStandIn(t)
+	s.handle(liveChatPath, func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusForbidden, "liveChatEnded")
+	})
+
+	api := New("k-1", WithBaseURL(srv.URL), WithRetryBackoff(time.Millisecond))
+	items, _, _, _, ended, err := api.ListLiveChatMessages(context.Background(), "chat-1", "")
+	if err != nil || !ended || items != nil {
+		t.Errorf("got items=%v ended=%v err=%v, want ended without error", items, ended, err)
+	}
+}
+
+func TestAPI_HTTPTest_ClassifiesPermanentErrors(t *testing.T) {
+	s, srv := newStandIn(t)
+	s.handle("/youtube/v3/videos", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusNotFound, "videoNotFound")
+	})
+
+	api := New("k-1", WithBaseURL(srv.URL))
+	_, err := api.GetActiveLiveChatID(context.Background(), "vid-1")
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeVideoNotFound {
+		t.Errorf("err = %v, want video_not_found", err)
+	}
+}
+
+func TestAPI_HTTPTest_FailsOverToNextKey(t *testing.T) {
+	s, srv := newStandIn(t)
+	s.handle("/youtube/v3/videos", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") == "key-exhausted-1" {
+			writeAPIError(w, http.StatusForbidden, "quotaExceeded")
+			return
+		}
+		writeJSON(w, map[string]any{"items": []map[string]any{{
+			"id":                   "vid-1",
+			"snippet":              map[string]any{"title": "配信", "channelTitle": "ch"},
+			"liveStreamingDetails": map[string]any{"activeLiveChatId": "chat-1"},
+		}}})
+	})
+	s.handle(liveChatPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"items": []any{}})
+	})
+
+	clock := system.NewManualClock(time.Date(2026, 6, 1, 17, 0, 0, 0, time.UTC))
+	api := NewWithKeys([]string{"key-exhausted-1", "key-healthy-02"}, clock, WithBaseURL(srv.URL))
+	meta, err := api.GetActiveLiveChatID(context.Background(), "vid-1")
+	if err != nil || meta.LiveChatID != "chat-1" {
+		t.Fatalf("GetActiveLiveChatID = %+v, %v", meta, err)
+	}
+	// 2 回目以降は最初から健全なキーを使う
+	if _, _, _, _, _, err := api.ListLiveChatMessages(context.Background(), "chat-1", ""); err != nil {
+		t.Fatalf("ListLiveChatMessages: %v", err)
+	}
+
+	want := []string{"key-exhausted-1", "key-healthy-02", "key-healthy-02"}
+	got := s.seenKeys()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("keys = %v, want %v", got, want)
+	}
+	states := api.Keys.KeyStates()
+	if states[0].Healthy || states[0].LastError != string(domain.ErrCodeQuotaExceeded) || !states[1].Active {
+		t.Errorf("key states = %+v", states)
+	}
+}
+
+func TestAPI_HTTPTest_ForbiddenKeepsKeysUsable(t *testing.T) {
+	s, srv := newStandIn(t)
+	s.handle("/youtube/v3/videos", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusForbidden, "forbidden")
+	})
+
+	clock := system.NewManualClock(time.Date(2026, 6, 1, 17, 0, 0, 0, time.UTC))
+	api := NewWithKeys([]string{"key-first-0001", "key-second-002"}, clock, WithBaseURL(srv.URL))
+	_, err := api.GetActiveLiveChatID(context.Background(), "vid-1")
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeAuthFailed {
+		t.Fatalf("err = %v, want auth_failed", err)
+	}
+
+	// 動画側の 403 ではフェイルオーバーせず、どのキーも休ませない
+	if got := s.seenKeys(); fmt.Sprint(got) != fmt.Sprint([]string{"key-first-0001"}) {
+		t.Errorf("keys = %v, want only the first key", got)
+	}
+	for _, st := range api.Keys.KeyStates() {
+		if !st.Healthy || st.CooldownUntil != nil {
+			t.Errorf("key should stay usable after 403 forbidden: %+v", st)
+		}
+	}
+}
+
+func TestAPI_HTTPTest_ChannelsCachedAcrossLookups(t *testing.T) {
+	s, srv := newStandIn(t)
+	calls := 0
+	s.handle("/youtube/v3/channels", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeJSON(w, map[string]any{"items": []map[string]any{
+			{"id": "UC1", "snippet": map[string]any{
+				"title": "User1", "customUrl": "@user1",
+				"thumbnails": map[string]any{"default": map[string]any{"url": "https://example.com/u1.jpg"}},
+			}},
+			{"id": "UC2", "snippet": map[string]any{"title": "User2"}},
+		}})
+	})
+
+	api := New("k-1", WithBaseURL(srv.URL), WithChannelCache(memory.NewChannelCache(0, 0, nil)))
+	names, _ := api.GetChannelDisplayNames(context.Background(), []string{"UC1", "UC2"})
+	handles, _ := api.GetChannelHandles(context.Background(), []string{"UC1", "UC2"})
+
+	if names["UC1"] != "User1" || names["UC2"] != "User2" {
+		t.Errorf("names = %v", names)
+	}
+	if len(handles) != 1 || handles["UC1"] != "@user1" {
+		t.Errorf("handles = %v, want only UC1 (UC2 has no customUrl)", handles)
+	}
+	if calls != 1 {
+		t.Errorf("channels.list calls = %d, want 1 (handles served from cache)", calls)
+	}
+	if info, ok := api.Channels.Get("UC1"); !ok || info.AvatarURL != "https://example.com/u1.jpg" {
+		t.Errorf("cached UC1 = %+v, %v", info, ok)
+	}
+}
+
+func TestAPI_HTTPTest_ChannelsQuotaErrorReturned(t *testing.T) {
+	s, srv := newStandIn(t)
+	calls := 0
+	s.handle("/youtube/v3/channels", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeAPIError(w, http.StatusForbidden, "quotaExceeded")
+	})
+
+	cache := memory.NewChannelCache(0, 0, nil)
+	cache.Put(domain.ChannelInfo{ChannelID: "UC0", Title: "Cached"})
+	api := New("k-1", WithBaseURL(srv.URL), WithChannelCache(cache))
+	ids := []string{"UC0"}
+	for i := range 60 {
+		ids = append(ids, fmt.Sprintf("UC%d", i+1))
+	}
+	names, err := api.GetChannelDisplayNames(context.Background(), ids)
+
+	// breaker が数えられるよう、キャッシュ分の部分結果と一緒に分類済みのエラーを返す
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeQuotaExceeded {
+		t.Fatalf("err = %v, want quota_exceeded", err)
+	}
+	if len(names) != 1 || names["UC0"] != "Cached" {
+		t.Errorf("names = %v, want only the cached channel", names)
+	}
+	if calls != 1 {
+		t.Errorf("channels.list calls = %d, want 1 (remaining batch skipped after quota error)", calls)
+	}
+}
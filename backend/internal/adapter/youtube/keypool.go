@@ -0,0 +1,174 @@
+package youtube
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"google.golang.org/api/googleapi"
+)
+
+// KeyPool は複数の API キーを保持し、quota 超過・キー不正となったキーを quota リセットまで
+// 休ませて次のキーへ切り替えます。健全なキーがある間は同じキーを使い続けます。
+type KeyPool struct {
+	clock port.Clock
+
+	mu      sync.Mutex
+	keys    []*keyHealth
+	current int
+}
+
+// keyHealth はキー 1 本分の状態です。
+type keyHealth struct {
+	key           string
+	cooldownUntil time.Time
+	lastError     domain.APIErrorCode
+	lastErrorAt   time.Time
+	calls         int
+	failures      int
+}
+
+// NewKeyPool は keys (空文字と重複は除外) の KeyPool を生成します。
+func NewKeyPool(keys []string, clock port.Clock) *KeyPool {
+	p := &KeyPool{clock: clock}
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		if k == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		p.keys = append(p.keys, &keyHealth{key: k})
+	}
+	return p
+}
+
+// Len は登録されているキー数を返します。
+func (p *KeyPool) Len() int {
+	return len(p.keys)
+}
+
+// Acquire は現在使うべきキーを返します。全キーが cooldown 中なら
+// 最も早く復帰するキーの時刻を含む ErrCodeQuotaExceeded の APIError を返します。
+func (p *KeyPool) Acquire() (string, error) {
+	if len(p.keys) == 0 {
+		return "", errors.New("youtube api key is required")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+	for i := range p.keys {
+		idx := (p.current + i) % len(p.keys)
+		if !now.Before(p.keys[idx].cooldownUntil) {
+			p.current = idx
+			return p.keys[idx].key, nil
+		}
+	}
+
+	earliest := p.keys[0].cooldownUntil
+	for _, k := range p.keys[1:] {
+		if k.cooldownUntil.Before(earliest) {
+			earliest = k.cooldownUntil
+		}
+	}
+	return "", &domain.APIError{
+		Code:    domain.ErrCodeQuotaExceeded,
+		Message: fmt.Sprintf("all %d api keys are cooling down until %s", len(p.keys), earliest.Format(time.RFC3339)),
+	}
+}
+
+// Report は key で発行した呼び出し 1 attempt の結果を記録します。
+// err が quota 超過・キー不正なら key を次の quota リセットまで休ませ、true (別キーで再試行すべき) を返します。
+// reason を特定できない 401/403 (forbidden など) は動画・チャット側の問題のことがあるため、キーは休ませません。
+func (p *KeyPool) Report(key string, err error) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k := p.find(key)
+	if k == nil {
+		return false
+	}
+	k.calls++
+	if err == nil {
+		return false
+	}
+	k.failures++
+	now := p.clock.Now()
+	k.lastErrorAt = now
+
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) {
+		k.lastError = ""
+		return false
+	}
+	k.lastError = apiErr.Code
+	if !rejectsKey(apiErr) {
+		return false
+	}
+	k.cooldownUntil = domain.NextQuotaReset(now)
+	return true
+}
+
+// rejectsKey は apiErr がキー自体の問題 (quota 超過、または reason で明示されたキー不正) かを返します。
+func rejectsKey(apiErr *domain.APIError) bool {
+	switch apiErr.Code {
+	case domain.ErrCodeQuotaExceeded:
+		return true
+	case domain.ErrCodeAuthFailed:
+		var gErr *googleapi.Error
+		return errors.As(apiErr.Wrapped, &gErr) && keyInvalidReasons[extractReason(gErr)]
+	}
+	return false
+}
+
+// KeyStates は各キーの状態をマスク済みで返します (port.APIKeyReporter)。
+func (p *KeyPool) KeyStates() []domain.APIKeyState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+	states := make([]domain.APIKeyState, len(p.keys))
+	for i, k := range p.keys {
+		st := domain.APIKeyState{
+			Key:       maskKey(k.key),
+			Healthy:   !now.Before(k.cooldownUntil),
+			Active:    i == p.current,
+			LastError: string(k.lastError),
+			Calls:     k.calls,
+			Failures:  k.failures,
+		}
+		if !st.Healthy {
+			until := k.cooldownUntil
+			st.CooldownUntil = &until
+		}
+		if !k.lastErrorAt.IsZero() {
+			at := k.lastErrorAt
+			st.LastErrorAt = &at
+		}
+		states[i] = st
+	}
+	return states
+}
+
+var _ port.APIKeyReporter = (*KeyPool)(nil)
+
+// find は key の keyHealth を返します。p.mu を保持して呼ぶこと。
+func (p *KeyPool) find(key string) *keyHealth {
+	for _, k := range p.keys {
+		if k.key == key {
+			return k
+		}
+	}
+	return nil
+}
+
+// maskKey はキーを表示用にマスクします (config.maskString と同じ形式)。
+func maskKey(key string) string {
+	if len(key) <= 8 {
+		return "****"
+	}
+	return key[:4] + "****" + key[len(key)-4:]
+}
@@ -0,0 +1,101 @@
+package youtube
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"google.golang.org/api/googleapi"
+)
+
+func TestKeyPool_FailoverAndCooldownUntilReset(t *testing.T) {
+	// 2026-06-01 10:00 PDT
+	clock := system.NewManualClock(time.Date(2026, 6, 1, 17, 0, 0, 0, time.UTC))
+	pool := NewKeyPool([]string{"key-aaaa-0001", "", "key-bbbb-0002", "key-aaaa-0001"}, clock)
+	if pool.Len() != 2 {
+		t.Fatalf("Len = %d, want 2 (empty and duplicate keys dropped)", pool.Len())
+	}
+
+	key, err := pool.Acquire()
+	if err != nil || key != "key-aaaa-0001" {
+		t.Fatalf("Acquire = %q, %v", key, err)
+	}
+
+	// 一時的なエラーではキーを切り替えない
+	if pool.Report(key, &domain.APIError{Code: domain.ErrCodeRateLimited}) {
+		t.Error("rate_limited should not trigger failover")
+	}
+	if pool.Report(key, errors.New("network timeout")) {
+		t.Error("unclassified error should not trigger failover")
+	}
+	if key, _ = pool.Acquire(); key != "key-aaaa-0001" {
+		t.Fatalf("Acquire after transient errors = %q", key)
+	}
+
+	// quota 超過で次のキーへ
+	if !pool.Report(key, &domain.APIError{Code: domain.ErrCodeQuotaExceeded}) {
+		t.Fatal("quota_exceeded should trigger failover")
+	}
+	if key, _ = pool.Acquire(); key != "key-bbbb-0002" {
+		t.Fatalf("Acquire after failover = %q, want second key", key)
+	}
+
+	// reason 不明の認証失敗 (403 forbidden など) ではキーを休ませない
+	if pool.Report(key, &domain.APIError{Code: domain.ErrCodeAuthFailed}) {
+		t.Error("auth_failed without key-invalid reason should not trigger failover")
+	}
+
+	// キー不正なら cooldown、全キー枯渇で quota_exceeded
+	keyInvalid := classifyAPIError(&googleapi.Error{Code: 400, Errors: []googleapi.ErrorItem{{Reason: "API_KEY_INVALID"}}})
+	if !pool.Report(key, keyInvalid) {
+		t.Fatal("API_KEY_INVALID should trigger failover")
+	}
+	_, err = pool.Acquire()
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeQuotaExceeded {
+		t.Fatalf("Acquire with all keys cooling down = %v, want quota_exceeded", err)
+	}
+
+	states := pool.KeyStates()
+	if len(states) != 2 {
+		t.Fatalf("KeyStates len = %d", len(states))
+	}
+	first := states[0]
+	if first.Key != "key-****0001" || first.Healthy || first.LastError != string(domain.ErrCodeQuotaExceeded) {
+		t.Errorf("first key state = %+v", first)
+	}
+	if first.Calls != 3 || first.Failures != 3 {
+		t.Errorf("first key calls/failures = %d/%d, want 3/3", first.Calls, first.Failures)
+	}
+	wantReset := domain.NextQuotaReset(clock.Now())
+	if first.CooldownUntil == nil || !first.CooldownUntil.Equal(wantReset) {
+		t.Errorf("CooldownUntil = %v, want %v", first.CooldownUntil, wantReset)
+	}
+	if !states[1].Active {
+		t.Errorf("second key should be active: %+v", states[1])
+	}
+
+	// quota リセット後は現在位置から復帰したキーを使う
+	clock.Set(wantReset.Add(time.Second))
+	if key, err = pool.Acquire(); err != nil || key != "key-bbbb-0002" {
+		t.Fatalf("Acquire after reset = %q, %v", key, err)
+	}
+	for _, st := range pool.KeyStates() {
+		if !st.Healthy || st.CooldownUntil != nil {
+			t.Errorf("key should be healthy after reset: %+v", st)
+		}
+	}
+}
+
+func TestKeyPool_Empty(t *testing.T) {
+	pool := NewKeyPool(nil, system.NewManualClock(time.Time{}))
+	if _, err := pool.Acquire(); err == nil {
+		t.Fatal("Acquire on empty pool should fail")
+	}
+	api := NewWithKeys(nil, system.NewManualClock(time.Time{}))
+	if api.hasKey() {
+		t.Error("hasKey should be false for empty pool")
+	}
+}
@@ -0,0 +1,125 @@
+package youtube
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"google.golang.org/api/option"
+	"google.golang.org/api/youtube/v3"
+)
+
+const (
+	// DefaultTimeout は HTTP client 未指定時の 1 リクエストあたりのタイムアウトです。
+	DefaultTimeout = 15 * time.Second
+	// defaultRetryBackoff は一時的エラー時のリトライ間隔の基準値です (1s, 2s, 4s)。
+	defaultRetryBackoff = time.Second
+)
+
+// Option は API の接続設定を変更します。
+type Option func(*API)
+
+// WithHTTPClient は YouTube API への通信に使う HTTP client を指定します。
+// API キーは Transport をラップしてクエリに付与するため、client 側で設定する必要はありません。
+func WithHTTPClient(c *http.Client) Option {
+	return func(a *API) { a.httpClient = c }
+}
+
+// WithBaseURL は API の接続先を差し替えます (httptest のスタンドインやプロキシ用)。空文字は既定のまま。
+func WithBaseURL(baseURL string) Option {
+	return func(a *API) {
+		if baseURL != "" && !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		a.baseURL = baseURL
+	}
+}
+
+// WithTimeout は 1 リクエストあたりのタイムアウトを指定します。0 以下は既定値のまま。
+func WithTimeout(d time.Duration) Option {
+	return func(a *API) {
+		if d > 0 {
+			a.timeout = d
+		}
+	}
+}
+
+// WithChannelCache はチャンネルメタデータのキャッシュを設定します (snapshot で永続化する共有インスタンスを main から渡す)。
+func WithChannelCache(c port.ChannelCache) Option {
+	return func(a *API) {
+		if c != nil {
+			a.Channels = c
+		}
+	}
+}
+
+// WithRetryBackoff は一時的エラー時のリトライ間隔の基準値を指定します (attempt ごとに倍増)。
+func WithRetryBackoff(d time.Duration) Option {
+	return func(a *API) {
+		if d > 0 {
+			a.retryBackoff = d
+		}
+	}
+}
+
+// service は key 用の youtube.Service を返します。キーごとに 1 度だけ生成し、以降は使い回します。
+// option.WithHTTPClient を指定すると option.WithAPIKey は無視されるため、キーは apiKeyTransport で付与します。
+func (a *API) service(ctx context.Context, key string) (*youtube.Service, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if svc, ok := a.services[key]; ok {
+		return svc, nil
+	}
+
+	client := http.Client{Timeout: DefaultTimeout}
+	if a.httpClient != nil {
+		client = *a.httpClient
+	}
+	if a.timeout > 0 {
+		client.Timeout = a.timeout
+	}
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client.Transport = &apiKeyTransport{key: key, base: base}
+
+	opts := []option.ClientOption{option.WithHTTPClient(&client)}
+	if a.baseURL != "" {
+		opts = append(opts, option.WithEndpoint(a.baseURL))
+	}
+	svc, err := youtube.NewService(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if a.services == nil {
+		a.services = make(map[string]*youtube.Service)
+	}
+	a.services[key] = svc
+	return svc, nil
+}
+
+// backoff は attempt 回目 (1 始まり) の失敗後に待つ時間です。
+func (a *API) backoff(attempt int) time.Duration {
+	base := a.retryBackoff
+	if base <= 0 {
+		base = defaultRetryBackoff
+	}
+	return time.Duration(1<<uint(attempt-1)) * base
+}
+
+// apiKeyTransport はリクエストの key クエリに API キーを付与します。
+type apiKeyTransport struct {
+	key  string
+	base http.RoundTripper
+}
+
+func (t *apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	q := r.URL.Query()
+	q.Set("key", t.key)
+	r.URL.RawQuery = q.Encode()
+	return t.base.RoundTrip(r)
+}
@@ -6,6 +6,10 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 )
 
 // Config はアプリケーション設定を保持します
@@ -15,8 +19,60 @@ type Config struct {
 	YouTubeAPIKey  string
 	LogLevel       string
 	GCSBucket      string
+
+	// YouTubeAPIKeys はローテーションする API キー (YT_API_KEYS をカンマ区切り、未設定なら YT_API_KEY のみ)。
+	// quota 超過・キー不正となったキーは quota リセットまで休ませて次のキーを使う
+	YouTubeAPIKeys []string
+	// YouTubeAPIBaseURL は YouTube Data API の接続先 (YT_API_BASE_URL)。空なら既定のエンドポイント
+	YouTubeAPIBaseURL string
+	// YouTubeHTTPTimeout は YouTube API 1 リクエストあたりのタイムアウト (YT_HTTP_TIMEOUT, 例: 15s)
+	YouTubeHTTPTimeout time.Duration
+
+	// ChannelCacheSize / ChannelCacheTTL はチャンネル名・ハンドルキャッシュの最大件数と有効期限
+	// (CHANNEL_CACHE_SIZE, CHANNEL_CACHE_TTL)。0 なら既定値 (10000 件 / 24h)
+	ChannelCacheSize int
+	ChannelCacheTTL  time.Duration
+
+	// ReactionLexiconPath はリアクション辞書 JSON のパス。空なら組み込みの既定辞書を使う
+	ReactionLexiconPath string
+	// YouTubeDailyQuota は YouTube API キー 1 本あたりの 1 日の予算 (units)。残量に応じて低優先度の呼び出しを抑制する
+	YouTubeDailyQuota int
+	// TermStopwords は頻出語集計で既定の stopword に追加して除外する語 (TERM_STOPWORDS をカンマ区切り)
+	TermStopwords []string
+
+	// PollMinInterval / PollMaxInterval は monitor の自動 Pull 間隔の下限・上限
+	// (POLL_MIN_INTERVAL, POLL_MAX_INTERVAL)。0 なら既定値 (60s / 120s)
+	PollMinInterval time.Duration
+	PollMaxInterval time.Duration
+	// ChannelWatchInterval はチャンネル監視の配信検出間隔の下限 (CHANNEL_WATCH_INTERVAL)。0 なら既定値 (5m)。
+	// quota 残量が減るとこれより長くなる
+	ChannelWatchInterval time.Duration
+	// StreamEndGrace は actualEndTime から ENDED にするまでの猶予 (STREAM_END_GRACE)。未設定なら既定値 (2m)、0 なら猶予なし。
+	// 配信終了直後の末尾のコメントはこの間に取得する
+	StreamEndGrace time.Duration
+	// ViewerSampleInterval は同時視聴者数・高評価数・再生数のサンプリング間隔の下限 (VIEWER_SAMPLE_INTERVAL)。0 なら既定値 (1m)。
+	// 1 回 1 unit で、quota 残量が減るとこれより長くなる
+	ViewerSampleInterval time.Duration
+	// SnapshotJournalInterval は snapshot の差分を journal chunk として追記する間隔 (SNAPSHOT_JOURNAL_INTERVAL)。0 なら既定値 (10s)
+	SnapshotJournalInterval time.Duration
+	// SnapshotCompactInterval は journal を base snapshot に畳み込む間隔 (SNAPSHOT_COMPACT_INTERVAL)。0 なら既定値 (10m)
+	SnapshotCompactInterval time.Duration
+
+	// PullDrainMaxPages は 1 回の Pull でバックログを追いかける最大ページ数 (PULL_DRAIN_MAX_PAGES)。1 なら追いつき無効
+	PullDrainMaxPages int
+	// PullDrainQuotaReserve は追いつきを止める quota 残量の割合 (PULL_DRAIN_QUOTA_RESERVE, 0〜1)。0 なら既定値 (0.25)
+	PullDrainQuotaReserve float64
 }
 
+const (
+	// youtubeDefaultTimeout は YT_HTTP_TIMEOUT 未設定時のタイムアウトです
+	youtubeDefaultTimeout = 15 * time.Second
+	// streamEndDefaultGrace は STREAM_END_GRACE 未設定時の終了猶予です
+	streamEndDefaultGrace = 2 * time.Minute
+	// pullDrainDefaultMaxPages は PULL_DRAIN_MAX_PAGES 未設定時の追いつき上限 (最大 5 × 5 units)
+	pullDrainDefaultMaxPages = 5
+)
+
 // Load は環境変数から設定を読み込み、検証します
 func Load() (*Config, error) {
 	config := &Config{
@@ -25,6 +81,69 @@ func Load() (*Config, error) {
 		YouTubeAPIKey:  os.Getenv("YT_API_KEY"),
 		LogLevel:       getEnv("LOG_LEVEL", "info"),
 		GCSBucket:      os.Getenv("GCS_BUCKET"),
+
+		YouTubeAPIBaseURL:  os.Getenv("YT_API_BASE_URL"),
+		YouTubeHTTPTimeout: youtubeDefaultTimeout,
+		StreamEndGrace:     streamEndDefaultGrace,
+
+		ReactionLexiconPath: os.Getenv("REACTION_LEXICON_PATH"),
+		YouTubeDailyQuota:   domain.DefaultDailyQuota,
+		TermStopwords:       splitList(os.Getenv("TERM_STOPWORDS")),
+
+		PullDrainMaxPages: pullDrainDefaultMaxPages,
+	}
+
+	config.YouTubeAPIKeys = splitList(os.Getenv("YT_API_KEYS"))
+	if len(config.YouTubeAPIKeys) == 0 && config.YouTubeAPIKey != "" {
+		config.YouTubeAPIKeys = []string{config.YouTubeAPIKey}
+	}
+
+	for key, dst := range map[string]*time.Duration{
+		"YT_HTTP_TIMEOUT":           &config.YouTubeHTTPTimeout,
+		"CHANNEL_CACHE_TTL":         &config.ChannelCacheTTL,
+		"POLL_MIN_INTERVAL":         &config.PollMinInterval,
+		"POLL_MAX_INTERVAL":         &config.PollMaxInterval,
+		"CHANNEL_WATCH_INTERVAL":    &config.ChannelWatchInterval,
+		"STREAM_END_GRACE":          &config.StreamEndGrace,
+		"VIEWER_SAMPLE_INTERVAL":    &config.ViewerSampleInterval,
+		"SNAPSHOT_JOURNAL_INTERVAL": &config.SnapshotJournalInterval,
+		"SNAPSHOT_COMPACT_INTERVAL": &config.SnapshotCompactInterval,
+	} {
+		if err := durationEnv(key, dst); err != nil {
+			return nil, fmt.Errorf("config validation failed: %w", err)
+		}
+	}
+
+	if v := os.Getenv("CHANNEL_CACHE_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("config validation failed: CHANNEL_CACHE_SIZE must be an integer: %w", err)
+		}
+		config.ChannelCacheSize = n
+	}
+
+	if v := os.Getenv("YT_QUOTA_DAILY_BUDGET"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("config validation failed: YT_QUOTA_DAILY_BUDGET must be an integer: %w", err)
+		}
+		config.YouTubeDailyQuota = n
+	}
+
+	if v := os.Getenv("PULL_DRAIN_MAX_PAGES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("config validation failed: PULL_DRAIN_MAX_PAGES must be an integer: %w", err)
+		}
+		config.PullDrainMaxPages = n
+	}
+
+	if v := os.Getenv("PULL_DRAIN_QUOTA_RESERVE"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("config validation failed: PULL_DRAIN_QUOTA_RESERVE must be a number: %w", err)
+		}
+		config.PullDrainQuotaReserve = f
 	}
 
 	if err := config.Validate(); err != nil {
@@ -62,9 +181,9 @@ func (c *Config) Validate() error {
 
 	// 本番環境での必須項目チェック
 	if env == "production" {
-		// YouTubeAPIKeyは本番環境では必須
-		if c.YouTubeAPIKey == "" {
-			return errors.New("YT_API_KEY is required in production environment")
+		// YouTube API キーは本番環境では必須 (YT_API_KEY または YT_API_KEYS)
+		if c.YouTubeAPIKey == "" && len(c.YouTubeAPIKeys) == 0 {
+			return errors.New("YT_API_KEY or YT_API_KEYS is required in production environment")
 		}
 
 		// FrontendOriginは本番環境では必須（CORS設定のため）
@@ -73,6 +192,48 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.YouTubeHTTPTimeout <= 0 {
+		return errors.New("YT_HTTP_TIMEOUT must be positive")
+	}
+
+	if c.ChannelCacheSize < 0 || c.ChannelCacheTTL < 0 {
+		return errors.New("CHANNEL_CACHE_SIZE and CHANNEL_CACHE_TTL must not be negative")
+	}
+
+	if c.PollMinInterval < 0 || c.PollMaxInterval < 0 {
+		return errors.New("POLL_MIN_INTERVAL and POLL_MAX_INTERVAL must not be negative")
+	}
+	if c.PollMinInterval > 0 && c.PollMaxInterval > 0 && c.PollMinInterval > c.PollMaxInterval {
+		return errors.New("POLL_MIN_INTERVAL must not exceed POLL_MAX_INTERVAL")
+	}
+
+	if c.ChannelWatchInterval < 0 {
+		return errors.New("CHANNEL_WATCH_INTERVAL must not be negative")
+	}
+	if c.StreamEndGrace < 0 {
+		return errors.New("STREAM_END_GRACE must not be negative")
+	}
+	if c.ViewerSampleInterval < 0 {
+		return errors.New("VIEWER_SAMPLE_INTERVAL must not be negative")
+	}
+	if c.SnapshotJournalInterval < 0 {
+		return errors.New("SNAPSHOT_JOURNAL_INTERVAL must not be negative")
+	}
+	if c.SnapshotCompactInterval < 0 {
+		return errors.New("SNAPSHOT_COMPACT_INTERVAL must not be negative")
+	}
+
+	if c.PullDrainMaxPages < 1 {
+		return errors.New("PULL_DRAIN_MAX_PAGES must be at least 1")
+	}
+	if c.PullDrainQuotaReserve < 0 || c.PullDrainQuotaReserve > 1 {
+		return errors.New("PULL_DRAIN_QUOTA_RESERVE must be between 0 and 1")
+	}
+
+	if c.YouTubeDailyQuota <= 0 {
+		return errors.New("YT_QUOTA_DAILY_BUDGET must be a positive integer")
+	}
+
 	// ログレベルの検証
 	validLogLevels := []string{"debug", "info", "warn", "error"}
 	if !contains(validLogLevels, c.LogLevel) {
@@ -84,7 +245,19 @@ func (c *Config) Validate() error {
 	log.Printf("  Port: %s", c.Port)
 	log.Printf("  Frontend Origin: %s", maskString(c.FrontendOrigin))
 	log.Printf("  YouTube API Key: %s", maskString(c.YouTubeAPIKey))
+	if len(c.YouTubeAPIKeys) > 1 {
+		masked := make([]string, len(c.YouTubeAPIKeys))
+		for i, k := range c.YouTubeAPIKeys {
+			masked[i] = maskString(k)
+		}
+		log.Printf("  YouTube API Key pool: %v", masked)
+	}
+	if c.YouTubeAPIBaseURL != "" {
+		log.Printf("  YouTube API Base URL: %s", c.YouTubeAPIBaseURL)
+	}
+	log.Printf("  YouTube HTTP Timeout: %s", c.YouTubeHTTPTimeout)
 	log.Printf("  Log Level: %s", c.LogLevel)
+	log.Printf("  YouTube Daily Quota: %d units per key", c.YouTubeDailyQuota)
 
 	return nil
 }
@@ -97,6 +270,31 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// durationEnv は環境変数 key が設定されていれば Go の duration として dst に読み込みます
+func durationEnv(key string, dst *time.Duration) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("%s must be a duration (e.g. 30s, 24h): %w", key, err)
+	}
+	*dst = d
+	return nil
+}
+
+// splitList はカンマ区切りの環境変数を空要素を除いて分割します
+func splitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // contains はスライスに値が含まれているかチェックします
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// ChatSpike はコメント流量が rolling baseline を大きく上回った区間 (チャットの盛り上がり) を表します。
+// OffsetSec は配信開始時刻を起点とした秒数で、切り抜き編集用の youtu.be ?t= リンクに使います。
+type ChatSpike struct {
+	OffsetSec    int64     `json:"offsetSec"`
+	At           time.Time `json:"at"`
+	DurationSec  int64     `json:"durationSec"`
+	CommentCount int       `json:"commentCount"`
+	PeakCount    int       `json:"peakCount"` // 区間内で最大の bucket あたりコメント数
+	Baseline     float64   `json:"baseline"`  // 直前 window の bucket あたり平均コメント数
+	TopTerms     []string  `json:"topTerms"`
+	URL          string    `json:"url"`
+}
+
+// ReactionMinute は 1 分間に観測したリアクション種別ごとのメッセージ数です。
+type ReactionMinute struct {
+	Minute time.Time      `json:"minute"` // 分単位に切り捨てた publishedAt (UTC)
+	Counts map[string]int `json:"counts"` // bucket 名 -> メッセージ数
+}
+
+// TermFrequency は頻出語集計の 1 term 分です。
+// Count は term を含むコメント数 (同一コメント内の重複は 1 回)、Authors はそのコメントの投稿者数です。
+type TermFrequency struct {
+	Term    string `json:"term"`
+	Count   int    `json:"count"`
+	Authors int    `json:"authors"`
+}
+
+// ViewerSample は videos.list から取得した同時視聴者数・高評価数・再生数の 1 サンプルです。
+type ViewerSample struct {
+	At                time.Time `json:"at"`
+	ConcurrentViewers int64     `json:"concurrentViewers"` // 配信中のみ (非公開・終了後は 0)
+	LikeCount         int64     `json:"likeCount"`
+	ViewCount         int64     `json:"viewCount"`
+}
+
+// ViewerPoint は視聴者サンプル 1 件と、直前のサンプルからの区間のコメント流量です (/analytics/viewers 用)。
+type ViewerPoint struct {
+	ViewerSample
+	Comments             int     `json:"comments"`             // 直前のサンプルからこのサンプルまでのコメント数
+	CommentsPerMinute    float64 `json:"commentsPerMinute"`    // 同区間のコメント流量
+	CommentsPer1kViewers float64 `json:"commentsPer1kViewers"` // 同時視聴者 1000 人あたりの毎分コメント数 (視聴者数 0 なら 0)
+}
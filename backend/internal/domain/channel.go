@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// ChannelInfo は channels.list で解決したチャンネルのメタデータです。
+type ChannelInfo struct {
+	ChannelID string    `json:"channelId"`
+	Title     string    `json:"title"`
+	Handle    string    `json:"handle,omitempty"`    // snippet.customUrl (@username)。未設定のチャンネルは空
+	AvatarURL string    `json:"avatarUrl,omitempty"` // snippet.thumbnails.default.url
+	FetchedAt time.Time `json:"fetchedAt"`           // TTL の起点
+}
+
+// NormalizeHandle は customUrl を @username 形式に揃えます。空文字はそのまま返します。
+func NormalizeHandle(handle string) string {
+	if handle != "" && !strings.HasPrefix(handle, "@") {
+		return "@" + handle
+	}
+	return handle
+}
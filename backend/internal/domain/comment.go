@@ -10,4 +10,5 @@ type Comment struct {
 	Handle      string    `json:"handle"`
 	Message     string    `json:"message"`
 	PublishedAt time.Time `json:"publishedAt"`
+	VideoID     string    `json:"videoId,omitempty"` // 投稿先の配信 (イベントでは配信ごとに異なる)
 }
@@ -9,6 +9,8 @@ const (
 	StatusWaiting  Status = "WAITING"
 	StatusActive   Status = "ACTIVE"
 	StatusReserved Status = "RESERVED"
+	StatusPaused   Status = "PAUSED" // Pull を一時停止中 (Resume で ACTIVE に戻る)
+	StatusEnded    Status = "ENDED"  // 配信終了。users / comments は保持したまま読み取り専用
 )
 
 // LiveState は現在の配信に関する状態を保持します。
@@ -23,14 +25,58 @@ type LiveState struct {
 	AutonomousMonitoring bool      // 予約経由 ACTIVE 中はサーバー側で pull する
 	ReservedAt           time.Time // 予約を受け付けた時刻
 	ScheduledStartTime   time.Time // YouTube が返す配信予定開始時刻
+	ActualStartTime      time.Time // liveStreamingDetails.actualStartTime (未取得なら zero)。spike の offset 起点
+
+	// EventStreams はコラボ配信などで同じイベントとして追加で取得する他の配信 (VideoID が主配信)。
+	// 全配信のチャットを同じ User / Comment に取り込み、snapshot / 履歴は主配信の 1 件にまとめる
+	EventStreams []EventStream
+
+	// Transitions は状態遷移の履歴 (古い順、最大 MaxTransitionHistory 件)。snapshot と /status に含める
+	Transitions []StateTransition
+
+	// Version は StateRepo が保存のたびに 1 ずつ進める世代番号 (楽観的排他制御用)。
+	// 呼び出し側は Get で得た値をそのまま CompareAndSet に渡す。
+	Version uint64
+}
+
+// SpikeOrigin は spike の offset と ?t= リンクの起点を返します。
+// actualStartTime を取得済みならそれを、未取得なら StartedAt (取得開始時刻) を使います。
+// 配信中の /analytics/spikes と snapshot に同梱する spike で同じ起点を使うため、必ずこれを経由してください。
+func (s LiveState) SpikeOrigin() time.Time {
+	if !s.ActualStartTime.IsZero() {
+		return s.ActualStartTime
+	}
+	return s.StartedAt
 }
 
 // User represents a user with join time information
 type User struct {
 	ChannelID         string    `json:"channelId"`
 	DisplayName       string    `json:"displayName"`
+	Handle            string    `json:"handle,omitempty"` // channels.list で解決した @handle (未解決なら空)
 	JoinedAt          time.Time `json:"joinedAt"`
 	CommentCount      int       `json:"commentCount"`
 	FirstCommentedAt  time.Time `json:"firstCommentedAt"`
 	LatestCommentedAt time.Time `json:"latestCommentedAt"`
+	Streams           []string  `json:"streams,omitempty"` // コメントした配信の videoID (イベントでは複数になる)
+}
+
+// EventStream はイベントに追加した配信 1 本分のチャット取得状態です。
+type EventStream struct {
+	VideoID       string `json:"videoId"`
+	LiveChatID    string `json:"liveChatId"`
+	NextPageToken string `json:"nextPageToken,omitempty"`
+	Ended         bool   `json:"ended,omitempty"` // この配信のチャットが終了した (主配信は継続)
+}
+
+// VideoIDs は主配信とイベントに追加した配信の videoID を返します。
+func (s LiveState) VideoIDs() []string {
+	if s.VideoID == "" {
+		return nil
+	}
+	ids := []string{s.VideoID}
+	for _, es := range s.EventStreams {
+		ids = append(ids, es.VideoID)
+	}
+	return ids
 }
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// MaxMetadataRevisions は 1 配信あたりに保持するメタデータの変更履歴の上限です (古いものから捨てる)。
+const MaxMetadataRevisions = 100
+
+// MetadataRevision は配信のタイトル・概要欄・予定開始時刻のある時点の値です。
+// 配信中にタイトルが変わる (「【雑談】」→「【歌枠】」など) のを追跡するため、変化したときだけ追加します。
+type MetadataRevision struct {
+	At                 time.Time `json:"at"` // 変化を観測した時刻
+	Title              string    `json:"title"`
+	DescriptionHash    string    `json:"descriptionHash"` // 概要欄の SHA-256 (先頭 16 桁の hex)。本文は保持しない
+	ScheduledStartTime time.Time `json:"scheduledStartTime"`
+}
+
+// SameContent は r と o のタイトル・概要欄・予定開始時刻が同じかを返します (At は比較しない)。
+func (r MetadataRevision) SameContent(o MetadataRevision) bool {
+	return r.Title == o.Title && r.DescriptionHash == o.DescriptionHash && r.ScheduledStartTime.Equal(o.ScheduledStartTime)
+}
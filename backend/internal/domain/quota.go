@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"time"
+	_ "time/tzdata" // コンテナに tzdata がなくても America/Los_Angeles を解決できるようにする
+)
+
+// DefaultDailyQuota は YouTube Data API v3 の既定の 1 日あたり quota (units) です。
+const DefaultDailyQuota = 10000
+
+// QuotaCost は API method ごとの quota 消費単位です (YouTube Data API v3 の公開コスト表)。
+// エラー応答でも消費されるため、呼び出し attempt ごとに加算します。
+var QuotaCost = map[string]int{
+	"liveChatMessages.list": 5,
+	"videos.list":           1,
+	"channels.list":         1,
+	"search.list":           100,
+	"playlistItems.list":    1,
+}
+
+// FindBroadcastsMethod はチャンネルの配信検出 (channels.list → playlistItems.list → videos.list) の予算判定・拒否数の key です。
+// 実際の消費は QuotaCost の各 method に計上されます。
+const FindBroadcastsMethod = "findChannelBroadcasts"
+
+// FindBroadcastsCost は配信検出 1 回 (channels.list + playlistItems.list + videos.list) の quota 消費単位です。
+func FindBroadcastsCost() int {
+	return QuotaCost["channels.list"] + QuotaCost["playlistItems.list"] + QuotaCost["videos.list"]
+}
+
+// quotaLocation は quota のリセット基準となる太平洋時間です。
+var quotaLocation = func() *time.Location {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		return time.FixedZone("PST", -8*60*60)
+	}
+	return loc
+}()
+
+// QuotaDay は t が属する quota 日 (太平洋時間の日付, "2006-01-02") を返します。
+func QuotaDay(t time.Time) string {
+	return t.In(quotaLocation).Format("2006-01-02")
+}
+
+// NextQuotaReset は now の次の quota リセット時刻 (太平洋時間の翌日 0:00) を返します。
+func NextQuotaReset(now time.Time) time.Time {
+	local := now.In(quotaLocation)
+	return time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, quotaLocation)
+}
+
+// QuotaUsage は当日の quota 消費状況です (/status 表示用)。
+type QuotaUsage struct {
+	Day      string         `json:"day"`     // 太平洋時間の日付
+	Used     int            `json:"used"`    // 消費済み units (推定)
+	Budget   int            `json:"budget"`  // 設定上の 1 日あたり予算
+	ResetAt  time.Time      `json:"resetAt"` // 次のリセット時刻
+	ByMethod map[string]int `json:"byMethod"`
+	Refused  map[string]int `json:"refused,omitempty"` // 予算保護のため呼び出しを拒否した回数 (method 別)
+}
+
+// PacedInterval は 1 回 units を使う定期処理を、残量の share 分で次のリセットまで均等に続けられる間隔を返します。
+// 1 回分も残っていなければリセットまでの時間、予算が未設定なら 0 です。
+// monitor の Pull と配信検出・視聴者数サンプリングの間隔はこの値で quota に合わせます。
+func (q QuotaUsage) PacedInterval(now time.Time, share float64, units int) time.Duration {
+	if q.Budget <= 0 || units <= 0 {
+		return 0
+	}
+	runs := int(float64(q.Budget-q.Used)*share) / units
+	untilReset := q.ResetAt.Sub(now)
+	if runs <= 0 {
+		return untilReset
+	}
+	return untilReset / time.Duration(runs)
+}
+
+// APIKeyState は YouTube API キー 1 本分の健全性です (/status 表示用、キーはマスク済み)。
+type APIKeyState struct {
+	Key           string     `json:"key"`     // マスク済みのキー (先頭・末尾 4 文字のみ)
+	Healthy       bool       `json:"healthy"` // false の間はローテーション対象外
+	Active        bool       `json:"active"`  // 現在の呼び出しに使っているキー
+	CooldownUntil *time.Time `json:"cooldownUntil,omitempty"`
+	LastError     string     `json:"lastError,omitempty"` // 直近のエラーコード (domain.APIErrorCode)
+	LastErrorAt   *time.Time `json:"lastErrorAt,omitempty"`
+	Calls         int        `json:"calls"`    // 当プロセスでの呼び出し attempt 数
+	Failures      int        `json:"failures"` // うちエラー応答の数
+}
+
+// BreakerState は YouTube API の circuit breaker の状態です。
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"    // 通常どおり呼び出す
+	BreakerOpen     BreakerState = "open"      // quota 超過・rate limit のため RetryAt まで呼び出さない
+	BreakerHalfOpen BreakerState = "half_open" // RetryAt を過ぎ、試行の呼び出し 1 回の結果待ち
+)
+
+// BreakerStatus は YouTube API の circuit breaker の状態です (/status 表示用)。
+type BreakerStatus struct {
+	State    BreakerState `json:"state"`
+	Reason   APIErrorCode `json:"reason,omitempty"`  // open にしたエラーコード (quota_exceeded / rate_limited)
+	Message  string       `json:"message,omitempty"` // open にしたエラーの詳細
+	OpenedAt *time.Time   `json:"openedAt,omitempty"`
+	RetryAt  *time.Time   `json:"retryAt,omitempty"` // 次に試行する時刻 (quota 超過なら太平洋時間 0:00 のリセット)
+	Trips    int          `json:"trips"`             // 当プロセスで open になった回数
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextQuotaReset_PacificMidnight(t *testing.T) {
+	tests := []struct {
+		name    string
+		now     time.Time
+		wantDay string
+		want    time.Time
+	}{
+		{
+			// 2026-01-15 07:59 UTC = 2026-01-14 23:59 PST
+			name:    "冬時間 (PST, UTC-8)",
+			now:     time.Date(2026, 1, 15, 7, 59, 0, 0, time.UTC),
+			wantDay: "2026-01-14",
+			want:    time.Date(2026, 1, 15, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			// 2026-07-15 07:00 UTC = 2026-07-15 00:00 PDT
+			name:    "夏時間 (PDT, UTC-7)",
+			now:     time.Date(2026, 7, 15, 7, 0, 0, 0, time.UTC),
+			wantDay: "2026-07-15",
+			want:    time.Date(2026, 7, 16, 7, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := QuotaDay(tt.now); got != tt.wantDay {
+				t.Errorf("QuotaDay = %q, want %q", got, tt.wantDay)
+			}
+			if got := NextQuotaReset(tt.now); !got.Equal(tt.want) {
+				t.Errorf("NextQuotaReset = %v, want %v", got.UTC(), tt.want)
+			}
+		})
+	}
+}
+
+func TestQuotaUsage_PacedInterval(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	q := QuotaUsage{Budget: 10000, Used: 9000, ResetAt: now.Add(time.Hour)}
+	tests := []struct {
+		name  string
+		q     QuotaUsage
+		share float64
+		units int
+		want  time.Duration
+	}{
+		{name: "残量 1000 units を 5 units ずつ", q: q, share: 1, units: 5, want: 18 * time.Second},
+		{name: "残量の 10% を 1 unit ずつ", q: q, share: 0.1, units: 1, want: 36 * time.Second},
+		{name: "1 回分も残っていない", q: QuotaUsage{Budget: 10000, Used: 9998, ResetAt: now.Add(time.Hour)}, share: 1, units: 3, want: time.Hour},
+		{name: "予算未設定", q: QuotaUsage{ResetAt: now.Add(time.Hour)}, share: 1, units: 1, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.q.PacedInterval(now, tt.share, tt.units); got != tt.want {
+				t.Errorf("PacedInterval = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
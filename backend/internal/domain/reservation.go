@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// ReservationStatus は予約キューの 1 件ごとの状態です。
+type ReservationStatus string
+
+const (
+	ReservationQueued   ReservationStatus = "QUEUED"   // 順番待ち
+	ReservationPromoted ReservationStatus = "PROMOTED" // 現在の予約 (RESERVED) に昇格済み
+	ReservationCanceled ReservationStatus = "CANCELED" // 取消済み
+	ReservationFailed   ReservationStatus = "FAILED"   // 昇格時に予約できなかった (非 live など、Reason に理由)
+)
+
+// MaxReservationHistory は予約キューに残す終了済み (QUEUED 以外) の件数の上限です。
+const MaxReservationHistory = 20
+
+// Reservation は予約キューの 1 件です。videoID ごとに 1 件で、ID は VideoID と同じです。
+type Reservation struct {
+	VideoID            string            `json:"videoId"`
+	ScheduledStartTime time.Time         `json:"scheduledStartTime"`
+	QueuedAt           time.Time         `json:"queuedAt"`
+	Status             ReservationStatus `json:"status"`
+	UpdatedAt          time.Time         `json:"updatedAt"`
+	Reason             string            `json:"reason,omitempty"`
+}
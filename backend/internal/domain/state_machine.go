@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// MaxTransitionHistory は LiveState.Transitions に保持する遷移の最大件数です。
+const MaxTransitionHistory = 50
+
+// StateTransition は状態遷移 1 件分の記録です。
+type StateTransition struct {
+	From   Status    `json:"from"`
+	To     Status    `json:"to"`
+	At     time.Time `json:"at"`
+	Reason string    `json:"reason"` // 遷移のきっかけ (例: "reserve", "stream_ended")
+}
+
+// transitions は許可する状態遷移の表です (同一状態への遷移は常に許可)。
+//
+//	WAITING  → RESERVED (予約) / ACTIVE (切替) / ENDED (終了済み配信の復元)
+//	RESERVED → WAITING (予約取消) / ACTIVE (配信開始) / ENDED (終了済み配信の復元)
+//	ACTIVE   → WAITING (リセット) / PAUSED (一時停止) / ENDED (配信終了)
+//	PAUSED   → WAITING (リセット) / ACTIVE (再開・切替) / ENDED (配信終了)
+//	ENDED    → WAITING (リセット) / RESERVED (次の予約) / ACTIVE (再切替)
+var transitions = map[Status][]Status{
+	StatusWaiting:  {StatusReserved, StatusActive, StatusEnded},
+	StatusReserved: {StatusWaiting, StatusActive, StatusEnded},
+	StatusActive:   {StatusWaiting, StatusPaused, StatusEnded},
+	StatusPaused:   {StatusWaiting, StatusActive, StatusEnded},
+	StatusEnded:    {StatusWaiting, StatusReserved, StatusActive},
+}
+
+// normalize は未初期化 (空文字) の Status を WAITING として扱います。
+func (s Status) normalize() Status {
+	if s == "" {
+		return StatusWaiting
+	}
+	return s
+}
+
+// InSession は配信セッションが進行中 (ACTIVE / PAUSED) かを返します。
+// 進行中のセッションは Reserve / CancelReserve で上書きせず、Reset を要求します。
+func (s Status) InSession() bool {
+	return s == StatusActive || s == StatusPaused
+}
+
+// CanTransition は from から to への遷移が許可されているかを返します。
+func CanTransition(from, to Status) bool {
+	from, to = from.normalize(), to.normalize()
+	if from == to {
+		return true
+	}
+	return slices.Contains(transitions[from], to)
+}
+
+// Transition は現在の状態 s から next への遷移を検証し、遷移履歴と Version を引き継いだ next を返します。
+// next.Status への遷移が許可されていない場合は ErrCodeConflict の APIError を返します。
+// 同一状態への遷移 (例: 別 video への切替) は履歴に残しません。
+func (s LiveState) Transition(next LiveState, at time.Time, reason string) (LiveState, error) {
+	from, to := s.Status.normalize(), next.Status.normalize()
+	if !CanTransition(from, to) {
+		return s, &APIError{Code: ErrCodeConflict, Message: fmt.Sprintf("cannot %s: invalid state transition %s → %s", reason, from, to)}
+	}
+	next.Version = s.Version
+	next.Transitions = s.Transitions
+	if from != to {
+		history := append(slices.Clone(s.Transitions), StateTransition{From: from, To: to, At: at, Reason: reason})
+		if len(history) > MaxTransitionHistory {
+			history = history[len(history)-MaxTransitionHistory:]
+		}
+		next.Transitions = history
+	}
+	return next, nil
+}
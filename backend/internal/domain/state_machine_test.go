@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		want     bool
+	}{
+		{"", StatusReserved, true}, // 未初期化は WAITING 扱い
+		{StatusWaiting, StatusActive, true},
+		{StatusWaiting, StatusPaused, false},
+		{StatusReserved, StatusActive, true},
+		{StatusReserved, StatusPaused, false},
+		{StatusActive, StatusPaused, true},
+		{StatusActive, StatusEnded, true},
+		{StatusActive, StatusReserved, false},
+		{StatusPaused, StatusActive, true},
+		{StatusPaused, StatusReserved, false},
+		{StatusEnded, StatusPaused, false},
+		{StatusEnded, StatusReserved, true},
+		{StatusActive, StatusActive, true}, // 同一状態 (別 video への切替など)
+	}
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestLiveState_TransitionRecordsHistory(t *testing.T) {
+	at := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	cur := LiveState{Status: StatusActive, VideoID: "v1", Version: 7}
+
+	next, err := cur.Transition(LiveState{Status: StatusPaused, VideoID: "v1"}, at, "pause")
+	if err != nil {
+		t.Fatalf("Transition: %v", err)
+	}
+	if next.Version != 7 {
+		t.Errorf("Version = %d, want 7 (carried over for CompareAndSet)", next.Version)
+	}
+	want := StateTransition{From: StatusActive, To: StatusPaused, At: at, Reason: "pause"}
+	if len(next.Transitions) != 1 || next.Transitions[0] != want {
+		t.Errorf("Transitions = %+v, want [%+v]", next.Transitions, want)
+	}
+
+	same, _ := next.Transition(next, at, "noop")
+	if len(same.Transitions) != 1 {
+		t.Errorf("self transition should not be recorded: %+v", same.Transitions)
+	}
+}
+
+func TestLiveState_TransitionRejectsInvalid(t *testing.T) {
+	cur := LiveState{Status: StatusWaiting}
+	_, err := cur.Transition(LiveState{Status: StatusPaused}, time.Now(), "pause")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrCodeConflict {
+		t.Errorf("err = %v, want conflict", err)
+	}
+}
+
+func TestLiveState_TransitionHistoryIsCapped(t *testing.T) {
+	at := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	st := LiveState{Status: StatusActive}
+	for i := range MaxTransitionHistory + 10 {
+		to := StatusPaused
+		if st.Status == StatusPaused {
+			to = StatusActive
+		}
+		st, _ = st.Transition(LiveState{Status: to}, at.Add(time.Duration(i)*time.Second), "toggle")
+	}
+	if len(st.Transitions) != MaxTransitionHistory {
+		t.Fatalf("len = %d, want %d", len(st.Transitions), MaxTransitionHistory)
+	}
+	if last := st.Transitions[len(st.Transitions)-1]; !last.At.Equal(at.Add(time.Duration(MaxTransitionHistory+9) * time.Second)) {
+		t.Errorf("newest transition dropped: %+v", last)
+	}
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// MaxChannelWatches は同時に監視できるチャンネル数の上限です (検出 1 回ごとに quota を消費するため)。
+const MaxChannelWatches = 10
+
+// ChannelWatch は次の配信を自動検出するために監視しているチャンネル 1 件です。
+// Channel は登録時の channelID または @handle で、監視の ID を兼ねます。
+type ChannelWatch struct {
+	Channel       string    `json:"channel"`
+	AddedAt       time.Time `json:"addedAt"`
+	LastCheckedAt time.Time `json:"lastCheckedAt"`
+	LastVideoID   string    `json:"lastVideoId,omitempty"` // 直近に検出して Reserve / SwitchVideo した videoID
+	LastError     string    `json:"lastError,omitempty"`   // 直近の検出失敗の理由 (成功でクリア)
+}
@@ -0,0 +1,11 @@
+package port
+
+import "context"
+
+// StateBlobStore は snapshot 以外の小さな運用状態 (quota ledger 等) を key 単位で永続化します。
+type StateBlobStore interface {
+	// LoadBlob は key の内容を返します。存在しない場合は (nil, nil) を返します。
+	LoadBlob(ctx context.Context, key string) ([]byte, error)
+	// SaveBlob は key の内容を上書き保存します。
+	SaveBlob(ctx context.Context, key string, data []byte) error
+}
@@ -0,0 +1,21 @@
+package port
+
+// UserProfileUpdater は解決済みのチャンネル情報で既存ユーザーを更新します (ChannelResolver の backfill 用)。
+type UserProfileUpdater interface {
+	// UpdateProfile は channelID のユーザーの displayName / handle を更新します。
+	// 空文字のフィールドは更新しません。ユーザーが存在しない場合は false を返します。
+	UpdateProfile(channelID, displayName, handle string) bool
+}
+
+// CommentAuthorUpdater は解決済みのチャンネル情報で保存済みコメントの投稿者情報を更新します。
+type CommentAuthorUpdater interface {
+	// UpdateAuthor は channelID の全コメントの displayName / handle を更新し、変更した件数を返します。
+	// 空文字のフィールドは更新しません。
+	UpdateAuthor(channelID, displayName, handle string) int
+}
+
+// UserStreamRecorder はユーザーがコメントした配信 (videoID) を記録します (イベントの統合ユーザー一覧用)。
+type UserStreamRecorder interface {
+	// RecordStream は channelID のユーザーの Streams に videoID を追加します (記録済みなら何もしない)。
+	RecordStream(channelID, videoID string)
+}
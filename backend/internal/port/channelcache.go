@@ -0,0 +1,20 @@
+package port
+
+import "github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+
+// ChannelCache は channels.list の解決結果を保持するキャッシュです。
+// 複数 goroutine (HTTP handler / monitor) から同時に呼ばれます。
+type ChannelCache interface {
+	// Get は有効期限内のエントリを返します。期限切れ・未登録は false。
+	Get(channelID string) (domain.ChannelInfo, bool)
+	// Put はエントリを登録・更新します。容量を超えた場合は最も使われていないエントリを捨てます。
+	Put(info domain.ChannelInfo)
+}
+
+// ChannelSnapshotSource は ChannelCache の snapshot dump/restore port です。
+type ChannelSnapshotSource interface {
+	// Dump は有効期限内のエントリを返します。
+	Dump() []domain.ChannelInfo
+	// LoadFrom は既存エントリに infos をマージします (video を跨いで使い回すため置き換えない)。
+	LoadFrom(infos []domain.ChannelInfo)
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+// ChannelWatchRepo は次の配信を自動検出するために監視しているチャンネルの一覧を保持します。
+type ChannelWatchRepo interface {
+	// List は監視中のチャンネルを登録順で返します (空なら空スライス)。
+	List(ctx context.Context) ([]domain.ChannelWatch, error)
+	// Save は監視一覧全体を置き換えて保存します。
+	Save(ctx context.Context, list []domain.ChannelWatch) error
+	// Update は現在の監視一覧を fn で置き換えて保存し、保存後の一覧を返します。
+	// 読み出しから保存までを他の Update / Save と排他するため、並行する更新を取りこぼしません。
+	// fn がエラーを返した場合は保存せずにそのエラーを返します。
+	Update(ctx context.Context, fn func(list []domain.ChannelWatch) ([]domain.ChannelWatch, error)) ([]domain.ChannelWatch, error)
+}
@@ -12,6 +12,10 @@ type CommentRepo interface {
 	// returns non-nil slice (empty slice when no matches)
 	SearchByKeywords(keywords []string) []domain.Comment
 
+	// ListSortedByPublishedAt は全コメントを時系列順（古い順）で返します
+	// returns non-nil slice (empty slice when no comments)
+	ListSortedByPublishedAt() []domain.Comment
+
 	// Clear は全コメントを削除します
 	Clear()
 
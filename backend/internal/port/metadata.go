@@ -0,0 +1,20 @@
+package port
+
+import "github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+
+// MetadataRepo は配信のタイトル・概要欄・予定開始時刻の変更履歴を保持します。
+type MetadataRepo interface {
+	// Record は rev が直前の履歴と異なるときだけ追加し、追加したかを返します。
+	Record(rev domain.MetadataRevision) bool
+	// Revisions は履歴を古い順で返します。
+	// returns non-nil slice (empty slice when no revisions)
+	Revisions() []domain.MetadataRevision
+	// Clear は全履歴を削除します。
+	Clear()
+}
+
+// MetadataSnapshotSource は in-memory MetadataRepo の snapshot dump/restore port です。
+type MetadataSnapshotSource interface {
+	Dump() []domain.MetadataRevision
+	LoadFrom(revisions []domain.MetadataRevision)
+}
@@ -0,0 +1,18 @@
+package port
+
+import "github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+
+// QuotaReporter は YouTube API quota の当日消費状況を返します (/status 表示用)。
+type QuotaReporter interface {
+	Usage() domain.QuotaUsage
+}
+
+// APIKeyReporter は YouTube API キープールの各キーの状態を返します (/status 表示用)。
+type APIKeyReporter interface {
+	KeyStates() []domain.APIKeyState
+}
+
+// BreakerReporter は YouTube API の circuit breaker の状態を返します (/status 表示・monitor の休止判定用)。
+type BreakerReporter interface {
+	BreakerStatus() domain.BreakerStatus
+}
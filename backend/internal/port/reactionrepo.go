@@ -0,0 +1,24 @@
+package port
+
+import (
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+// ReactionRepo はリアクション (草 / 888 等) の分単位カウンタを保持します。
+type ReactionRepo interface {
+	// Record は at を分単位に切り捨てた枠の各 bucket を 1 加算します。
+	Record(at time.Time, buckets []string)
+	// Timeline は分単位カウンタを時系列順（古い順）で返します。
+	// returns non-nil slice (empty slice when no reactions)
+	Timeline() []domain.ReactionMinute
+	// Clear は全カウンタを削除します。
+	Clear()
+}
+
+// ReactionSnapshotSource は in-memory ReactionRepo の snapshot dump/restore port です。
+type ReactionSnapshotSource interface {
+	Dump() []domain.ReactionMinute
+	LoadFrom(minutes []domain.ReactionMinute)
+}
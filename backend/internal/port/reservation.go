@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+// ReservationRepo は連続配信用の予約キューを保持します。
+// 順序はキュー内の並び (先頭から昇格) で、QUEUED 以外の件は状態表示用の履歴です。
+type ReservationRepo interface {
+	// List は予約キューを並び順で返します (空なら空スライス)。
+	List(ctx context.Context) ([]domain.Reservation, error)
+	// Save は予約キュー全体を置き換えて保存します。
+	Save(ctx context.Context, list []domain.Reservation) error
+	// Update は現在の予約キューを fn で置き換えて保存し、保存後のキューを返します。
+	// 読み出しから保存までを他の Update / Save と排他するため、並行する更新を取りこぼしません。
+	// fn がエラーを返した場合は保存せずにそのエラーを返します。
+	Update(ctx context.Context, fn func(list []domain.Reservation) ([]domain.Reservation, error)) ([]domain.Reservation, error)
+}
@@ -9,16 +9,38 @@ import (
 
 // Snapshot は単一 video の状態スナップショットです。
 type Snapshot struct {
-	SchemaVersion int               `json:"schemaVersion"`
-	VideoID       string            `json:"videoId"`
-	LiveChatID    string            `json:"liveChatId"`
-	VideoTitle    string            `json:"videoTitle,omitempty"`
-	ChannelTitle  string            `json:"channelTitle,omitempty"`
-	SavedAt       time.Time         `json:"savedAt"`
-	Users         []domain.User     `json:"users"`
-	Comments      []domain.Comment  `json:"comments"`
-	ProcessedMsgs []string          `json:"processedMsgs"`
-	State         *domain.LiveState `json:"state,omitempty"` // nil の場合は旧 snapshot 互換として skip
+	SchemaVersion int                       `json:"schemaVersion"`
+	VideoID       string                    `json:"videoId"`
+	LiveChatID    string                    `json:"liveChatId"`
+	VideoTitle    string                    `json:"videoTitle,omitempty"`
+	ChannelTitle  string                    `json:"channelTitle,omitempty"`
+	SavedAt       time.Time                 `json:"savedAt"`
+	Users         []domain.User             `json:"users"`
+	Comments      []domain.Comment          `json:"comments"`
+	ProcessedMsgs []string                  `json:"processedMsgs"`
+	State         *domain.LiveState         `json:"state,omitempty"`  // nil の場合は旧 snapshot 互換として skip
+	Spikes        []domain.ChatSpike        `json:"spikes,omitempty"` // 配信終了後の save でのみ埋める
+	Reactions     []domain.ReactionMinute   `json:"reactions,omitempty"`
+	Viewers       []domain.ViewerSample     `json:"viewers,omitempty"`    // 同時視聴者数・高評価数・再生数の時系列
+	Metadata      []domain.MetadataRevision `json:"metadata,omitempty"`   // タイトル・概要欄・予定開始時刻の変更履歴 (古い順)
+	Channels      []domain.ChannelInfo      `json:"channels,omitempty"`   // channels.list の解決結果 (再起動時のキャッシュ warm-load 用)
+	JournalSeq    int                       `json:"journalSeq,omitempty"` // この snapshot に取り込み済みの journal chunk の最終 Seq
+}
+
+// JournalChunk は base snapshot 以降の差分 (追加・更新されたユーザー・コメント・付随データ、処理済みメッセージID) です。
+// Seq は coordinator ごとに単調増加し、compaction を跨いでも巻き戻りません。
+type JournalChunk struct {
+	VideoID       string                    `json:"videoId"`
+	Seq           int                       `json:"seq"`
+	WrittenAt     time.Time                 `json:"writtenAt"`
+	Users         []domain.User             `json:"users,omitempty"`
+	Comments      []domain.Comment          `json:"comments,omitempty"`
+	ProcessedMsgs []string                  `json:"processedMsgs,omitempty"`
+	State         *domain.LiveState         `json:"state,omitempty"`     // 直前の chunk から変わったときのみ
+	Reactions     []domain.ReactionMinute   `json:"reactions,omitempty"` // 加算された分のカウンタ (書き込み時点の値)
+	Viewers       []domain.ViewerSample     `json:"viewers,omitempty"`   // 追加されたサンプル
+	Metadata      []domain.MetadataRevision `json:"metadata,omitempty"`  // 追加された履歴
+	Channels      []domain.ChannelInfo      `json:"channels,omitempty"`  // 登録・更新されたチャンネルメタデータ
 }
 
 // CurrentPointer は現在アクティブな video を指すポインタです。
@@ -48,3 +70,13 @@ type SnapshotSink interface {
 	// current.json は除外します。malformed file は warn skip します。
 	List(ctx context.Context) ([]SnapshotSummary, error)
 }
+
+// JournalSink は SnapshotSink の任意拡張で、差分を追記専用の chunk として書き込みます。
+// coordinator は sink がこの interface を実装していれば、毎回の全量書き換えの代わりに journal を使います。
+type JournalSink interface {
+	AppendJournal(ctx context.Context, chunk *JournalChunk) error
+	// LoadJournal は videoID の chunk を Seq の昇順で返します。不在時は空 slice を返します。
+	LoadJournal(ctx context.Context, videoID string) ([]JournalChunk, error)
+	// DeleteJournal は videoID の chunk をすべて削除します (compaction 後の後始末)。
+	DeleteJournal(ctx context.Context, videoID string) error
+}
@@ -22,3 +22,28 @@ type CommentSnapshotSource interface {
 	Dump() []domain.Comment
 	LoadFrom(comments []domain.Comment)
 }
+
+// Changes は ChangeSource が前回の TakeChanges 以降に記録した変更です。
+type Changes[T any] struct {
+	Items []T  // 追加・更新された要素 (取り出し時点の値)
+	Reset bool // Clear / LoadFrom で内容が置き換えられた。削除は差分で表せないため全量 save が必要
+}
+
+// ChangeSource は書き込み時に追加・更新された要素を記録する in-memory repo の port です。
+// coordinator は取り出した変更だけを journal chunk に書きます。
+// TakeChanges を一度も呼ばなければ記録しません (最初の呼び出しは空の変更を返し、以後の記録を始めます)。
+type ChangeSource[T any] interface {
+	TakeChanges() Changes[T]
+}
+
+// UserChanges は UserRepo が前回の TakeChanges 以降に記録した変更です。
+type UserChanges struct {
+	Users         []domain.User // 追加・更新されたユーザー (取り出し時点の値)
+	ProcessedMsgs []string      // 新たに処理済みになったメッセージID
+	Reset         bool          // Clear / LoadFrom で内容が置き換えられた
+}
+
+// UserChangeSource は UserRepo の変更を取り出す port です (ChangeSource のユーザー版)。
+type UserChangeSource interface {
+	TakeChanges() UserChanges
+}
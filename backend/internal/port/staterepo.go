@@ -6,7 +6,12 @@ import (
 )
 
 // StateRepo は配信状態を永続化（または InMemory 保持）します。
+// 保存のたびに LiveState.Version が進みます (st.Version の値は無視して repo が採番)。
 type StateRepo interface {
 	Get(ctx context.Context) (domain.LiveState, error)
+	// Set は現在の状態に関係なく st で上書きします (Reset・snapshot 復元など)。
 	Set(ctx context.Context, st domain.LiveState) error
+	// CompareAndSet は st.Version が現在の Version と一致するときだけ st を保存し、保存後の状態を返します。
+	// 他の操作が先に保存していた場合は domain.ErrCodeConflict の APIError を返します。
+	CompareAndSet(ctx context.Context, st domain.LiveState) (domain.LiveState, error)
 }
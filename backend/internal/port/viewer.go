@@ -0,0 +1,20 @@
+package port
+
+import "github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+
+// ViewerRepo は同時視聴者数・高評価数・再生数のサンプル (時系列) を保持します。
+type ViewerRepo interface {
+	// Record はサンプルを追加します。
+	Record(sample domain.ViewerSample)
+	// Samples はサンプルを時系列順（古い順）で返します。
+	// returns non-nil slice (empty slice when no samples)
+	Samples() []domain.ViewerSample
+	// Clear は全サンプルを削除します。
+	Clear()
+}
+
+// ViewerSnapshotSource は in-memory ViewerRepo の snapshot dump/restore port です。
+type ViewerSnapshotSource interface {
+	Dump() []domain.ViewerSample
+	LoadFrom(samples []domain.ViewerSample)
+}
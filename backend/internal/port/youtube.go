@@ -5,6 +5,10 @@ import (
 	"time"
 )
 
+// LiveChatMaxResults は liveChatMessages.list の 1 ページあたり最大件数です (API 上限)。
+// 返ってきた件数がこれに達したページは後続のバックログが残っている可能性が高いとみなします。
+const LiveChatMaxResults = 2000
+
 // ChatMessage は YouTube Live Chat のメッセージの最小情報です。
 type ChatMessage struct {
 	ID          string // メッセージID（重複チェック用）
@@ -26,11 +30,25 @@ type VideoLiveDetails struct {
 	LiveChatID         string // activeLiveChatId (チャット未開なら空)
 	Title              string
 	ChannelTitle       string
+	Description        string    // snippet.description
 	ScheduledStartTime time.Time // liveStreamingDetails.scheduledStartTime (未指定なら zero)
 	ActualStartTime    time.Time // liveStreamingDetails.actualStartTime (未開始なら zero)
+	ActualEndTime      time.Time // liveStreamingDetails.actualEndTime (配信中・未開始なら zero)
+	ConcurrentViewers  int64     // liveStreamingDetails.concurrentViewers (配信中のみ、非公開・未提供なら 0)
+	LikeCount          int64     // statistics.likeCount (非公開なら 0)
+	ViewCount          int64     // statistics.viewCount
 	IsLiveContent      bool      // liveStreamingDetails != nil なら true
 }
 
+// Broadcast はチャンネルの配信中・配信予定のライブ配信 1 件です (チャンネル監視用)。
+type Broadcast struct {
+	VideoID            string
+	Title              string
+	ScheduledStartTime time.Time // 未指定なら zero
+	ActualStartTime    time.Time // 未開始なら zero
+	Live               bool      // 配信中なら true、配信予定なら false
+}
+
 // YouTubePort は YouTube API 呼び出しを抽象化します。
 type YouTubePort interface {
 	// 指定 videoID の activeLiveChatId と動画メタデータを取得します。
@@ -45,4 +63,7 @@ type YouTubePort interface {
 	GetChannelHandles(ctx context.Context, channelIDs []string) (map[string]string, error)
 	// 指定 videoID の liveStreamingDetails を取得します。activeLiveChatId 空でもエラーにせず返します (予約用)。
 	GetVideoLiveDetails(ctx context.Context, videoID string) (VideoLiveDetails, error)
+	// チャンネル (channelID または @handle) の配信中・配信予定のライブ配信を返します。
+	// 配信中を先頭に、配信予定は ScheduledStartTime の早い順に並べます。チャンネルが無ければ video_not_found。
+	FindChannelBroadcasts(ctx context.Context, channel string) ([]Broadcast, error)
 }
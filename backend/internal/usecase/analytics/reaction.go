@@ -0,0 +1,157 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ReactionBucket はリアクション種別 1 つ分の判定ルールです。
+// URL を除いた正規化後メッセージに対し、Tokens は部分一致、Patterns は空白区切りの各トークンへの正規表現一致で判定します。
+type ReactionBucket struct {
+	Name     string
+	Tokens   []string
+	Patterns []*regexp.Regexp
+}
+
+// Lexicon はチャットメッセージをリアクション bucket に分類する辞書です。
+// 判定前にメッセージを NFKC 正規化 + 小文字化するため、"ｗｗ" / "８８８" など全角表記も半角と同一視されます。
+type Lexicon struct {
+	buckets []ReactionBucket
+}
+
+// DefaultLexicon は日本語チャット向けの既定辞書 (laugh / applause / kawaii) を返します。
+func DefaultLexicon() *Lexicon {
+	return &Lexicon{buckets: []ReactionBucket{
+		{
+			Name:   "laugh",
+			Tokens: []string{"草", "笑", "lol", "lmao"},
+			// トークン末尾 (後ろは記号のみ) の w を拾う。日本語などの直後なら 1 文字 ("それはないw") から、
+			// それ以外は 2 文字以上とし、"wow" などの英単語は除外する (URL は bucketMatches で除外)
+			Patterns: []*regexp.Regexp{regexp.MustCompile(`(?:[^\x00-\x7f]w+|(?:^|[^a-z0-9])w{2,})[\p{P}\p{S}]*$`)},
+		},
+		{
+			Name:     "applause",
+			Tokens:   []string{"パチパチ", "ぱちぱち", "👏"},
+			Patterns: []*regexp.Regexp{regexp.MustCompile(`8{3,}`)},
+		},
+		{
+			Name:     "kawaii",
+			Tokens:   []string{"かわいい", "かわいー", "かわよ", "可愛い", "カワイイ", "cute"},
+			Patterns: []*regexp.Regexp{regexp.MustCompile(`kawa+i+`)},
+		},
+	}}
+}
+
+// lexiconFile は ParseLexicon が受け付ける JSON 形式です。
+//
+//	{"buckets": [{"name": "laugh", "tokens": ["草"], "patterns": ["w{2,}"]}]}
+type lexiconFile struct {
+	Buckets []struct {
+		Name     string   `json:"name"`
+		Tokens   []string `json:"tokens"`
+		Patterns []string `json:"patterns"`
+	} `json:"buckets"`
+}
+
+// ParseLexicon は JSON 定義から Lexicon を生成します。
+// tokens は NFKC 正規化 + 小文字化してから登録するため、定義側の全角/半角は問いません。
+func ParseLexicon(data []byte) (*Lexicon, error) {
+	var f lexiconFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("lexicon: unmarshal: %w", err)
+	}
+	if len(f.Buckets) == 0 {
+		return nil, fmt.Errorf("lexicon: no buckets defined")
+	}
+
+	lex := &Lexicon{}
+	seen := map[string]bool{}
+	for _, b := range f.Buckets {
+		if b.Name == "" {
+			return nil, fmt.Errorf("lexicon: bucket name is required")
+		}
+		if seen[b.Name] {
+			return nil, fmt.Errorf("lexicon: duplicate bucket %q", b.Name)
+		}
+		seen[b.Name] = true
+
+		bucket := ReactionBucket{Name: b.Name}
+		for _, t := range b.Tokens {
+			if t = normalizeText(t); t != "" {
+				bucket.Tokens = append(bucket.Tokens, t)
+			}
+		}
+		for _, p := range b.Patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("lexicon: bucket %q pattern %q: %w", b.Name, p, err)
+			}
+			bucket.Patterns = append(bucket.Patterns, re)
+		}
+		lex.buckets = append(lex.buckets, bucket)
+	}
+	return lex, nil
+}
+
+// Buckets は定義順の bucket 名一覧を返します。
+func (l *Lexicon) Buckets() []string {
+	names := make([]string, len(l.buckets))
+	for i, b := range l.buckets {
+		names[i] = b.Name
+	}
+	return names
+}
+
+// Classify はメッセージが該当する bucket 名を定義順で返します (該当なしは nil)。
+// 1 メッセージが複数 bucket に該当することはありますが、同一 bucket は 1 回だけ数えます。
+func (l *Lexicon) Classify(message string) []string {
+	msg := normalizeText(message)
+	if msg == "" {
+		return nil
+	}
+
+	var matched []string
+	for _, b := range l.buckets {
+		if bucketMatches(b, msg) {
+			matched = append(matched, b.Name)
+		}
+	}
+	return matched
+}
+
+func bucketMatches(b ReactionBucket, msg string) bool {
+	var words []string
+	for _, w := range strings.Fields(msg) {
+		if !isURLToken(w) {
+			words = append(words, w)
+		}
+	}
+	text := strings.Join(words, " ")
+	for _, t := range b.Tokens {
+		if strings.Contains(text, t) {
+			return true
+		}
+	}
+	for _, re := range b.Patterns {
+		for _, w := range words {
+			if re.MatchString(w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isURLToken は URL らしいトークン ("https://…" / "www.…") かを返します。URL 中の "www" などを反応として数えないためのものです。
+func isURLToken(w string) bool {
+	return strings.Contains(w, "://") || strings.HasPrefix(w, "www.")
+}
+
+// normalizeText は全角英数 (ｗ, ８) を半角に寄せ、小文字化します (Lexicon / Tokenizer 共通)。
+func normalizeText(s string) string {
+	return strings.ToLower(strings.TrimSpace(norm.NFKC.String(s)))
+}
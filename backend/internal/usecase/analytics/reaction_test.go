@@ -0,0 +1,79 @@
+package analytics_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/analytics"
+)
+
+func TestDefaultLexicon_Classify(t *testing.T) {
+	lex := analytics.DefaultLexicon()
+	tests := []struct {
+		name    string
+		message string
+		want    []string
+	}{
+		{"草", "草", []string{"laugh"}},
+		{"w の連なり", "それはないwww", []string{"laugh"}},
+		{"全角ｗ", "ｗｗｗ", []string{"laugh"}},
+		{"英単語中の w は除外", "wow nice", nil},
+		{"日本語の後ろの単独の w", "それはないw", []string{"laugh"}},
+		{"日本語の後ろの単独の全角ｗ", "おもしろｗ!", []string{"laugh"}},
+		{"英単語末尾の w は除外", "show now", nil},
+		{"URL の www は除外", "www.example.com みて", nil},
+		{"URL 中の w の連なりは除外", "https://example.com/www", nil},
+		{"URL の後ろの www", "https://example.com www", []string{"laugh"}},
+		{"末尾でない w の連なりは除外", "wwwですね", nil},
+		{"末尾の記号は許容", "それはないwww!!", []string{"laugh"}},
+		{"888", "8888888", []string{"applause"}},
+		{"全角８８８", "８８８", []string{"applause"}},
+		{"88 は対象外", "88", nil},
+		{"拍手絵文字", "👏👏", []string{"applause"}},
+		{"かわいい", "かわいいｗｗ", []string{"laugh", "kawaii"}},
+		{"kawaii 大文字", "KAWAII", []string{"kawaii"}},
+		{"該当なし", "こんにちは", nil},
+		{"空", "  ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lex.Classify(tt.message)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Classify(%q) = %v, want %v", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLexicon(t *testing.T) {
+	t.Run("tokens は正規化して登録される", func(t *testing.T) {
+		lex, err := analytics.ParseLexicon([]byte(`{"buckets":[{"name":"gg","tokens":["ＧＧ"]},{"name":"laugh","patterns":["w{2,}"]}]}`))
+		if err != nil {
+			t.Fatalf("ParseLexicon error: %v", err)
+		}
+		if got := lex.Buckets(); !reflect.DeepEqual(got, []string{"gg", "laugh"}) {
+			t.Errorf("Buckets() = %v", got)
+		}
+		if got := lex.Classify("gg"); !reflect.DeepEqual(got, []string{"gg"}) {
+			t.Errorf("Classify(gg) = %v", got)
+		}
+		if got := lex.Classify("w"); got != nil {
+			t.Errorf("Classify(w) = %v, want nil", got)
+		}
+	})
+
+	invalid := map[string]string{
+		"不正な JSON":    `{`,
+		"bucket なし":   `{"buckets":[]}`,
+		"name なし":     `{"buckets":[{"tokens":["a"]}]}`,
+		"name 重複":     `{"buckets":[{"name":"a"},{"name":"a"}]}`,
+		"不正な pattern": `{"buckets":[{"name":"a","patterns":["("]}]}`,
+	}
+	for name, data := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := analytics.ParseLexicon([]byte(data)); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Package analytics はコメント列に対する集計・検出ロジック (外部 I/O なし) を提供する。
+package analytics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+// SpikeConfig は DetectSpikes の検出パラメータです。
+type SpikeConfig struct {
+	Bucket    time.Duration // 集計粒度 (0 なら 30s)
+	Window    int           // rolling baseline に使う直前 bucket 数 (0 なら 10)
+	Threshold float64       // baseline に対する倍率 (0 なら 3.0)
+	MinCount  int           // spike と見なす bucket あたり最小コメント数 (0 なら 10)
+	TopTerms  int           // spike ごとに返す頻出語数 (0 なら 5)
+	Tokenizer *Tokenizer    // 頻出語の分解に使う Tokenizer (nil なら DefaultTokenizer)
+}
+
+// DefaultSpikeConfig は 30s bucket / 直前 5 分 baseline / 3 倍を spike とする既定値を返します。
+func DefaultSpikeConfig() SpikeConfig {
+	return SpikeConfig{Bucket: 30 * time.Second, Window: 10, Threshold: 3.0, MinCount: 10, TopTerms: 5}
+}
+
+func (c SpikeConfig) withDefaults() SpikeConfig {
+	d := DefaultSpikeConfig()
+	if c.Bucket <= 0 {
+		c.Bucket = d.Bucket
+	}
+	if c.Window <= 0 {
+		c.Window = d.Window
+	}
+	if c.Threshold <= 0 {
+		c.Threshold = d.Threshold
+	}
+	if c.MinCount <= 0 {
+		c.MinCount = d.MinCount
+	}
+	if c.TopTerms <= 0 {
+		c.TopTerms = d.TopTerms
+	}
+	if c.Tokenizer == nil {
+		c.Tokenizer = DefaultTokenizer()
+	}
+	return c
+}
+
+// DetectSpikes は origin (配信開始時刻) 起点でコメントを bucket 集計し、
+// 直前 Window bucket の平均 (rolling baseline) の Threshold 倍を超えた bucket を spike として返します。
+// 連続する spike bucket は 1 区間にまとめます。origin より前のコメントは対象外です。
+// returns non-nil slice (empty slice when no spikes)
+func DetectSpikes(comments []domain.Comment, origin time.Time, videoID string, cfg SpikeConfig) []domain.ChatSpike {
+	cfg = cfg.withDefaults()
+	spikes := []domain.ChatSpike{}
+	if origin.IsZero() || len(comments) == 0 {
+		return spikes
+	}
+
+	// bucket index -> コメント
+	buckets := map[int][]domain.Comment{}
+	last := -1
+	for _, c := range comments {
+		d := c.PublishedAt.Sub(origin)
+		if d < 0 {
+			continue
+		}
+		idx := int(d / cfg.Bucket)
+		buckets[idx] = append(buckets[idx], c)
+		if idx > last {
+			last = idx
+		}
+	}
+	if last < 0 {
+		return spikes
+	}
+
+	counts := make([]int, last+1)
+	for idx, cs := range buckets {
+		counts[idx] = len(cs)
+	}
+
+	var run *domain.ChatSpike
+	var runComments []domain.Comment
+	closeRun := func() {
+		if run == nil {
+			return
+		}
+		run.TopTerms = topTerms(runComments, cfg.TopTerms, cfg.Tokenizer)
+		spikes = append(spikes, *run)
+		run = nil
+		runComments = nil
+	}
+
+	for i, n := range counts {
+		baseline := rollingMean(counts, i, cfg.Window)
+		// 配信冒頭は baseline が存在しないため spike 判定しない
+		isSpike := i > 0 && n >= cfg.MinCount && float64(n) >= max(baseline, 1)*cfg.Threshold
+		if !isSpike {
+			closeRun()
+			continue
+		}
+		if run == nil {
+			offset := int64((time.Duration(i) * cfg.Bucket) / time.Second)
+			run = &domain.ChatSpike{
+				OffsetSec: offset,
+				At:        origin.Add(time.Duration(i) * cfg.Bucket),
+				Baseline:  baseline,
+				URL:       VideoTimestampURL(videoID, offset),
+			}
+		}
+		run.DurationSec += int64(cfg.Bucket / time.Second)
+		run.CommentCount += n
+		run.PeakCount = max(run.PeakCount, n)
+		runComments = append(runComments, buckets[i]...)
+	}
+	closeRun()
+
+	return spikes
+}
+
+// VideoTimestampURL は offsetSec 秒目から再生する youtu.be の deep link を返します。
+func VideoTimestampURL(videoID string, offsetSec int64) string {
+	return fmt.Sprintf("https://youtu.be/%s?t=%d", videoID, offsetSec)
+}
+
+// rollingMean は counts[i] 直前 window 件の平均を返します (i=0 は 0)。
+func rollingMean(counts []int, i, window int) float64 {
+	from := max(i-window, 0)
+	if from >= i {
+		return 0
+	}
+	sum := 0
+	for _, n := range counts[from:i] {
+		sum += n
+	}
+	return float64(sum) / float64(i-from)
+}
+
+// topTerms は TopTerms の term 文字列だけを返します (ChatSpike.TopTerms 用)。
+func topTerms(comments []domain.Comment, k int, tok *Tokenizer) []string {
+	freqs := TopTerms(comments, k, tok)
+	terms := make([]string, len(freqs))
+	for i, f := range freqs {
+		terms[i] = f.Term
+	}
+	return terms
+}
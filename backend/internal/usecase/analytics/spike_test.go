@@ -0,0 +1,79 @@
+package analytics_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/analytics"
+)
+
+// genComments は origin+at から step 間隔で n 件のコメントを生成する。
+func genComments(origin time.Time, at time.Duration, n int, step time.Duration, msg string) []domain.Comment {
+	out := make([]domain.Comment, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, domain.Comment{
+			ID:          fmt.Sprintf("%s-%d-%d", msg, at, i),
+			Message:     msg,
+			PublishedAt: origin.Add(at + time.Duration(i)*step),
+		})
+	}
+	return out
+}
+
+func TestDetectSpikes_FindsBurstAgainstBaseline(t *testing.T) {
+	origin := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	var comments []domain.Comment
+	// 0-10 分: 30s あたり 2 件の平常流量
+	for m := 0; m < 20; m++ {
+		comments = append(comments, genComments(origin, time.Duration(m)*30*time.Second, 2, time.Second, "こんにちは")...)
+	}
+	// 10 分 00 秒から 1 bucket 内に 40 件の盛り上がり
+	comments = append(comments, genComments(origin, 10*time.Minute, 40, 500*time.Millisecond, "草 gg")...)
+
+	spikes := analytics.DetectSpikes(comments, origin, "vid00000001", analytics.DefaultSpikeConfig())
+	if len(spikes) != 1 {
+		t.Fatalf("len(spikes) = %d, want 1: %+v", len(spikes), spikes)
+	}
+	s := spikes[0]
+	if s.OffsetSec != 600 {
+		t.Errorf("OffsetSec = %d, want 600", s.OffsetSec)
+	}
+	if s.URL != "https://youtu.be/vid00000001?t=600" {
+		t.Errorf("URL = %q", s.URL)
+	}
+	if s.CommentCount != 40 {
+		t.Errorf("CommentCount = %d, want 40", s.CommentCount)
+	}
+	if len(s.TopTerms) == 0 || (s.TopTerms[0] != "gg" && s.TopTerms[0] != "草") {
+		t.Errorf("TopTerms = %v, want gg/草 first", s.TopTerms)
+	}
+}
+
+func TestDetectSpikes_MergesConsecutiveBuckets(t *testing.T) {
+	origin := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	var comments []domain.Comment
+	for m := 0; m < 10; m++ {
+		comments = append(comments, genComments(origin, time.Duration(m)*30*time.Second, 1, time.Second, "a")...)
+	}
+	comments = append(comments, genComments(origin, 5*time.Minute, 60, 500*time.Millisecond, "b")...)
+
+	spikes := analytics.DetectSpikes(comments, origin, "v", analytics.DefaultSpikeConfig())
+	if len(spikes) != 1 {
+		t.Fatalf("len(spikes) = %d, want 1 (consecutive buckets merged)", len(spikes))
+	}
+	if spikes[0].DurationSec != 30 && spikes[0].DurationSec != 60 {
+		t.Errorf("DurationSec = %d, want 30 or 60", spikes[0].DurationSec)
+	}
+}
+
+func TestDetectSpikes_NoOriginOrNoComments(t *testing.T) {
+	if got := analytics.DetectSpikes(nil, time.Now(), "v", analytics.SpikeConfig{}); got == nil || len(got) != 0 {
+		t.Errorf("want empty non-nil slice, got %v", got)
+	}
+	comments := []domain.Comment{{ID: "1", PublishedAt: time.Now()}}
+	if got := analytics.DetectSpikes(comments, time.Time{}, "v", analytics.SpikeConfig{}); got == nil || len(got) != 0 {
+		t.Errorf("want empty non-nil slice for zero origin, got %v", got)
+	}
+}
@@ -0,0 +1,224 @@
+package analytics
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+// defaultNGram は漢字・ひらがなの連なりを分割する文字 n-gram の既定長です。
+const defaultNGram = 2
+
+// DefaultStopwords は頻出語集計から除外する既定の語 (助詞・助動詞由来の bigram と英語の機能語) です。
+func DefaultStopwords() []string {
+	return []string{
+		// ひらがな bigram (活用語尾・助詞の組み合わせ)
+		"です", "ます", "した", "して", "てる", "ない", "いる", "ある", "から", "まで",
+		"けど", "って", "これ", "それ", "あれ", "この", "その", "よね", "だね", "んで",
+		"のは", "には", "では", "とか", "なの", "だよ",
+		// 英語の機能語
+		"a", "an", "the", "is", "are", "to", "of", "and", "in", "on", "it", "i", "you",
+	}
+}
+
+// Tokenizer は分かち書きのない日本語チャットを頻出語集計用の term 列に分解します。
+//
+// メッセージは NFKC 正規化 + 小文字化した上で文字種 (英数字 / ひらがな / カタカナ / 漢字) の
+// 境界で区切られ、記号・空白・絵文字は区切り文字として捨てられます。
+// 英数字とカタカナの連なりはそのまま 1 term、漢字とひらがなの連なりは n 文字より長ければ
+// 文字 n-gram に分解します (1 文字のひらがなは助詞とみなして捨てます)。
+// 同一文字の 4 連以上 ("wwwww", "88888") は 3 連に丸め、表記ゆれを 1 term に寄せます。
+type Tokenizer struct {
+	ngram     int
+	stopwords map[string]bool
+}
+
+// NewTokenizer は n-gram 長と stopword 一覧から Tokenizer を生成します (ngram <= 0 は既定値 2)。
+// stopword も term と同じ正規化をしてから登録します。
+func NewTokenizer(ngram int, stopwords []string) *Tokenizer {
+	if ngram <= 0 {
+		ngram = defaultNGram
+	}
+	t := &Tokenizer{ngram: ngram, stopwords: make(map[string]bool, len(stopwords))}
+	for _, w := range stopwords {
+		if w = normalizeText(w); w != "" {
+			t.stopwords[w] = true
+		}
+	}
+	return t
+}
+
+// DefaultTokenizer は bigram + DefaultStopwords の Tokenizer を返します。
+func DefaultTokenizer() *Tokenizer {
+	return NewTokenizer(defaultNGram, DefaultStopwords())
+}
+
+// WithStopwords は stopword を追加した Tokenizer のコピーを返します (レシーバは変更しません)。
+func (t *Tokenizer) WithStopwords(extra []string) *Tokenizer {
+	if len(extra) == 0 {
+		return t
+	}
+	cp := &Tokenizer{ngram: t.ngram, stopwords: make(map[string]bool, len(t.stopwords)+len(extra))}
+	for w := range t.stopwords {
+		cp.stopwords[w] = true
+	}
+	for _, w := range extra {
+		if w = normalizeText(w); w != "" {
+			cp.stopwords[w] = true
+		}
+	}
+	return cp
+}
+
+// Tokenize はメッセージを term 列に分解します (出現順、重複あり)。
+func (t *Tokenizer) Tokenize(message string) []string {
+	var terms []string
+	emit := func(term string) {
+		if term != "" && !t.stopwords[term] {
+			terms = append(terms, term)
+		}
+	}
+
+	for _, run := range splitScriptRuns(normalizeText(message)) {
+		runes := []rune(squeezeRepeats(run.text))
+		switch run.script {
+		case scriptWord, scriptKatakana:
+			emit(string(runes))
+		case scriptHan, scriptHiragana:
+			if run.script == scriptHiragana && len(runes) == 1 {
+				continue
+			}
+			if len(runes) <= t.ngram {
+				emit(string(runes))
+				continue
+			}
+			for i := 0; i+t.ngram <= len(runes); i++ {
+				emit(string(runes[i : i+t.ngram]))
+			}
+		}
+	}
+	return terms
+}
+
+// TopTerms はコメント本文を tok で分解し、出現コメント数の多い順に k 件を返します。
+// 同じコメント内の重複 term は 1 回として数えます (連投・コピペの影響を抑えるため)。
+// returns non-nil slice (empty slice when no terms)
+func TopTerms(comments []domain.Comment, k int, tok *Tokenizer) []domain.TermFrequency {
+	if tok == nil {
+		tok = DefaultTokenizer()
+	}
+	freq := map[string]int{}
+	authors := map[string]map[string]bool{}
+	for _, c := range comments {
+		seen := map[string]bool{}
+		for _, term := range tok.Tokenize(c.Message) {
+			if seen[term] {
+				continue
+			}
+			seen[term] = true
+			freq[term]++
+			if authors[term] == nil {
+				authors[term] = map[string]bool{}
+			}
+			authors[term][c.ChannelID] = true
+		}
+	}
+
+	out := make([]domain.TermFrequency, 0, len(freq))
+	for term, n := range freq {
+		out = append(out, domain.TermFrequency{Term: term, Count: n, Authors: len(authors[term])})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		if out[i].Authors != out[j].Authors {
+			return out[i].Authors > out[j].Authors
+		}
+		return out[i].Term < out[j].Term
+	})
+	if k > 0 && len(out) > k {
+		out = out[:k]
+	}
+	return out
+}
+
+type script int
+
+const (
+	scriptNone script = iota // 区切り文字 (空白・記号・絵文字)
+	scriptWord               // 英数字 (ラテン文字以外のアルファベットも含む)
+	scriptHiragana
+	scriptKatakana
+	scriptHan
+)
+
+type scriptRun struct {
+	script script
+	text   string
+}
+
+// splitScriptRuns は文字種が変わる位置と区切り文字で文字列を分割します。
+// 長音符 "ー" は直前のかな (かわいー / ゲーム) に連結します。
+func splitScriptRuns(s string) []scriptRun {
+	var runs []scriptRun
+	var b strings.Builder
+	cur := scriptNone
+	flush := func() {
+		if cur != scriptNone && b.Len() > 0 {
+			runs = append(runs, scriptRun{script: cur, text: b.String()})
+		}
+		b.Reset()
+	}
+
+	for _, r := range s {
+		sc := scriptOf(r)
+		if r == 'ー' && (cur == scriptHiragana || cur == scriptKatakana) {
+			sc = cur
+		}
+		if sc != cur {
+			flush()
+			cur = sc
+		}
+		if sc != scriptNone {
+			b.WriteRune(r)
+		}
+	}
+	flush()
+	return runs
+}
+
+func scriptOf(r rune) script {
+	switch {
+	case unicode.Is(unicode.Hiragana, r):
+		return scriptHiragana
+	case unicode.Is(unicode.Katakana, r), r == 'ー':
+		return scriptKatakana
+	case unicode.Is(unicode.Han, r), r == '々':
+		return scriptHan
+	case unicode.IsLetter(r), unicode.IsDigit(r):
+		return scriptWord
+	default:
+		return scriptNone
+	}
+}
+
+// squeezeRepeats は同一文字の 4 連以上を 3 連に丸めます ("wwwww" → "www")。
+func squeezeRepeats(s string) string {
+	var b strings.Builder
+	var prev rune
+	repeat := 0
+	for _, r := range s {
+		if r == prev {
+			repeat++
+		} else {
+			prev, repeat = r, 1
+		}
+		if repeat <= 3 {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package analytics_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/analytics"
+)
+
+func TestTokenizer_Tokenize(t *testing.T) {
+	tok := analytics.NewTokenizer(2, nil)
+	tests := []struct {
+		name    string
+		message string
+		want    []string
+	}{
+		{"文字種境界で分割", "ゲーム実況GG!", []string{"ゲーム", "実況", "gg"}},
+		{"漢字の連なりは bigram", "神回確定", []string{"神回", "回確", "確定"}},
+		{"ひらがな 1 文字は捨てる", "草は生える", []string{"草", "生", "える"}},
+		{"長音符はかなに連結", "かわいー", []string{"かわ", "わい", "いー"}},
+		{"全角英数と同一文字の連続を正規化", "ｗｗｗｗｗ 8888", []string{"www", "888"}},
+		{"記号と絵文字は区切り", "最高👏👏!!", []string{"最高"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tok.Tokenize(tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize(%q) = %v, want %v", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizer_Stopwords(t *testing.T) {
+	tok := analytics.DefaultTokenizer()
+	if got := tok.Tokenize("the ゲームです"); !reflect.DeepEqual(got, []string{"ゲーム"}) {
+		t.Errorf("Tokenize = %v, want [ゲーム]", got)
+	}
+
+	extended := tok.WithStopwords([]string{"ＧＧ"})
+	if got := extended.Tokenize("gg ゲーム"); !reflect.DeepEqual(got, []string{"ゲーム"}) {
+		t.Errorf("extended Tokenize = %v, want [ゲーム]", got)
+	}
+	// 元の Tokenizer は変更されない
+	if got := tok.Tokenize("gg"); !reflect.DeepEqual(got, []string{"gg"}) {
+		t.Errorf("original Tokenize = %v, want [gg]", got)
+	}
+}
+
+func TestTopTerms_CountsOncePerComment(t *testing.T) {
+	comments := []domain.Comment{
+		{ChannelID: "a", Message: "草 草 草"},
+		{ChannelID: "a", Message: "草"},
+		{ChannelID: "b", Message: "草 ゲーム"},
+		{ChannelID: "c", Message: "ゲーム"},
+	}
+	got := analytics.TopTerms(comments, 10, nil)
+	want := []domain.TermFrequency{
+		{Term: "草", Count: 3, Authors: 2},
+		{Term: "ゲーム", Count: 2, Authors: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TopTerms = %+v, want %+v", got, want)
+	}
+
+	if got := analytics.TopTerms(comments, 1, nil); len(got) != 1 || got[0].Term != "草" {
+		t.Errorf("TopTerms k=1 = %+v", got)
+	}
+	if got := analytics.TopTerms(nil, 10, nil); got == nil || len(got) != 0 {
+		t.Errorf("TopTerms(nil) = %v, want empty slice", got)
+	}
+}
@@ -0,0 +1,78 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+)
+
+// firstViewerWindow は最初のサンプルのコメント流量を数える区間です (直前のサンプルが無いため)。
+const firstViewerWindow = time.Minute
+
+// minCorrelationPoints は相関係数を計算する最少の点数です。これ未満では nil を返します。
+const minCorrelationPoints = 3
+
+// ViewerTimeline は視聴者サンプルごとに、直前のサンプルからの区間のコメント流量を対応づけます。
+// 最初のサンプルは直前 1 分を区間とします。comments は時系列順 (古い順) であること。
+// returns non-nil slice (empty slice when no samples)
+func ViewerTimeline(samples []domain.ViewerSample, comments []domain.Comment) []domain.ViewerPoint {
+	points := make([]domain.ViewerPoint, 0, len(samples))
+	for i, s := range samples {
+		from := s.At.Add(-firstViewerWindow)
+		if i > 0 {
+			from = samples[i-1].At
+		}
+		window := s.At.Sub(from)
+		if window <= 0 {
+			continue // 同時刻のサンプルは区間が取れないため除く
+		}
+		// (from, s.At] のコメント数
+		lo := sort.Search(len(comments), func(j int) bool { return comments[j].PublishedAt.After(from) })
+		hi := sort.Search(len(comments), func(j int) bool { return comments[j].PublishedAt.After(s.At) })
+		p := domain.ViewerPoint{ViewerSample: s, Comments: hi - lo}
+		p.CommentsPerMinute = float64(p.Comments) / window.Minutes()
+		if s.ConcurrentViewers > 0 {
+			p.CommentsPer1kViewers = p.CommentsPerMinute * 1000 / float64(s.ConcurrentViewers)
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+// ViewerCommentCorrelation は同時視聴者数と毎分コメント数の相関係数 (Pearson) を返します。
+// 同時視聴者数が 0 の点 (非公開・終了後) は除き、点が少ないか値が一定なら nil を返します。
+func ViewerCommentCorrelation(points []domain.ViewerPoint) *float64 {
+	var xs, ys []float64
+	for _, p := range points {
+		if p.ConcurrentViewers > 0 {
+			xs = append(xs, float64(p.ConcurrentViewers))
+			ys = append(ys, p.CommentsPerMinute)
+		}
+	}
+	if len(xs) < minCorrelationPoints {
+		return nil
+	}
+	mx, my := mean(xs), mean(ys)
+	var cov, vx, vy float64
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		cov += dx * dy
+		vx += dx * dx
+		vy += dy * dy
+	}
+	if vx == 0 || vy == 0 {
+		return nil
+	}
+	r := cov / math.Sqrt(vx*vy)
+	return &r
+}
+
+func mean(xs []float64) float64 {
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
@@ -0,0 +1,72 @@
+package analytics_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/analytics"
+)
+
+func TestViewerTimeline_CommentRatePerSampleWindow(t *testing.T) {
+	base := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	samples := []domain.ViewerSample{
+		{At: base, ConcurrentViewers: 100},
+		{At: base.Add(2 * time.Minute), ConcurrentViewers: 200},
+		{At: base.Add(3 * time.Minute), ConcurrentViewers: 400},
+	}
+	var comments []domain.Comment
+	add := func(at time.Time, n int) {
+		for range n {
+			comments = append(comments, domain.Comment{PublishedAt: at})
+		}
+	}
+	add(base.Add(-30*time.Second), 2)  // 最初のサンプルの直前 1 分
+	add(base.Add(90*time.Second), 6)   // 2 分間の区間
+	add(base.Add(150*time.Second), 12) // 1 分間の区間
+
+	points := analytics.ViewerTimeline(samples, comments)
+	if len(points) != 3 {
+		t.Fatalf("len(points) = %d, want 3", len(points))
+	}
+	want := []struct {
+		comments int
+		perMin   float64
+		per1k    float64
+	}{{2, 2, 20}, {6, 3, 15}, {12, 12, 30}}
+	for i, w := range want {
+		p := points[i]
+		if p.Comments != w.comments || p.CommentsPerMinute != w.perMin || p.CommentsPer1kViewers != w.per1k {
+			t.Errorf("points[%d] = %+v, want comments=%d perMin=%v per1k=%v", i, p, w.comments, w.perMin, w.per1k)
+		}
+	}
+
+	r := analytics.ViewerCommentCorrelation(points)
+	if r == nil || *r <= 0.5 || *r > 1 {
+		t.Errorf("correlation = %v, want strongly positive", r)
+	}
+}
+
+func TestViewerCommentCorrelation_NilWhenTooFewOrConstant(t *testing.T) {
+	points := []domain.ViewerPoint{
+		{ViewerSample: domain.ViewerSample{ConcurrentViewers: 10}, CommentsPerMinute: 1},
+		{ViewerSample: domain.ViewerSample{ConcurrentViewers: 0}, CommentsPerMinute: 5}, // 視聴者数 0 は除く
+		{ViewerSample: domain.ViewerSample{ConcurrentViewers: 20}, CommentsPerMinute: 2},
+	}
+	if r := analytics.ViewerCommentCorrelation(points); r != nil {
+		t.Errorf("correlation with 2 points = %v, want nil", *r)
+	}
+	points = append(points, domain.ViewerPoint{ViewerSample: domain.ViewerSample{ConcurrentViewers: 30}, CommentsPerMinute: 3})
+	if r := analytics.ViewerCommentCorrelation(points); r == nil || math.Abs(*r-1) > 1e-9 {
+		t.Errorf("correlation = %v, want 1", r)
+	}
+	flat := []domain.ViewerPoint{
+		{ViewerSample: domain.ViewerSample{ConcurrentViewers: 10}, CommentsPerMinute: 1},
+		{ViewerSample: domain.ViewerSample{ConcurrentViewers: 20}, CommentsPerMinute: 1},
+		{ViewerSample: domain.ViewerSample{ConcurrentViewers: 30}, CommentsPerMinute: 1},
+	}
+	if r := analytics.ViewerCommentCorrelation(flat); r != nil {
+		t.Errorf("correlation with constant rate = %v, want nil", *r)
+	}
+}
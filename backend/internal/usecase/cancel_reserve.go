@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
@@ -17,24 +16,22 @@ type CancelReserveOutput struct {
 type CancelReserve struct {
 	State port.StateRepo
 	Snap  snapshot.Coordinator
+	Clock port.Clock // 任意: 遷移履歴の時刻 (nil なら time.Now)
 }
 
-// Execute: RESERVED/WAITING を WAITING に正規化 (冪等)。
-// ACTIVE 中は 409 Conflict で拒否 — Reserve とシンメトリックに現セッションを守る。
+// Execute: RESERVED/WAITING/ENDED を WAITING に正規化 (冪等)。
+// ACTIVE / PAUSED 中は 409 Conflict で拒否 — Reserve とシンメトリックに現セッションを守る。
 // users/comments は触らない (Reserve では users 未変更のため)。
 func (uc *CancelReserve) Execute(ctx context.Context) (CancelReserveOutput, error) {
-	cur, err := uc.State.Get(ctx)
+	newState, err := updateState(ctx, uc.State, func(cur domain.LiveState) (domain.LiveState, error) {
+		// ACTIVE 中のキャンセルは state 破壊につながるため拒否する
+		if cur.Status.InSession() {
+			return cur, &domain.APIError{Code: domain.ErrCodeConflict, Message: "stream is currently active, reset first"}
+		}
+		return cur.Transition(domain.LiveState{Status: domain.StatusWaiting}, clockNow(uc.Clock), "cancel_reserve")
+	})
 	if err != nil {
-		return CancelReserveOutput{}, fmt.Errorf("state_get: %w", err)
-	}
-	// ACTIVE 中のキャンセルは state 破壊につながるため拒否する
-	if cur.Status == domain.StatusActive {
-		return CancelReserveOutput{}, &domain.APIError{Code: domain.ErrCodeConflict, Message: "stream is currently active, reset first"}
-	}
-
-	newState := domain.LiveState{Status: domain.StatusWaiting}
-	if err := uc.State.Set(ctx, newState); err != nil {
-		return CancelReserveOutput{}, fmt.Errorf("state_set: %w", err)
+		return CancelReserveOutput{}, err
 	}
 
 	uc.Snap.MarkDirty()
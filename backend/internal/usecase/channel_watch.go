@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+const (
+	// DefaultWatchInterval はチャンネル 1 件あたりの配信検出の最短間隔です。
+	DefaultWatchInterval = 5 * time.Minute
+	// DefaultWatchQuotaShare は次の quota リセットまでの残量のうち、配信検出に使ってよい割合です。
+	// 複数セッションでは、この割合をセッション数で分け合う。
+	// 残量が減るほど検出間隔が延び、チャットポーリングの分を食わないようにする。
+	DefaultWatchQuotaShare = 0.10
+)
+
+type WatchInput struct {
+	Channel string // channelID (UC...) または @handle
+}
+
+type WatchOutput struct {
+	Watches []domain.ChannelWatch
+}
+
+// WatchChannel はチャンネルを監視一覧に追加する。
+// 以降、配信が無い間は monitor が DetectBroadcast でそのチャンネルの次の配信を探す。
+type WatchChannel struct {
+	Watches port.ChannelWatchRepo
+	Clock   port.Clock
+}
+
+// Execute: channelID / @handle 以外は invalid_argument、登録済みなら conflict、上限超過は invalid_argument。
+func (uc *WatchChannel) Execute(ctx context.Context, in WatchInput) (WatchOutput, error) {
+	channel, err := normalizeWatchChannel(in.Channel)
+	if err != nil {
+		return WatchOutput{}, err
+	}
+	now := clockNow(uc.Clock)
+	list, err := updateWatches(ctx, uc.Watches, func(list []domain.ChannelWatch) ([]domain.ChannelWatch, error) {
+		if indexWatch(list, channel) >= 0 {
+			return nil, &domain.APIError{Code: domain.ErrCodeConflict, Message: fmt.Sprintf("channel %s is already watched", channel)}
+		}
+		if len(list) >= domain.MaxChannelWatches {
+			return nil, &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: fmt.Sprintf("at most %d channels can be watched", domain.MaxChannelWatches)}
+		}
+		return append(list, domain.ChannelWatch{Channel: channel, AddedAt: now}), nil
+	})
+	if err != nil {
+		return WatchOutput{}, err
+	}
+	logging.Log(ctx, "info", "WATCH", "watching channel %s (%d watched)", channel, len(list))
+	return WatchOutput{Watches: list}, nil
+}
+
+// UnwatchChannel はチャンネルを監視一覧から外す。検出済みの予約・配信はそのまま残す。
+type UnwatchChannel struct {
+	Watches port.ChannelWatchRepo
+}
+
+// Execute: 監視していなければ video_not_found。
+func (uc *UnwatchChannel) Execute(ctx context.Context, in WatchInput) (WatchOutput, error) {
+	channel := strings.TrimSpace(in.Channel)
+	list, err := updateWatches(ctx, uc.Watches, func(list []domain.ChannelWatch) ([]domain.ChannelWatch, error) {
+		i := indexWatch(list, channel)
+		if i < 0 {
+			return nil, &domain.APIError{Code: domain.ErrCodeVideoNotFound, Message: fmt.Sprintf("channel %s is not watched", channel)}
+		}
+		return slices.Delete(list, i, i+1), nil
+	})
+	if err != nil {
+		return WatchOutput{}, err
+	}
+	logging.Log(ctx, "info", "WATCH", "stopped watching channel %s", channel)
+	return WatchOutput{Watches: list}, nil
+}
+
+type DetectBroadcastOutput struct {
+	Channel    string           // 検出したチャンネル (無ければ空)
+	Detected   *port.Broadcast  // Reserve / SwitchVideo した配信 (無ければ nil)
+	Dispatched string           // StartOrReserve の振り分け: "switch" または "reserve"
+	State      domain.LiveState // 実行後の状態
+}
+
+// DetectBroadcast は配信が無い (WAITING / ENDED) とき監視中のチャンネルの配信中・配信予定の配信を探し、
+// StartOrReserve で配信中なら SwitchVideo、配信前なら Reserve する。
+// チャンネルごとの検出間隔は Interval を下限に、quota 残量に応じて延ばす。
+type DetectBroadcast struct {
+	YT             port.YouTubePort
+	Watches        port.ChannelWatchRepo
+	State          port.StateRepo
+	StartOrReserve *StartOrReserve
+	Clock          port.Clock
+	Quota          port.QuotaReporter // 任意: 設定時は残量に応じて検出間隔を延ばす
+	Interval       time.Duration      // 0 なら DefaultWatchInterval
+	QuotaShare     float64            // 0 なら DefaultWatchQuotaShare
+	Sessions       SessionCounter     // 任意: 同じ quota を使うセッション数。設定時は QuotaShare をその数で分け合う
+}
+
+// Execute: 配信中・予約中、監視が無い、または検出間隔に達したチャンネルが無ければ何もしない。
+// 配信中の配信を配信予定より優先し、配信予定同士は予定開始時刻の早いものを選ぶ。
+// 直前まで追跡していた配信と、そのチャンネルで前回検出した配信は選ばない (終了直後の再開始や取消の巻き戻しを防ぐ)。
+func (uc *DetectBroadcast) Execute(ctx context.Context) (DetectBroadcastOutput, error) {
+	st, err := uc.State.Get(ctx)
+	if err != nil {
+		return DetectBroadcastOutput{}, fmt.Errorf("state_get: %w", err)
+	}
+	if st.Status.InSession() || st.Status == domain.StatusReserved {
+		return DetectBroadcastOutput{State: st}, nil
+	}
+	list, err := uc.Watches.List(ctx)
+	if err != nil {
+		return DetectBroadcastOutput{}, fmt.Errorf("watches_list: %w", err)
+	}
+	if len(list) == 0 {
+		return DetectBroadcastOutput{State: st}, nil
+	}
+
+	now := clockNow(uc.Clock)
+	interval := uc.interval(now, len(list))
+	type candidate struct {
+		watch     int
+		broadcast port.Broadcast
+	}
+	var candidates []candidate
+	checked := false
+	for i, w := range list {
+		if !w.LastCheckedAt.IsZero() && now.Sub(w.LastCheckedAt) < interval {
+			continue
+		}
+		checked = true
+		list[i].LastCheckedAt = now
+		broadcasts, err := uc.YT.FindChannelBroadcasts(ctx, w.Channel)
+		if err != nil {
+			logging.Log(ctx, "warn", "WATCH", "detect broadcasts of %s failed: %v", w.Channel, err)
+			list[i].LastError = err.Error()
+			continue
+		}
+		list[i].LastError = ""
+		for _, b := range broadcasts {
+			if b.VideoID == st.VideoID || b.VideoID == w.LastVideoID {
+				continue
+			}
+			candidates = append(candidates, candidate{watch: i, broadcast: b})
+			break // FindChannelBroadcasts は配信中 → 予定の早い順に並ぶため先頭が最有力
+		}
+	}
+	if !checked {
+		return DetectBroadcastOutput{State: st}, nil
+	}
+	defer func() {
+		// 検出中 (API 呼び出しの間) に追加・削除されたチャンネルを消さないよう、
+		// 今回検出したチャンネルの結果だけを最新の一覧に反映する
+		_, err := uc.Watches.Update(ctx, func(cur []domain.ChannelWatch) ([]domain.ChannelWatch, error) {
+			for _, w := range list {
+				if !w.LastCheckedAt.Equal(now) {
+					continue
+				}
+				if i := indexWatch(cur, w.Channel); i >= 0 {
+					cur[i].LastCheckedAt, cur[i].LastVideoID, cur[i].LastError = w.LastCheckedAt, w.LastVideoID, w.LastError
+				}
+			}
+			return cur, nil
+		})
+		if err != nil {
+			logging.Log(ctx, "warn", "WATCH", "save watches failed: %v", err)
+		}
+	}()
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		if a.broadcast.Live != b.broadcast.Live {
+			if a.broadcast.Live {
+				return -1
+			}
+			return 1
+		}
+		return a.broadcast.ScheduledStartTime.Compare(b.broadcast.ScheduledStartTime)
+	})
+	for _, c := range candidates {
+		w := &list[c.watch]
+		out, err := uc.StartOrReserve.Execute(ctx, StartOrReserveInput{VideoID: c.broadcast.VideoID, Autonomous: true})
+		if err != nil {
+			logging.Log(ctx, "warn", "WATCH", "start or reserve %s from %s failed: %v", c.broadcast.VideoID, w.Channel, err)
+			w.LastError = err.Error()
+			continue
+		}
+		w.LastVideoID = c.broadcast.VideoID
+		logging.Log(ctx, "info", "WATCH", "detected %s on %s → %s (live=%v scheduled=%s)",
+			c.broadcast.VideoID, w.Channel, out.Dispatched, c.broadcast.Live, c.broadcast.ScheduledStartTime.Format(time.RFC3339))
+		b := c.broadcast
+		return DetectBroadcastOutput{Channel: w.Channel, Detected: &b, Dispatched: out.Dispatched, State: out.State}, nil
+	}
+	return DetectBroadcastOutput{State: st}, nil
+}
+
+// interval は watches 件のチャンネルを検出する間隔を返す。
+// quota 残量の QuotaShare 分で次のリセットまで検出を続けられる間隔より短くはしない。
+func (uc *DetectBroadcast) interval(now time.Time, watches int) time.Duration {
+	interval := uc.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	share := uc.QuotaShare
+	if share <= 0 {
+		share = DefaultWatchQuotaShare
+	}
+	return quotaPacedInterval(now, interval, uc.Quota, sessionShare(share, uc.Sessions), domain.FindBroadcastsCost()*watches)
+}
+
+// SessionCounter は同じ quota を使うセッションの数を返します (session.Registry)。
+type SessionCounter interface {
+	Len() int
+}
+
+// sessionShare は share をセッション数で割った 1 セッションあたりの割合を返します (sessions が nil なら share)。
+// 各セッションが同じ ledger の残量に対して share ずつ使うと、合計でセッション数倍を消費してしまうため。
+func sessionShare(share float64, sessions SessionCounter) float64 {
+	if sessions == nil {
+		return share
+	}
+	return share / float64(max(sessions.Len(), 1))
+}
+
+// quotaPacedInterval は 1 回 units を使う定期処理の間隔を返す。
+// quota 残量の share 分で次のリセットまで続けられる間隔を、base より短くはしない (quota が nil なら base)。
+func quotaPacedInterval(now time.Time, base time.Duration, quota port.QuotaReporter, share float64, units int) time.Duration {
+	if quota == nil {
+		return base
+	}
+	return max(base, quota.Usage().PacedInterval(now, share, units))
+}
+
+// normalizeWatchChannel は監視対象の channelID (UC...) / @handle を検証して返す。
+func normalizeWatchChannel(s string) (string, error) {
+	channel := strings.TrimSpace(s)
+	if channel == "" {
+		return "", &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: "channel is required"}
+	}
+	if strings.HasPrefix(channel, "@") && len(channel) > 1 && !strings.ContainsAny(channel, " /?#") {
+		return channel, nil
+	}
+	if strings.HasPrefix(channel, "UC") && len(channel) == 24 {
+		return channel, nil
+	}
+	return "", &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: "channel must be a channel ID (UC...) or an @handle"}
+}
+
+// updateWatches は最新の監視一覧を fn で書き換えて保存する。fn のエラーはそのまま返す。
+func updateWatches(ctx context.Context, repo port.ChannelWatchRepo, fn func(list []domain.ChannelWatch) ([]domain.ChannelWatch, error)) ([]domain.ChannelWatch, error) {
+	var fnErr error
+	list, err := repo.Update(ctx, func(list []domain.ChannelWatch) ([]domain.ChannelWatch, error) {
+		next, err := fn(list)
+		fnErr = err
+		return next, err
+	})
+	if fnErr != nil {
+		return nil, fnErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("watches_save: %w", err)
+	}
+	return list, nil
+}
+
+func indexWatch(list []domain.ChannelWatch, channel string) int {
+	return slices.IndexFunc(list, func(w domain.ChannelWatch) bool { return strings.EqualFold(w.Channel, channel) })
+}
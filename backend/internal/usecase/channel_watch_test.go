@@ -0,0 +1,208 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// broadcastsYT は byChannel のチャンネルごとの配信中・配信予定の配信を返す fakeYT を返す。
+// GetVideoLiveDetails は登録済みの配信の開始状態をそのまま返す。
+func broadcastsYT(byChannel map[string][]port.Broadcast) *fakeYT {
+	return &fakeYT{
+		findBroadcasts: func(channel string) ([]port.Broadcast, error) {
+			bs, ok := byChannel[channel]
+			if !ok {
+				return nil, &domain.APIError{Code: domain.ErrCodeVideoNotFound, Message: "channel not found"}
+			}
+			return bs, nil
+		},
+		liveDetails: func(videoID string) (port.VideoLiveDetails, error) {
+			for _, bs := range byChannel {
+				for _, b := range bs {
+					if b.VideoID == videoID {
+						return port.VideoLiveDetails{IsLiveContent: true, ScheduledStartTime: b.ScheduledStartTime, ActualStartTime: b.ActualStartTime}, nil
+					}
+				}
+			}
+			return port.VideoLiveDetails{}, &domain.APIError{Code: domain.ErrCodeVideoNotFound, Message: "video not found"}
+		},
+	}
+}
+
+// fixedQuota は固定の quota 消費状況を返す fake。
+type fixedQuota struct{ usage domain.QuotaUsage }
+
+func (q fixedQuota) Usage() domain.QuotaUsage { return q.usage }
+
+func newDetect(yt *fakeYT, watches port.ChannelWatchRepo, state port.StateRepo, clock port.Clock) *usecase.DetectBroadcast {
+	snap := &snapshot.NopCoordinator{}
+	return &usecase.DetectBroadcast{
+		YT:      yt,
+		Watches: watches,
+		State:   state,
+		Clock:   clock,
+		StartOrReserve: &usecase.StartOrReserve{
+			YT:          yt,
+			Clock:       clock,
+			SwitchVideo: &usecase.SwitchVideo{YT: yt, Users: memory.NewUserRepo(), Comments: memory.NewCommentRepo(), State: state, Clock: clock, Snap: snap},
+			Reserve:     &usecase.Reserve{YT: yt, State: state, Clock: clock, Snap: snap},
+		},
+	}
+}
+
+func TestWatchChannel_ValidatesAndRejectsDuplicates(t *testing.T) {
+	ctx := context.Background()
+	watches := memory.NewWatchRepo(nil, memory.DefaultWatchKey)
+	watch := &usecase.WatchChannel{Watches: watches, Clock: system.NewManualClock(time.Now())}
+
+	var apiErr *domain.APIError
+	for _, bad := range []string{"", "alice", "@", "https://youtube.com/@alice"} {
+		if _, err := watch.Execute(ctx, usecase.WatchInput{Channel: bad}); !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeInvalidArgument {
+			t.Errorf("watch %q err = %v, want invalid_argument", bad, err)
+		}
+	}
+	if _, err := watch.Execute(ctx, usecase.WatchInput{Channel: " @alice "}); err != nil {
+		t.Fatalf("watch @alice: %v", err)
+	}
+	if _, err := watch.Execute(ctx, usecase.WatchInput{Channel: "@Alice"}); !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeConflict {
+		t.Errorf("duplicate watch err = %v, want conflict", err)
+	}
+	out, err := watch.Execute(ctx, usecase.WatchInput{Channel: "UCxxxxxxxxxxxxxxxxxxxxxx"})
+	if err != nil || len(out.Watches) != 2 || out.Watches[0].Channel != "@alice" {
+		t.Errorf("watches = %+v, %v; want [@alice UC...]", out.Watches, err)
+	}
+
+	unwatch := &usecase.UnwatchChannel{Watches: watches}
+	if out, err := unwatch.Execute(ctx, usecase.WatchInput{Channel: "@alice"}); err != nil || len(out.Watches) != 1 {
+		t.Errorf("unwatch = %+v, %v; want one watch left", out.Watches, err)
+	}
+	if _, err := unwatch.Execute(ctx, usecase.WatchInput{Channel: "@alice"}); !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeVideoNotFound {
+		t.Errorf("unwatch unknown err = %v, want video_not_found", err)
+	}
+}
+
+func TestDetectBroadcast_ReservesUpcomingThenSwitchesLive(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := system.NewManualClock(t0)
+	byChannel := map[string][]port.Broadcast{
+		"@alice": {{VideoID: "later", ScheduledStartTime: t0.Add(3 * time.Hour)}},
+		"@bob":   {{VideoID: "soon", ScheduledStartTime: t0.Add(time.Hour)}},
+	}
+	yt := broadcastsYT(byChannel)
+	watches := memory.NewWatchRepo(nil, memory.DefaultWatchKey)
+	_ = watches.Save(ctx, []domain.ChannelWatch{{Channel: "@alice"}, {Channel: "@bob"}, {Channel: "@gone"}})
+	state := memory.NewStateRepo()
+	detect := newDetect(yt, watches, state, clock)
+
+	// 配信予定のみ → 予定の早い配信を予約する
+	out, err := detect.Execute(ctx)
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if out.Detected == nil || out.Detected.VideoID != "soon" || out.Dispatched != "reserve" || out.State.Status != domain.StatusReserved {
+		t.Fatalf("detect = %+v, want soon reserved", out)
+	}
+	list, _ := watches.List(ctx)
+	if list[1].LastVideoID != "soon" || list[2].LastError == "" {
+		t.Errorf("watches = %+v, want @bob detected soon and @gone errored", list)
+	}
+
+	// 予約中は検出しない
+	calls := yt.callCount("FindChannelBroadcasts")
+	if out, err := detect.Execute(ctx); err != nil || out.Detected != nil || yt.callCount("FindChannelBroadcasts") != calls {
+		t.Errorf("detect while RESERVED = %+v, %v (calls %d→%d); want no-op", out, err, calls, yt.callCount("FindChannelBroadcasts"))
+	}
+
+	// 配信終了後: 検出間隔内は API を呼ばず、間隔後は配信中を予定より優先して切り替える
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusEnded, VideoID: "soon"})
+	byChannel["@alice"] = []port.Broadcast{{VideoID: "now", Live: true, ActualStartTime: t0.Add(-time.Minute)}}
+	if out, _ := detect.Execute(ctx); out.Detected != nil || yt.callCount("FindChannelBroadcasts") != calls {
+		t.Errorf("detect within interval = %+v (calls %d→%d), want no API call", out, calls, yt.callCount("FindChannelBroadcasts"))
+	}
+	clock.Set(t0.Add(usecase.DefaultWatchInterval))
+	out, err = detect.Execute(ctx)
+	if err != nil {
+		t.Fatalf("detect after end: %v", err)
+	}
+	if out.Detected == nil || out.Detected.VideoID != "now" || out.Dispatched != "switch" || out.State.Status != domain.StatusActive {
+		t.Errorf("detect after end = %+v, want now switched (the ended soon is skipped)", out)
+	}
+	// 人が Pull しない経路なので monitor が自動 Pull する
+	if !out.State.AutonomousMonitoring {
+		t.Errorf("detect after end state = %+v, want AutonomousMonitoring", out.State)
+	}
+}
+
+func TestDetectBroadcast_KeepsWatchChangesMadeDuringDetection(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := system.NewManualClock(t0)
+	yt := broadcastsYT(map[string][]port.Broadcast{
+		"@alice": {{VideoID: "later", ScheduledStartTime: t0.Add(3 * time.Hour)}},
+		"@bob":   {},
+	})
+	watches := memory.NewWatchRepo(nil, memory.DefaultWatchKey)
+	_ = watches.Save(ctx, []domain.ChannelWatch{{Channel: "@alice"}, {Channel: "@bob"}})
+	detect := newDetect(yt, watches, memory.NewStateRepo(), clock)
+
+	// @alice の検出中に @bob の監視を外し @carol を追加する
+	find, hooked := yt.findBroadcasts, false
+	yt.findBroadcasts = func(channel string) ([]port.Broadcast, error) {
+		if channel == "@alice" && !hooked {
+			hooked = true
+			if _, err := (&usecase.UnwatchChannel{Watches: watches}).Execute(ctx, usecase.WatchInput{Channel: "@bob"}); err != nil {
+				t.Errorf("unwatch during detect: %v", err)
+			}
+			if _, err := (&usecase.WatchChannel{Watches: watches, Clock: clock}).Execute(ctx, usecase.WatchInput{Channel: "@carol"}); err != nil {
+				t.Errorf("watch during detect: %v", err)
+			}
+		}
+		return find(channel)
+	}
+	if out, err := detect.Execute(ctx); err != nil || out.Detected == nil || out.Detected.VideoID != "later" {
+		t.Fatalf("detect = %+v, %v; want later reserved", out, err)
+	}
+	list, _ := watches.List(ctx)
+	if len(list) != 2 || list[0].Channel != "@alice" || list[1].Channel != "@carol" {
+		t.Fatalf("watches = %+v, want @alice and @carol", list)
+	}
+	if list[0].LastVideoID != "later" || !list[0].LastCheckedAt.Equal(t0) || !list[1].LastCheckedAt.IsZero() {
+		t.Errorf("watches = %+v, want only @alice's detection result recorded", list)
+	}
+}
+
+func TestDetectBroadcast_QuotaStretchesInterval(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := system.NewManualClock(t0)
+	yt := broadcastsYT(map[string][]port.Broadcast{"@alice": {}})
+	watches := memory.NewWatchRepo(nil, memory.DefaultWatchKey)
+	_ = watches.Save(ctx, []domain.ChannelWatch{{Channel: "@alice"}})
+	detect := newDetect(yt, watches, memory.NewStateRepo(), clock)
+	// 残り 300 units の 10% = 30 units → 3 units/回 で 10 回。リセットまで 10 時間なら 1 時間ごと
+	detect.Quota = fixedQuota{usage: domain.QuotaUsage{Budget: 10000, Used: 9700, ResetAt: t0.Add(10 * time.Hour)}}
+
+	if _, err := detect.Execute(ctx); err != nil || yt.callCount("FindChannelBroadcasts") != 1 {
+		t.Fatalf("first detect calls = %d, %v; want 1", yt.callCount("FindChannelBroadcasts"), err)
+	}
+	clock.Set(t0.Add(30 * time.Minute))
+	_, _ = detect.Execute(ctx)
+	if yt.callCount("FindChannelBroadcasts") != 1 {
+		t.Errorf("calls after 30m = %d, want 1 (interval stretched by quota)", yt.callCount("FindChannelBroadcasts"))
+	}
+	clock.Set(t0.Add(time.Hour))
+	_, _ = detect.Execute(ctx)
+	if yt.callCount("FindChannelBroadcasts") != 2 {
+		t.Errorf("calls after 1h = %d, want 2", yt.callCount("FindChannelBroadcasts"))
+	}
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/analytics"
+)
+
+// DefaultSpikeOriginRetry は actualStartTime を取得できなかった (API エラー・未開始) ときに再取得するまでの間隔です。
+const DefaultSpikeOriginRetry = 5 * time.Minute
+
+// ChatSpikesOutput は ChatSpikes の出力です。
+type ChatSpikesOutput struct {
+	VideoID string
+	Origin  time.Time // offset の起点 (LiveState.SpikeOrigin: actualStartTime、取得できなければ StartedAt)
+	Spikes  []domain.ChatSpike
+}
+
+// ChatSpikes は現在の配信のコメント流量 spike を検出します。
+// State に actualStartTime が無く YT が非 nil の場合は liveStreamingDetails から取得して State に保存し、
+// snapshot に同梱する spike と同じ起点を使います。取得できなかった場合は RetryAfter の間は再取得しません。
+type ChatSpikes struct {
+	Comments   port.CommentRepo
+	State      port.StateRepo
+	YT         port.YouTubePort // 任意 (nil なら StartedAt を起点にする)
+	Config     analytics.SpikeConfig
+	Clock      port.Clock    // 任意 (nil なら time.Now)
+	RetryAfter time.Duration // 0 なら DefaultSpikeOriginRetry
+
+	mu          sync.Mutex
+	missVideo   string    // actualStartTime を取得できなかった videoId
+	missRetryAt time.Time // missVideo の再取得を許す時刻
+}
+
+// Execute は in-memory のコメントから spike 一覧を返します。
+func (uc *ChatSpikes) Execute(ctx context.Context) (ChatSpikesOutput, error) {
+	state, err := uc.State.Get(ctx)
+	if err != nil {
+		return ChatSpikesOutput{}, fmt.Errorf("state_get: %w", err)
+	}
+	if state.VideoID == "" {
+		return ChatSpikesOutput{Spikes: []domain.ChatSpike{}}, nil
+	}
+
+	origin := uc.resolveOrigin(ctx, state).SpikeOrigin()
+	spikes := analytics.DetectSpikes(uc.Comments.ListSortedByPublishedAt(), origin, state.VideoID, uc.Config)
+	return ChatSpikesOutput{VideoID: state.VideoID, Origin: origin, Spikes: spikes}, nil
+}
+
+// resolveOrigin は State に actualStartTime が無ければ取得して保存し、反映後の State を返します。
+// 取得に失敗した (または未開始の) videoId は RetryAfter の間キャッシュし、リクエストごとに videos.list を呼ばない。
+func (uc *ChatSpikes) resolveOrigin(ctx context.Context, state domain.LiveState) domain.LiveState {
+	if !state.ActualStartTime.IsZero() || uc.YT == nil {
+		return state
+	}
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	now := clockNow(uc.Clock)
+	if uc.missVideo == state.VideoID && now.Before(uc.missRetryAt) {
+		return state
+	}
+	details, err := uc.YT.GetVideoLiveDetails(ctx, state.VideoID)
+	if err != nil || details.ActualStartTime.IsZero() {
+		if err != nil {
+			logging.Log(ctx, "warn", "SPIKES", "get_video_live_details failed, falling back to startedAt: %v", err)
+		}
+		retry := uc.RetryAfter
+		if retry <= 0 {
+			retry = DefaultSpikeOriginRetry
+		}
+		uc.missVideo = state.VideoID
+		uc.missRetryAt = now.Add(retry)
+		return state
+	}
+
+	state.ActualStartTime = details.ActualStartTime
+	if _, err := updateState(ctx, uc.State, func(cur domain.LiveState) (domain.LiveState, error) {
+		if cur.VideoID == state.VideoID && cur.ActualStartTime.IsZero() {
+			cur.ActualStartTime = details.ActualStartTime
+		}
+		return cur, nil
+	}); err != nil {
+		logging.Log(ctx, "warn", "SPIKES", "failed to store actualStartTime: %v", err)
+	}
+	return state
+}
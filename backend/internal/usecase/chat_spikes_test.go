@@ -0,0 +1,63 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+)
+
+func TestChatSpikes_UsesActualStartTimeAsOrigin(t *testing.T) {
+	ctx := context.Background()
+	actualStart := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{
+		Status:    domain.StatusActive,
+		VideoID:   "vid00000001",
+		StartedAt: actualStart.Add(3 * time.Minute), // 途中から監視開始
+	})
+	comments := memory.NewCommentRepo()
+	for i := 0; i < 20; i++ {
+		_ = comments.Add(domain.Comment{ID: fmt.Sprintf("base-%d", i), Message: "hi", PublishedAt: actualStart.Add(time.Duration(i) * 30 * time.Second)})
+	}
+	for i := 0; i < 30; i++ {
+		_ = comments.Add(domain.Comment{ID: fmt.Sprintf("burst-%d", i), Message: "888", PublishedAt: actualStart.Add(8*time.Minute + time.Duration(i)*500*time.Millisecond)})
+	}
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true, ActualStartTime: actualStart}}
+
+	uc := &usecase.ChatSpikes{Comments: comments, State: state, YT: yt}
+	out, err := uc.Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !out.Origin.Equal(actualStart) {
+		t.Errorf("Origin = %v, want actualStartTime %v", out.Origin, actualStart)
+	}
+	if len(out.Spikes) != 1 || out.Spikes[0].OffsetSec != 480 {
+		t.Fatalf("Spikes = %+v, want one spike at 480s", out.Spikes)
+	}
+
+	// 2 回目は actualStartTime をキャッシュから使い API を呼ばない
+	if _, err := uc.Execute(ctx); err != nil {
+		t.Fatalf("Execute (2nd) failed: %v", err)
+	}
+	if yt.callCount("GetVideoLiveDetails") != 1 {
+		t.Errorf("GetVideoLiveDetails calls = %d, want 1 (cached)", yt.callCount("GetVideoLiveDetails"))
+	}
+}
+
+func TestChatSpikes_NoVideo_ReturnsEmpty(t *testing.T) {
+	uc := &usecase.ChatSpikes{Comments: memory.NewCommentRepo(), State: memory.NewStateRepo()}
+	out, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if out.Spikes == nil || len(out.Spikes) != 0 {
+		t.Errorf("Spikes = %v, want empty non-nil slice", out.Spikes)
+	}
+}
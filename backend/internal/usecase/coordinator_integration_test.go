@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
@@ -46,8 +47,8 @@ func TestSwitchVideo_CoordinatorCallOrder(t *testing.T) {
 
 	users := memory.NewUserRepo()
 	state := memory.NewStateRepo()
-	yt := &fakeYT{}
-	clock := fixedClock{t: time.Unix(2000, 0)}
+	yt := &fakeYT{meta: switchMeta}
+	clock := system.NewManualClock(time.Unix(2000, 0))
 	coord := &mockCoord{}
 
 	uc := &usecase.SwitchVideo{
@@ -187,10 +188,10 @@ func TestPull_MarkDirty_CalledOnlyWhenDiff(t *testing.T) {
 				VideoID:    "v",
 				LiveChatID: "live:chat",
 			})
-			clock := &fakeClock{now: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)}
+			clock := system.NewManualClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
 			coord := &mockCoord{}
 
-			yt := &fakeYTForPull{items: tt.items, ended: false}
+			yt := &fakeYT{items: tt.items, ended: false}
 			uc := &usecase.Pull{
 				YT:       yt,
 				Users:    users,
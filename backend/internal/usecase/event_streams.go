@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// MaxEventStreams はイベントに追加できる配信の上限です (主配信を除く)。
+// Pull 1 回ごとに配信数分の liveChatMessages.list を消費するため quota 保護のために抑える。
+const MaxEventStreams = 8
+
+type EventStreamInput struct {
+	VideoID string
+}
+
+type EventStreamOutput struct {
+	State domain.LiveState
+}
+
+// AttachStream はコラボ配信などの他の配信を現在のイベントに追加する。
+// 以降の Pull は追加した配信のチャットも同じ User / Comment に取り込む (ユーザーは ChannelID で統合される)。
+type AttachStream struct {
+	YT    port.YouTubePort
+	State port.StateRepo
+	Snap  snapshot.Coordinator
+}
+
+// Execute: 配信中 (ACTIVE / PAUSED) のイベントに videoID の配信を追加する。
+// 追加済みの配信なら何もしない。配信中でなければ conflict、上限を超える場合は invalid_argument。
+func (uc *AttachStream) Execute(ctx context.Context, in EventStreamInput) (EventStreamOutput, error) {
+	if in.VideoID == "" {
+		return EventStreamOutput{}, &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: "videoId is required"}
+	}
+	cur, err := uc.State.Get(ctx)
+	if err != nil {
+		return EventStreamOutput{}, fmt.Errorf("state_get: %w", err)
+	}
+	if slices.Contains(cur.VideoIDs(), in.VideoID) {
+		return EventStreamOutput{State: cur}, nil
+	}
+
+	meta, err := uc.YT.GetActiveLiveChatID(ctx, in.VideoID)
+	if err != nil {
+		return EventStreamOutput{}, fmt.Errorf("get_live_chat_id: %w", err)
+	}
+
+	st, err := updateState(ctx, uc.State, func(cur domain.LiveState) (domain.LiveState, error) {
+		if !cur.Status.InSession() {
+			return cur, &domain.APIError{Code: domain.ErrCodeConflict, Message: "no stream is in session to attach to"}
+		}
+		if slices.Contains(cur.VideoIDs(), in.VideoID) {
+			return cur, nil
+		}
+		if len(cur.EventStreams) >= MaxEventStreams {
+			return cur, &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: fmt.Sprintf("an event can have at most %d additional streams", MaxEventStreams)}
+		}
+		next := cur
+		next.EventStreams = append(slices.Clone(cur.EventStreams), domain.EventStream{VideoID: in.VideoID, LiveChatID: meta.LiveChatID})
+		return next, nil
+	})
+	if err != nil {
+		return EventStreamOutput{}, err
+	}
+	logging.Log(ctx, "info", "EVENT", "attached stream %s to event %s (streams=%d)", in.VideoID, st.VideoID, len(st.EventStreams)+1)
+
+	uc.Snap.MarkDirty()
+	return EventStreamOutput{State: st}, nil
+}
+
+// DetachStream はイベントに追加した配信を外す。取り込み済みのユーザー / コメントは残す。
+type DetachStream struct {
+	State port.StateRepo
+	Snap  snapshot.Coordinator
+}
+
+// Execute: videoID の配信をイベントから外す。追加されていなければ video_not_found。
+func (uc *DetachStream) Execute(ctx context.Context, in EventStreamInput) (EventStreamOutput, error) {
+	st, err := updateState(ctx, uc.State, func(cur domain.LiveState) (domain.LiveState, error) {
+		i := slices.IndexFunc(cur.EventStreams, func(es domain.EventStream) bool { return es.VideoID == in.VideoID })
+		if i < 0 {
+			return cur, &domain.APIError{Code: domain.ErrCodeVideoNotFound, Message: fmt.Sprintf("stream %s is not attached to the event", in.VideoID)}
+		}
+		next := cur
+		next.EventStreams = slices.Delete(slices.Clone(cur.EventStreams), i, i+1)
+		return next, nil
+	})
+	if err != nil {
+		return EventStreamOutput{}, err
+	}
+	logging.Log(ctx, "info", "EVENT", "detached stream %s from event %s", in.VideoID, st.VideoID)
+
+	uc.Snap.MarkDirty()
+	return EventStreamOutput{State: st}, nil
+}
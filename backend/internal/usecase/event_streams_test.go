@@ -0,0 +1,145 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// multiChatYT は liveChatID ごとに chats のチャットを返す fakeYT を返す。ended に含まれるチャットは終了扱い。
+// 動画 ID に対応する liveChatID は "chat-" + videoID。
+func multiChatYT(chats map[string][]port.ChatMessage, ended map[string]bool) *fakeYT {
+	return &fakeYT{
+		activeLiveChatID: func(videoID string) (port.VideoMeta, error) {
+			return port.VideoMeta{LiveChatID: "chat-" + videoID}, nil
+		},
+		listMessages: func(_ context.Context, liveChatID string, _ string) ([]port.ChatMessage, string, int64, int, bool, error) {
+			if ended[liveChatID] {
+				return nil, "", 0, 0, true, nil
+			}
+			return chats[liveChatID], "next-" + liveChatID, 5000, 0, false, nil
+		},
+	}
+}
+
+func TestEventStreams_PullMergesUsersAcrossStreams(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	ended := map[string]bool{}
+	yt := multiChatYT(map[string][]port.ChatMessage{
+		"chat-host": {
+			{ID: "m1", ChannelID: "UC-shared", DisplayName: "Shared", Message: "hi host", PublishedAt: t0},
+			{ID: "m2", ChannelID: "UC-host-only", DisplayName: "HostFan", Message: "yo", PublishedAt: t0},
+		},
+		"chat-guest": {
+			{ID: "m3", ChannelID: "UC-shared", DisplayName: "Shared", Message: "hi guest", PublishedAt: t0.Add(time.Second)},
+		},
+	}, ended)
+	users := memory.NewUserRepo()
+	comments := memory.NewCommentRepo()
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "host", LiveChatID: "chat-host"})
+	coord := &snapshot.NopCoordinator{}
+
+	attach := &usecase.AttachStream{YT: yt, State: state, Snap: coord}
+	if _, err := attach.Execute(ctx, usecase.EventStreamInput{VideoID: "guest"}); err != nil {
+		t.Fatalf("Attach: %v", err)
+	}
+	// 追加済み / 主配信の再追加は何もしない
+	out, err := attach.Execute(ctx, usecase.EventStreamInput{VideoID: "host"})
+	if err != nil || len(out.State.EventStreams) != 1 {
+		t.Fatalf("re-attach = %+v, %v; want a single event stream", out.State.EventStreams, err)
+	}
+
+	pull := &usecase.Pull{YT: yt, Users: users, Comments: comments, State: state, Clock: system.NewManualClock(t0), Snap: coord}
+	pout, err := pull.Execute(ctx)
+	if err != nil {
+		t.Fatalf("Pull: %v", err)
+	}
+	if pout.AddedCount != 3 {
+		t.Errorf("AddedCount = %d, want 3 (host 2 + guest 1)", pout.AddedCount)
+	}
+	if pout.ChatsPerPull != 2 {
+		t.Errorf("ChatsPerPull = %d, want 2 (host + guest)", pout.ChatsPerPull)
+	}
+
+	// ChannelID で統合され、コメントした配信が記録される
+	if got := users.Count(); got != 2 {
+		t.Fatalf("users = %d, want 2 merged by channel", got)
+	}
+	for _, u := range users.ListUsersSortedByJoinTime() {
+		switch u.ChannelID {
+		case "UC-shared":
+			if u.CommentCount != 2 || !slices.Equal(u.Streams, []string{"host", "guest"}) {
+				t.Errorf("shared user = %+v, want 2 comments in [host guest]", u)
+			}
+		case "UC-host-only":
+			if !slices.Equal(u.Streams, []string{"host"}) {
+				t.Errorf("host-only user streams = %v, want [host]", u.Streams)
+			}
+		}
+	}
+	// コメントは投稿先の videoID を保持する
+	for _, c := range comments.ListSortedByPublishedAt() {
+		want := "host"
+		if c.ID == "m3" {
+			want = "guest"
+		}
+		if c.VideoID != want {
+			t.Errorf("comment %s videoId = %q, want %q", c.ID, c.VideoID, want)
+		}
+	}
+
+	st, _ := state.Get(ctx)
+	if len(st.EventStreams) != 1 || st.EventStreams[0].NextPageToken != "next-chat-guest" {
+		t.Errorf("event streams after pull = %+v, want guest token advanced", st.EventStreams)
+	}
+
+	// 追加配信のチャット終了は主配信を止めず、その配信だけ Ended にする
+	ended["chat-guest"] = true
+	if pout, err = pull.Execute(ctx); err != nil {
+		t.Fatalf("Pull after guest end: %v", err)
+	}
+	if pout.ChatsPerPull != 1 {
+		t.Errorf("ChatsPerPull after guest end = %d, want 1", pout.ChatsPerPull)
+	}
+	st, _ = state.Get(ctx)
+	if st.Status != domain.StatusActive || !st.EventStreams[0].Ended {
+		t.Errorf("state = %s streams=%+v, want ACTIVE with guest ended", st.Status, st.EventStreams)
+	}
+}
+
+func TestEventStreams_AttachRequiresSessionAndDetach(t *testing.T) {
+	ctx := context.Background()
+	yt := multiChatYT(nil, nil)
+	state := memory.NewStateRepo()
+	coord := &snapshot.NopCoordinator{}
+	attach := &usecase.AttachStream{YT: yt, State: state, Snap: coord}
+	detach := &usecase.DetachStream{State: state, Snap: coord}
+
+	var apiErr *domain.APIError
+	if _, err := attach.Execute(ctx, usecase.EventStreamInput{VideoID: "guest"}); !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeConflict {
+		t.Errorf("attach while WAITING err = %v, want conflict", err)
+	}
+
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "host", LiveChatID: "chat-host"})
+	if _, err := attach.Execute(ctx, usecase.EventStreamInput{VideoID: "guest"}); err != nil {
+		t.Fatalf("Attach: %v", err)
+	}
+	out, err := detach.Execute(ctx, usecase.EventStreamInput{VideoID: "guest"})
+	if err != nil || len(out.State.EventStreams) != 0 {
+		t.Errorf("Detach = %+v, %v; want no event streams", out.State.EventStreams, err)
+	}
+	if _, err := detach.Execute(ctx, usecase.EventStreamInput{VideoID: "guest"}); !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeVideoNotFound {
+		t.Errorf("detach unknown err = %v, want video_not_found", err)
+	}
+}
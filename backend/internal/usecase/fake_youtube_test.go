@@ -0,0 +1,91 @@
+package usecase_test
+
+import (
+	"context"
+	"sync"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// fakeYT は usecase_test パッケージで使用する YouTubePort の fake。
+// 既定ではフィールドに設定した固定の応答を返し、関数フィールドを設定した method はその結果を返す。
+// method ごとの呼び出し回数を数える。
+type fakeYT struct {
+	meta    port.VideoMeta // GetActiveLiveChatID の応答
+	metaErr error
+
+	items  []port.ChatMessage // ListLiveChatMessages の応答
+	next   string
+	pollMs int64
+	ended  bool
+
+	details    port.VideoLiveDetails // GetVideoLiveDetails の応答
+	detailsErr error
+
+	// 任意: 設定すると対応する method の応答を置き換える
+	activeLiveChatID func(videoID string) (port.VideoMeta, error)
+	listMessages     func(ctx context.Context, liveChatID, pageToken string) ([]port.ChatMessage, string, int64, int, bool, error)
+	liveDetails      func(videoID string) (port.VideoLiveDetails, error)
+	findBroadcasts   func(channel string) ([]port.Broadcast, error)
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+// callCount は method の呼び出し回数を返す。
+func (f *fakeYT) callCount(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+func (f *fakeYT) record(method string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.calls == nil {
+		f.calls = make(map[string]int)
+	}
+	f.calls[method]++
+}
+
+func (f *fakeYT) GetActiveLiveChatID(_ context.Context, videoID string) (port.VideoMeta, error) {
+	f.record("GetActiveLiveChatID")
+	if f.activeLiveChatID != nil {
+		return f.activeLiveChatID(videoID)
+	}
+	return f.meta, f.metaErr
+}
+
+func (f *fakeYT) ListLiveChatMessages(ctx context.Context, liveChatID string, pageToken string) ([]port.ChatMessage, string, int64, int, bool, error) {
+	f.record("ListLiveChatMessages")
+	if f.listMessages != nil {
+		return f.listMessages(ctx, liveChatID, pageToken)
+	}
+	return f.items, f.next, f.pollMs, 0, f.ended, nil
+}
+
+func (f *fakeYT) GetChannelDisplayNames(context.Context, []string) (map[string]string, error) {
+	f.record("GetChannelDisplayNames")
+	return nil, nil
+}
+
+func (f *fakeYT) GetChannelHandles(context.Context, []string) (map[string]string, error) {
+	f.record("GetChannelHandles")
+	return nil, nil
+}
+
+func (f *fakeYT) GetVideoLiveDetails(_ context.Context, videoID string) (port.VideoLiveDetails, error) {
+	f.record("GetVideoLiveDetails")
+	if f.liveDetails != nil {
+		return f.liveDetails(videoID)
+	}
+	return f.details, f.detailsErr
+}
+
+func (f *fakeYT) FindChannelBroadcasts(_ context.Context, channel string) ([]port.Broadcast, error) {
+	f.record("FindChannelBroadcasts")
+	if f.findBroadcasts != nil {
+		return f.findBroadcasts(channel)
+	}
+	return nil, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
 )
 
 // ListHistorySnapshots は GCS 上の全 snapshot サマリーを savedAt 降順で返します。
@@ -42,9 +43,9 @@ type GetHistorySnapshotOutput struct {
 	Snapshot *port.Snapshot
 }
 
-// Execute は指定 videoID の snapshot を取得して返します。
+// Execute は指定 videoID の snapshot を、まだ畳み込まれていない journal を適用して返します。
 func (uc *GetHistorySnapshot) Execute(ctx context.Context, videoID string) (GetHistorySnapshotOutput, error) {
-	snap, err := uc.Sink.Load(ctx, videoID)
+	snap, err := snapshot.Load(ctx, uc.Sink, videoID)
 	if err != nil {
 		return GetHistorySnapshotOutput{}, fmt.Errorf("snapshot_load: %w", err)
 	}
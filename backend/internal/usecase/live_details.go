@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// LiveDetailsInput は配信中の確認 (RefreshLiveChat / SampleViewers / RefreshMetadata / CheckStreamEnd) の入力です。
+// monitor は 1 回の起床で liveStreamingDetails を 1 度だけ取得し、期限の来た確認すべてに同じ値を渡す。
+type LiveDetailsInput struct {
+	VideoID string                 // Details を取得した videoID
+	Details *port.VideoLiveDetails // 任意: 取得済みの詳細。nil か VideoID が State の配信と異なれば usecase が取得する
+}
+
+// liveDetails は videoID の取得済みの詳細があればそれを、無ければ yt から取得して返す。
+func (in LiveDetailsInput) liveDetails(ctx context.Context, yt port.YouTubePort, videoID string) (port.VideoLiveDetails, error) {
+	if in.Details != nil && in.VideoID == videoID {
+		return *in.Details, nil
+	}
+	details, err := yt.GetVideoLiveDetails(ctx, videoID)
+	if err != nil {
+		return port.VideoLiveDetails{}, fmt.Errorf("get_video_live_details: %w", err)
+	}
+	return details, nil
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+type RescheduleInput struct {
+	VideoID            string
+	ScheduledStartTime time.Time
+}
+
+type RescheduleOutput struct {
+	Changed bool
+	State   domain.LiveState
+}
+
+// Reschedule は予約中 (RESERVED) の配信の予定開始時刻を、配信者による変更に合わせて更新する。
+// Reserve 時に写した ScheduledStartTime を monitor が最新の liveStreamingDetails で更新するために使う。
+type Reschedule struct {
+	State port.StateRepo
+	Snap  snapshot.Coordinator
+}
+
+// Execute: RESERVED かつ videoID が一致するときだけ更新する。予約が取り消し・変更済みなら何もしない。
+func (uc *Reschedule) Execute(ctx context.Context, in RescheduleInput) (RescheduleOutput, error) {
+	changed := false
+	st, err := updateState(ctx, uc.State, func(cur domain.LiveState) (domain.LiveState, error) {
+		changed = false
+		if cur.Status != domain.StatusReserved || cur.VideoID != in.VideoID || cur.ScheduledStartTime.Equal(in.ScheduledStartTime) {
+			return cur, nil
+		}
+		changed = true
+		next := cur
+		next.ScheduledStartTime = in.ScheduledStartTime
+		return next, nil
+	})
+	if err != nil {
+		return RescheduleOutput{}, err
+	}
+	if changed {
+		logging.Log(ctx, "info", "RESERVE", "rescheduled %s to %s", in.VideoID, in.ScheduledStartTime.Format(time.RFC3339))
+		uc.Snap.MarkDirty()
+	}
+	return RescheduleOutput{Changed: changed, State: st}, nil
+}
+
+type RefreshLiveChatOutput struct {
+	Changed       bool
+	OldLiveChatID string
+	State         domain.LiveState
+}
+
+// RefreshLiveChat は配信中 (ACTIVE / PAUSED) の動画の activeLiveChatId を確認し、
+// 配信の再開などで新しいチャットになっていれば切り替える。
+// SwitchVideo と違い users / comments / StartedAt はそのまま引き継ぎ、ページトークンだけ捨てる。
+type RefreshLiveChat struct {
+	YT    port.YouTubePort
+	State port.StateRepo
+	Snap  snapshot.Coordinator
+}
+
+// Execute: チャットが閉じている (activeLiveChatId が空) か変わっていなければ何もしない。
+func (uc *RefreshLiveChat) Execute(ctx context.Context, in LiveDetailsInput) (RefreshLiveChatOutput, error) {
+	st, err := uc.State.Get(ctx)
+	if err != nil {
+		return RefreshLiveChatOutput{}, fmt.Errorf("state_get: %w", err)
+	}
+	if !st.Status.InSession() || st.VideoID == "" {
+		return RefreshLiveChatOutput{State: st}, nil
+	}
+	details, err := in.liveDetails(ctx, uc.YT, st.VideoID)
+	if err != nil {
+		return RefreshLiveChatOutput{}, err
+	}
+	return uc.rotate(ctx, st, details)
+}
+
+// rotate は details の activeLiveChatId が st と異なれば State を新しいチャットに切り替える。
+func (uc *RefreshLiveChat) rotate(ctx context.Context, st domain.LiveState, details port.VideoLiveDetails) (RefreshLiveChatOutput, error) {
+	if details.LiveChatID == "" || details.LiveChatID == st.LiveChatID {
+		return RefreshLiveChatOutput{State: st}, nil
+	}
+	changed := false
+	next, err := updateState(ctx, uc.State, func(cur domain.LiveState) (domain.LiveState, error) {
+		changed = false
+		// 確認中に切替・リセットされていたら触らない
+		if cur.VideoID != st.VideoID || cur.LiveChatID != st.LiveChatID || !cur.Status.InSession() {
+			return cur, nil
+		}
+		changed = true
+		next := cur
+		next.LiveChatID = details.LiveChatID
+		next.NextPageToken = ""
+		return next, nil
+	})
+	if err != nil {
+		return RefreshLiveChatOutput{}, err
+	}
+	if !changed {
+		return RefreshLiveChatOutput{State: next}, nil
+	}
+	logging.Log(ctx, "info", "LIVE_CHAT", "live chat of %s changed %s → %s (broadcast restarted), keeping users",
+		st.VideoID, st.LiveChatID, details.LiveChatID)
+	uc.Snap.SetVideo(st.VideoID, details.LiveChatID, details.Title, details.ChannelTitle)
+	uc.Snap.MarkDirty()
+	return RefreshLiveChatOutput{Changed: true, OldLiveChatID: st.LiveChatID, State: next}, nil
+}
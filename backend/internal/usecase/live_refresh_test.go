@@ -0,0 +1,99 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// restartedYT は配信の再開で activeLiveChatId が oldChatID から newChatID に変わった動画を表す fakeYT を返す。
+// 旧チャットは終了を返し、新チャットは items を返す。
+func restartedYT(oldChatID, newChatID string, items []port.ChatMessage) *fakeYT {
+	return &fakeYT{
+		details: port.VideoLiveDetails{IsLiveContent: true, LiveChatID: newChatID, Title: "restarted"},
+		listMessages: func(_ context.Context, liveChatID string, _ string) ([]port.ChatMessage, string, int64, int, bool, error) {
+			if liveChatID == oldChatID {
+				return nil, "", 0, 0, true, nil
+			}
+			return items, "new-token", 0, 0, false, nil
+		},
+	}
+}
+
+func TestReschedule_UpdatesOnlyMatchingReservation(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusReserved, VideoID: "v", ScheduledStartTime: t0})
+	uc := &usecase.Reschedule{State: state, Snap: &snapshot.NopCoordinator{}}
+
+	out, err := uc.Execute(ctx, usecase.RescheduleInput{VideoID: "v", ScheduledStartTime: t0.Add(2 * time.Hour)})
+	if err != nil || !out.Changed || !out.State.ScheduledStartTime.Equal(t0.Add(2*time.Hour)) {
+		t.Fatalf("reschedule = %+v, %v; want moved by 2h", out, err)
+	}
+	if out, _ := uc.Execute(ctx, usecase.RescheduleInput{VideoID: "other", ScheduledStartTime: t0}); out.Changed {
+		t.Errorf("reschedule of another video changed state: %+v", out.State)
+	}
+	if got, _ := state.Get(ctx); !got.ScheduledStartTime.Equal(t0.Add(2 * time.Hour)) {
+		t.Errorf("ScheduledStartTime = %s, want %s", got.ScheduledStartTime, t0.Add(2*time.Hour))
+	}
+}
+
+func TestRefreshLiveChat_SwitchesChatKeepingUsers(t *testing.T) {
+	ctx := context.Background()
+	users := memory.NewUserRepo()
+	_ = users.UpsertWithJoinTime("ch1", "Alice", time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC))
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v", LiveChatID: "chat-1", NextPageToken: "old-token"})
+	yt := restartedYT("chat-1", "chat-2", nil)
+	uc := &usecase.RefreshLiveChat{YT: yt, State: state, Snap: &snapshot.NopCoordinator{}}
+
+	out, err := uc.Execute(ctx, usecase.LiveDetailsInput{})
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if !out.Changed || out.OldLiveChatID != "chat-1" || out.State.LiveChatID != "chat-2" || out.State.NextPageToken != "" {
+		t.Errorf("refresh = %+v, want switched to chat-2 with the page token cleared", out)
+	}
+	if out.State.Status != domain.StatusActive || users.Count() != 1 {
+		t.Errorf("status = %s users = %d, want ACTIVE with users kept", out.State.Status, users.Count())
+	}
+	if out, _ := uc.Execute(ctx, usecase.LiveDetailsInput{}); out.Changed {
+		t.Errorf("second refresh changed again: %+v", out)
+	}
+}
+
+func TestPull_ChatEndedButRestarted_ContinuesOnNewChat(t *testing.T) {
+	ctx := context.Background()
+	users := memory.NewUserRepo()
+	_ = users.UpsertWithJoinTime("ch1", "Alice", time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC))
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v", LiveChatID: "chat-1"})
+	yt := restartedYT("chat-1", "chat-2", []port.ChatMessage{
+		{ID: "m1", ChannelID: "ch2", DisplayName: "Bob", PublishedAt: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)},
+	})
+	clock := system.NewManualClock(time.Date(2026, 1, 1, 10, 1, 0, 0, time.UTC))
+	uc := &usecase.Pull{YT: yt, Users: users, Comments: memory.NewCommentRepo(), State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
+
+	out, err := uc.Execute(ctx)
+	if err != nil {
+		t.Fatalf("pull: %v", err)
+	}
+	if out.AutoReset || out.AddedCount != 1 {
+		t.Errorf("pull = %+v, want no reset and Bob added from the new chat", out)
+	}
+	got, _ := state.Get(ctx)
+	if got.Status != domain.StatusActive || got.LiveChatID != "chat-2" || got.NextPageToken != "new-token" {
+		t.Errorf("state = %+v, want ACTIVE on chat-2", got)
+	}
+	if users.Count() != 2 {
+		t.Errorf("users = %d, want 2 (Alice kept across the restart)", users.Count())
+	}
+}
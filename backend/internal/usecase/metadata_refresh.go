@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+type RefreshMetadataOutput struct {
+	Changed  bool                     // 今回変化を検知して履歴に追加した
+	Revision domain.MetadataRevision  // 今回取得した値 (配信中でなければ zero)
+	Previous *domain.MetadataRevision // 変化前の値 (初回や変化なしなら nil)
+}
+
+// RefreshMetadata は配信中 (ACTIVE / PAUSED) の動画のタイトル・概要欄・予定開始時刻を videos.list で取得し、
+// 直前の値から変わっていれば時刻付きで Metadata に記録する。
+// タイトルが変わったら snapshot の表示用タイトルも差し替え、履歴一覧に最終タイトルが出るようにする。
+type RefreshMetadata struct {
+	YT       port.YouTubePort
+	State    port.StateRepo
+	Metadata port.MetadataRepo
+	Snap     snapshot.Coordinator
+	Clock    port.Clock
+}
+
+// Execute: 配信中でなければ何もしない。
+func (uc *RefreshMetadata) Execute(ctx context.Context, in LiveDetailsInput) (RefreshMetadataOutput, error) {
+	st, err := uc.State.Get(ctx)
+	if err != nil {
+		return RefreshMetadataOutput{}, fmt.Errorf("state_get: %w", err)
+	}
+	if !st.Status.InSession() || st.VideoID == "" {
+		return RefreshMetadataOutput{}, nil
+	}
+	details, err := in.liveDetails(ctx, uc.YT, st.VideoID)
+	if err != nil {
+		return RefreshMetadataOutput{}, err
+	}
+	rev := domain.MetadataRevision{
+		At:                 clockNow(uc.Clock),
+		Title:              details.Title,
+		DescriptionHash:    descriptionHash(details.Description),
+		ScheduledStartTime: details.ScheduledStartTime,
+	}
+	out := RefreshMetadataOutput{Revision: rev}
+
+	var prev *domain.MetadataRevision
+	if revs := uc.Metadata.Revisions(); len(revs) > 0 {
+		last := revs[len(revs)-1]
+		prev = &last
+	}
+	if !uc.Metadata.Record(rev) {
+		return out, nil
+	}
+	out.Changed = true
+	out.Previous = prev
+
+	if prev == nil {
+		logging.Log(ctx, "info", "METADATA", "recorded initial metadata of %s: title=%q", st.VideoID, rev.Title)
+	} else if prev.Title != rev.Title {
+		logging.Log(ctx, "info", "METADATA", "title of %s changed: %q → %q", st.VideoID, prev.Title, rev.Title)
+	} else {
+		logging.Log(ctx, "info", "METADATA", "metadata of %s changed (description or schedule)", st.VideoID)
+	}
+	if prev == nil || prev.Title != rev.Title {
+		uc.Snap.SetVideo(st.VideoID, st.LiveChatID, details.Title, details.ChannelTitle)
+	}
+	uc.Snap.MarkDirty()
+	return out, nil
+}
+
+// descriptionHash は概要欄の SHA-256 の先頭 16 桁 (hex) を返す。本文は snapshot に載せない。
+func descriptionHash(description string) string {
+	sum := sha256.Sum256([]byte(description))
+	return hex.EncodeToString(sum[:])[:16]
+}
@@ -0,0 +1,105 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// titleRecorder は SetVideo に渡されたタイトルを記録する Coordinator。
+type titleRecorder struct {
+	snapshot.NopCoordinator
+	titles []string
+	dirty  int
+}
+
+func (c *titleRecorder) SetVideo(_, _, videoTitle, _ string) { c.titles = append(c.titles, videoTitle) }
+func (c *titleRecorder) MarkDirty()                          { c.dirty++ }
+
+func TestRefreshMetadata_RecordsOnlyChanges(t *testing.T) {
+	ctx := context.Background()
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v", LiveChatID: "chat"})
+	yt := &fakeYT{details: port.VideoLiveDetails{Title: "【雑談】朝活", Description: "概要", ChannelTitle: "ch"}}
+	repo := memory.NewMetadataRepo()
+	snap := &titleRecorder{}
+	clock := system.NewManualClock(time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC))
+	uc := &usecase.RefreshMetadata{YT: yt, State: state, Metadata: repo, Snap: snap, Clock: clock}
+
+	out, err := uc.Execute(ctx, usecase.LiveDetailsInput{})
+	if err != nil || !out.Changed || out.Previous != nil || len(out.Revision.DescriptionHash) != 16 {
+		t.Fatalf("first refresh = %+v, %v; want initial revision with a 16-digit hash", out, err)
+	}
+	if out, _ := uc.Execute(ctx, usecase.LiveDetailsInput{}); out.Changed {
+		t.Errorf("unchanged refresh recorded a revision: %+v", out)
+	}
+
+	clock.Advance(10 * time.Minute)
+	yt.details.Title = "【歌枠】朝活"
+	out, err = uc.Execute(ctx, usecase.LiveDetailsInput{})
+	if err != nil || !out.Changed || out.Previous == nil || out.Previous.Title != "【雑談】朝活" {
+		t.Fatalf("title change = %+v, %v; want the previous title reported", out, err)
+	}
+	if revs := repo.Revisions(); len(revs) != 2 || revs[1].Title != "【歌枠】朝活" || !revs[1].At.Equal(clock.Now()) {
+		t.Errorf("revisions = %+v", revs)
+	}
+	if len(snap.titles) != 2 || snap.titles[1] != "【歌枠】朝活" || snap.dirty != 2 {
+		t.Errorf("snapshot titles = %v dirty = %d, want the new title set and marked dirty", snap.titles, snap.dirty)
+	}
+
+	yt.details.Description = "概要 (セトリ追記)"
+	if out, _ := uc.Execute(ctx, usecase.LiveDetailsInput{}); !out.Changed || len(snap.titles) != 2 {
+		t.Errorf("description change = %+v titles = %v, want recorded without resetting the title", out, snap.titles)
+	}
+}
+
+func TestRefreshMetadata_SkipsOutsideSession(t *testing.T) {
+	ctx := context.Background()
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusEnded, VideoID: "v"})
+	repo := memory.NewMetadataRepo()
+	uc := &usecase.RefreshMetadata{YT: &fakeYT{}, State: state, Metadata: repo, Snap: &snapshot.NopCoordinator{}}
+
+	if out, err := uc.Execute(ctx, usecase.LiveDetailsInput{}); err != nil || out.Changed || len(repo.Revisions()) != 0 {
+		t.Errorf("refresh after end = %+v, %v; want no-op", out, err)
+	}
+}
+
+func TestSwitchVideo_RecordsInitialMetadata(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	state := memory.NewStateRepo()
+	yt := &fakeYT{details: port.VideoLiveDetails{Title: "【雑談】朝活", Description: "概要"}}
+	repo := memory.NewMetadataRepo()
+	clock := system.NewManualClock(t0)
+	sv := &usecase.SwitchVideo{
+		YT: yt, Users: memory.NewUserRepo(), Comments: memory.NewCommentRepo(), State: state,
+		Clock: clock, Snap: &snapshot.NopCoordinator{}, Metadata: repo,
+	}
+
+	// 手動の切替でも、切替時点のタイトルが最初の履歴になる
+	if _, err := sv.Execute(ctx, usecase.SwitchVideoInput{VideoID: "v"}); err != nil {
+		t.Fatalf("switch: %v", err)
+	}
+	revs := repo.Revisions()
+	if len(revs) != 1 || revs[0].Title != "【雑談】朝活" || !revs[0].At.Equal(t0) {
+		t.Fatalf("revisions after switch = %+v, want the title at switch time", revs)
+	}
+
+	// 同じ配信への再切替では履歴を増やさず、以後の確認も変化が無ければ記録しない
+	clock.Set(t0.Add(time.Minute))
+	if _, err := sv.Execute(ctx, usecase.SwitchVideoInput{VideoID: "v"}); err != nil {
+		t.Fatalf("re-switch: %v", err)
+	}
+	refresh := &usecase.RefreshMetadata{YT: yt, State: state, Metadata: repo, Snap: &snapshot.NopCoordinator{}, Clock: clock}
+	if out, _ := refresh.Execute(ctx, usecase.LiveDetailsInput{}); out.Changed || len(repo.Revisions()) != 1 {
+		t.Errorf("refresh after switch = %+v revisions=%d, want no new revision", out, len(repo.Revisions()))
+	}
+}
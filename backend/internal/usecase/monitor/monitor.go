@@ -1,5 +1,16 @@
 // Package monitor は配信開始を待ち受ける background goroutine を提供する。
-// RESERVED → SwitchVideo 呼出、ACTIVE+AutonomousMonitoring=true → Pull 呼出 を 60s tick で実行する。
+// RESERVED → SwitchVideo 呼出、ACTIVE+AutonomousMonitoring=true → Pull 呼出 を実行し、
+// 配信が無い (WAITING / ENDED) ときは予約キューの次の配信を RESERVED に昇格し、
+// キューが空なら監視中のチャンネルから次の配信を検出する。
+// 待ち受け中は Interval ごと、Pull 中は PollPolicy が Pull 結果から決めた間隔で次を実行する。
+// RESERVED の予定開始時刻は起きるたびに評価し直し、配信者による予定変更や起動後の予約にも追従する。
+// 配信中は activeLiveChatId を定期的に確認し、配信の再開でチャットが変わればユーザーを維持したまま切り替える。
+// また actualEndTime を定期的に確認し、チャットが開いたままの配信も猶予の後に ENDED にする
+// (手動 Pull の ACTIVE / PAUSED の配信も対象)。
+// 配信中は同時視聴者数などを SampleViewers が quota 残量に応じた間隔でサンプリングし、
+// タイトル・概要欄・予定開始時刻も定期的に取り直して変更履歴を残す。
+// これらの確認に使う liveStreamingDetails は 1 回の起床で 1 度だけ取得して共有する。
+// YouTube API の circuit breaker が open の間は、試行時刻 (RetryAt) まで何もせずに休止する。
 package monitor
 
 import (
@@ -8,6 +19,7 @@ import (
 	"time"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/metrics"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
@@ -16,6 +28,14 @@ import (
 const (
 	DefaultInterval = 60 * time.Second
 	DefaultBuffer   = 5 * time.Minute
+	// DefaultScheduleRecheck は予定開始時刻まで待つ間に liveStreamingDetails を取り直す間隔です (予定変更の検知用)。
+	DefaultScheduleRecheck = 15 * time.Minute
+	// DefaultChatCheckInterval は配信中に activeLiveChatId の変化 (配信の再開) を確認する間隔です。
+	DefaultChatCheckInterval = 5 * time.Minute
+	// DefaultEndCheckInterval は配信中に actualEndTime (配信の終了) を確認する間隔です。
+	DefaultEndCheckInterval = 2 * time.Minute
+	// DefaultMetadataCheckInterval は配信中にタイトル・概要欄・予定開始時刻の変更を確認する間隔です。
+	DefaultMetadataCheckInterval = 10 * time.Minute
 )
 
 // switcher は SwitchVideo usecase の依存を抽象化する (test fake 注入用)。
@@ -28,6 +48,42 @@ type puller interface {
 	Execute(ctx context.Context) (usecase.PullOutput, error)
 }
 
+// promoter は PromoteReservation usecase の依存を抽象化する (test fake 注入用)。
+type promoter interface {
+	Execute(ctx context.Context) (usecase.PromoteReservationOutput, error)
+}
+
+// detector は DetectBroadcast usecase の依存を抽象化する (test fake 注入用)。
+type detector interface {
+	Execute(ctx context.Context) (usecase.DetectBroadcastOutput, error)
+}
+
+// rescheduler は Reschedule usecase の依存を抽象化する (test fake 注入用)。
+type rescheduler interface {
+	Execute(ctx context.Context, in usecase.RescheduleInput) (usecase.RescheduleOutput, error)
+}
+
+// chatRefresher は RefreshLiveChat usecase の依存を抽象化する (test fake 注入用)。
+type chatRefresher interface {
+	Execute(ctx context.Context, in usecase.LiveDetailsInput) (usecase.RefreshLiveChatOutput, error)
+}
+
+// viewerSampler は SampleViewers usecase の依存を抽象化する (test fake 注入用)。
+type viewerSampler interface {
+	Due(now time.Time) bool
+	Execute(ctx context.Context, in usecase.LiveDetailsInput) (usecase.SampleViewersOutput, error)
+}
+
+// metadataRefresher は RefreshMetadata usecase の依存を抽象化する (test fake 注入用)。
+type metadataRefresher interface {
+	Execute(ctx context.Context, in usecase.LiveDetailsInput) (usecase.RefreshMetadataOutput, error)
+}
+
+// streamEndChecker は CheckStreamEnd usecase の依存を抽象化する (test fake 注入用)。
+type streamEndChecker interface {
+	Execute(ctx context.Context, in usecase.LiveDetailsInput) (usecase.CheckStreamEndOutput, error)
+}
+
 // detailsFetcher は YouTubePort.GetVideoLiveDetails のサブセット (test fake 注入用)。
 type detailsFetcher interface {
 	GetVideoLiveDetails(ctx context.Context, videoID string) (port.VideoLiveDetails, error)
@@ -37,13 +93,48 @@ type detailsFetcher interface {
 type Monitor struct {
 	SwitchVideo switcher
 	Pull        puller
-	YT          detailsFetcher
+	YT          detailsFetcher // nil なら配信中の確認は各 usecase が個別に取得する
 	State       port.StateRepo
 	Clock       port.Clock
 	Interval    time.Duration // 0 なら DefaultInterval
 	Buffer      time.Duration // 0 なら DefaultBuffer
 
-	// TickC を inject すると Interval 無視で外部 channel を使う (test 用)。
+	Poll  *PollPolicy        // 任意: 設定時は Pull の間隔を動的に決める (nil なら Interval 固定)
+	Quota port.QuotaReporter // 任意: PollPolicy に quota 残量を渡す
+
+	// Promote は任意: 設定時は配信が無い (WAITING / ENDED) とき予約キューの先頭を RESERVED に昇格する
+	Promote promoter
+	// Detect は任意: 設定時は昇格する予約が無いとき監視中のチャンネルから次の配信を検出する
+	Detect detector
+
+	// Reschedule は任意: 設定時は RESERVED の予定開始時刻の変更を State に反映する
+	Reschedule      rescheduler
+	ScheduleRecheck time.Duration // 0 なら DefaultScheduleRecheck
+	// RefreshChat は任意: 設定時は配信中に ChatCheckInterval ごとに activeLiveChatId の変化を確認する
+	RefreshChat       chatRefresher
+	ChatCheckInterval time.Duration // 0 なら DefaultChatCheckInterval
+	// CheckEnd は任意: 設定時は配信中に EndCheckInterval ごとに actualEndTime を確認し、終了した配信を ENDED にする
+	CheckEnd         streamEndChecker
+	EndCheckInterval time.Duration // 0 なら DefaultEndCheckInterval
+
+	// SampleViewers は任意: 設定時は配信中の起床のたびに Due を確認し、サンプリング間隔の判定は usecase 側で行う
+	SampleViewers viewerSampler
+	// SampleYT は任意: サンプリングのためだけに詳細を取得するときに使う (低優先度で予算判定する fetcher を渡す)。nil なら YT
+	SampleYT detailsFetcher
+	// RefreshMetadata は任意: 設定時は配信中に MetadataCheckInterval ごとにタイトルなどの変更を確認する
+	RefreshMetadata       metadataRefresher
+	MetadataCheckInterval time.Duration // 0 なら DefaultMetadataCheckInterval
+
+	// Breaker は任意: 設定時は circuit breaker が open の間 RetryAt まで休止する
+	Breaker port.BreakerReporter
+
+	// Run の goroutine からのみ触る待ち受け状態
+	wait              reservedWait
+	nextChatCheck     time.Time
+	nextEndCheck      time.Time
+	nextMetadataCheck time.Time
+
+	// TickC を inject すると Interval / Poll 無視で外部 channel を使う (test 用)。
 	TickC <-chan time.Time
 }
 
@@ -65,91 +156,72 @@ func New(
 	}
 }
 
+// reservedWait は RESERVED の配信の予定開始時刻 - Buffer まで待っている間の計画です。
+// State の videoID / 予定時刻が変わらず recheckAt 前なら、API を呼ばずに待ち続ける。
+type reservedWait struct {
+	videoID   string
+	scheduled time.Time // 計画を立てたときの State.ScheduledStartTime
+	wakeAt    time.Time
+	recheckAt time.Time
+}
+
+// matches は st が計画を立てたときの予約のままかを返す。
+func (w reservedWait) matches(st domain.LiveState) bool {
+	return w.videoID != "" && w.videoID == st.VideoID && w.scheduled.Equal(st.ScheduledStartTime)
+}
+
 // Run は ctx.Done まで tick loop を回す。
-// RESERVED + ScheduledStartTime - Buffer が未来なら最初に sleep してから tick 開始する。
+// 起動直後に 1 回実行し、以降は process が返した間隔 (TickC 設定時は tick ごと) で実行する。
 func (m *Monitor) Run(ctx context.Context) {
 	interval := m.Interval
 	if interval == 0 {
 		interval = DefaultInterval
 	}
-	buffer := m.Buffer
-	if buffer == 0 {
-		buffer = DefaultBuffer
-	}
 
-	// 初期 sleep: RESERVED + scheduledStartTime - buffer が未来なら待つ (API quota 節約)
-	m.waitUntilScheduled(ctx, buffer)
-	if ctx.Err() != nil {
-		return
-	}
+	next := m.process(ctx, interval)
 
-	tickC := m.TickC
-	var ticker *time.Ticker
-	if tickC == nil {
-		ticker = time.NewTicker(interval)
-		defer ticker.Stop()
-		tickC = ticker.C
+	if m.TickC != nil {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-m.TickC:
+				m.process(ctx, interval)
+			}
+		}
 	}
 
-	// sleep 完了直後に即実行 (体感応答を良くする)
-	m.process(ctx)
-
+	timer := time.NewTimer(next)
+	defer timer.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-tickC:
-			m.process(ctx)
+		case <-timer.C:
+			next = m.process(ctx, interval)
+			timer.Reset(next)
 		}
 	}
 }
 
-// waitUntilScheduled は RESERVED かつ scheduledStartTime - buffer が未来なら sleep する。
-func (m *Monitor) waitUntilScheduled(ctx context.Context, buffer time.Duration) {
-	st, err := m.State.Get(ctx)
-	if err != nil {
-		logging.Log(ctx, "warn", "MONITOR", "state_get on init failed: %v", err)
-		return
-	}
-	if st.Status != domain.StatusReserved || st.ScheduledStartTime.IsZero() {
-		return
-	}
-	wakeAt := st.ScheduledStartTime.Add(-buffer)
-	wait := wakeAt.Sub(m.Clock.Now())
-	if wait <= 0 {
-		return
-	}
-	logging.Log(ctx, "info", "MONITOR", "sleeping until %s (buffer=%s) before tick start", wakeAt.Format(time.RFC3339), buffer)
-	select {
-	case <-ctx.Done():
-	case <-time.After(wait):
-	}
-}
-
-// process は State を再評価して RESERVED / ACTIVE+AM の場合に適切な usecase を呼ぶ。
+// process は State を再評価して RESERVED / ACTIVE+AM の場合に適切な usecase を呼び、次の実行までの待ち時間を返す。
 // tick ごとに毎回 State.Get することで Reserve / Cancel / Reset 等の動的変化に追従する。
-func (m *Monitor) process(ctx context.Context) {
+func (m *Monitor) process(ctx context.Context, interval time.Duration) time.Duration {
 	st, err := m.State.Get(ctx)
 	if err != nil {
 		logging.Log(ctx, "warn", "MONITOR", "state_get failed: %v", err)
-		return
+		metrics.MonitorTicks.Inc("state_get", "error")
+		return interval
+	}
+
+	if wait, paused := m.paused(ctx, st); paused {
+		return wait
 	}
 
 	switch {
 	case st.Status == domain.StatusReserved:
-		// 配信開始済みかを usecase.IsLiveNotStarted で判定 (handler の StartOrReserve と同一述語)。
-		// premiere / test broadcast 中は ActualStartTime が先行して立つことがあるため、ScheduledStartTime も併用する。
-		if m.YT != nil {
-			details, derr := m.YT.GetVideoLiveDetails(ctx, st.VideoID)
-			if derr != nil {
-				logging.Log(ctx, "warn", "MONITOR", "get_video_live_details failed: %v", derr)
-				return
-			}
-			if usecase.IsLiveNotStarted(details, m.Clock.Now()) {
-				logging.Log(ctx, "info", "MONITOR", "tick: RESERVED, waiting (videoId=%s actualStart=%s scheduled=%s)",
-					st.VideoID, details.ActualStartTime.Format(time.RFC3339), details.ScheduledStartTime.Format(time.RFC3339))
-				return
-			}
+		if wait, waiting := m.waitReserved(ctx, st, interval); waiting {
+			return wait
 		}
 		logging.Log(ctx, "info", "MONITOR", "tick: RESERVED → SwitchVideo (videoId=%s)", st.VideoID)
 		_, err := m.SwitchVideo.Execute(ctx, usecase.SwitchVideoInput{VideoID: st.VideoID})
@@ -161,12 +233,300 @@ func (m *Monitor) process(ctx context.Context) {
 			} else {
 				logging.Log(ctx, "warn", "MONITOR", "switch_video failed: %v", err)
 			}
+			metrics.MonitorTicks.Inc("switch_video", "error")
+			return interval
+		}
+		metrics.MonitorTicks.Inc("switch_video", "ok")
+		if m.Poll != nil {
+			// 新しい配信の速度で測り直し、最初の Pull はすぐ行う
+			m.Poll.Reset()
+			minD, _ := m.Poll.bounds()
+			return minD
 		}
 	case st.Status == domain.StatusActive && st.AutonomousMonitoring:
-		if _, err := m.Pull.Execute(ctx); err != nil {
+		out, err := m.Pull.Execute(ctx)
+		if err != nil {
 			logging.Log(ctx, "warn", "MONITOR", "pull failed: %v", err)
+			metrics.MonitorTicks.Inc("pull", "error")
+			return interval
+		}
+		metrics.MonitorTicks.Inc("pull", "ok")
+		if !out.AutoReset {
+			out.AutoReset = m.watchLive(ctx, st)
+		}
+		if out.AutoReset && (m.promote(ctx) || m.detect(ctx)) {
+			// 配信終了 → 予約キュー / 監視チャンネルの次の配信を待ち受ける
+			return interval
+		}
+		if m.Poll != nil && !out.AutoReset {
+			var quota *domain.QuotaUsage
+			if m.Quota != nil {
+				usage := m.Quota.Usage()
+				quota = &usage
+			}
+			next := m.Poll.Next(m.Clock.Now(), out, quota)
+			logging.Log(ctx, "info", "MONITOR", "next pull in %s (added=%d pageFull=%v server=%dms)",
+				next, out.AddedCount, out.PageFull, out.ServerPollingIntervalMillis)
+			return next
+		}
+	case st.Status == domain.StatusWaiting || st.Status == domain.StatusEnded || st.Status == "":
+		if !m.promote(ctx) && !m.detect(ctx) {
+			metrics.MonitorTicks.Inc("idle", "ok")
+		}
+	case st.Status.InSession():
+		// ACTIVE+AM=false / PAUSED → Pull はしないが、配信の終了などは確認する
+		if m.watchLive(ctx, st) && (m.promote(ctx) || m.detect(ctx)) {
+			return interval
 		}
 	default:
-		// WAITING / ACTIVE+AM=false → no-op
+		metrics.MonitorTicks.Inc("idle", "ok")
+	}
+	return interval
+}
+
+// paused は circuit breaker が open で、st が API を呼ぶ状態なら (RetryAt までの待ち時間, true) を返す。
+// RetryAt を過ぎていれば通常どおり処理し、その呼び出しが half-open の試行になる。
+func (m *Monitor) paused(ctx context.Context, st domain.LiveState) (time.Duration, bool) {
+	if m.Breaker == nil {
+		return 0, false
+	}
+	b := m.Breaker.BreakerStatus()
+	if b.State == domain.BreakerClosed || b.RetryAt == nil {
+		return 0, false
+	}
+	wait := b.RetryAt.Sub(m.Clock.Now())
+	if wait <= 0 {
+		return 0, false
+	}
+	logging.Log(ctx, "info", "MONITOR", "tick: YouTube API paused (%s) until %s, skipping (status=%s)", b.Reason, b.RetryAt.Format(time.RFC3339), st.Status)
+	metrics.MonitorTicks.Inc("paused", "ok")
+	return wait, true
+}
+
+// waitReserved は RESERVED の配信をまだ SwitchVideo すべきでなければ (次の実行までの待ち時間, true) を返す。
+// 予定開始時刻 - Buffer までは API を呼ばずに待ち、ScheduleRecheck ごとに liveStreamingDetails を取り直して
+// 予定変更を State に反映する。State の予約が変わった (取消・別の予約) ときは次の tick で計画を立て直す。
+func (m *Monitor) waitReserved(ctx context.Context, st domain.LiveState, interval time.Duration) (time.Duration, bool) {
+	buffer := m.Buffer
+	if buffer == 0 {
+		buffer = DefaultBuffer
+	}
+	recheck := m.ScheduleRecheck
+	if recheck == 0 {
+		recheck = DefaultScheduleRecheck
+	}
+	now := m.Clock.Now()
+	if m.wait.matches(st) && now.Before(m.wait.wakeAt) && now.Before(m.wait.recheckAt) {
+		metrics.MonitorTicks.Inc("wait", "ok")
+		return min(interval, m.wait.wakeAt.Sub(now)), true
+	}
+	m.wait = reservedWait{}
+
+	scheduled := st.ScheduledStartTime
+	var details *port.VideoLiveDetails
+	if m.YT != nil {
+		d, err := m.YT.GetVideoLiveDetails(ctx, st.VideoID)
+		if err != nil {
+			logging.Log(ctx, "warn", "MONITOR", "get_video_live_details failed: %v", err)
+			metrics.MonitorTicks.Inc("wait", "error")
+			return interval, true
+		}
+		details = &d
+		if !d.ScheduledStartTime.IsZero() && !d.ScheduledStartTime.Equal(scheduled) {
+			logging.Log(ctx, "info", "MONITOR", "tick: RESERVED schedule changed (videoId=%s %s → %s)",
+				st.VideoID, scheduled.Format(time.RFC3339), d.ScheduledStartTime.Format(time.RFC3339))
+			scheduled = d.ScheduledStartTime
+			if m.Reschedule != nil {
+				out, err := m.Reschedule.Execute(ctx, usecase.RescheduleInput{VideoID: st.VideoID, ScheduledStartTime: scheduled})
+				if err != nil {
+					logging.Log(ctx, "warn", "MONITOR", "reschedule failed: %v", err)
+				} else {
+					st = out.State
+				}
+			}
+		}
+	}
+
+	// 予定開始時刻 - Buffer が未来なら、それまで API を呼ばずに待つ (API quota 節約)
+	if wakeAt := scheduled.Add(-buffer); !scheduled.IsZero() && wakeAt.After(now) {
+		m.wait = reservedWait{videoID: st.VideoID, scheduled: st.ScheduledStartTime, wakeAt: wakeAt, recheckAt: now.Add(recheck)}
+		logging.Log(ctx, "info", "MONITOR", "tick: RESERVED, sleeping until %s (videoId=%s buffer=%s)", wakeAt.Format(time.RFC3339), st.VideoID, buffer)
+		metrics.MonitorTicks.Inc("wait", "ok")
+		return min(interval, wakeAt.Sub(now)), true
+	}
+
+	// 配信開始済みかを usecase.IsLiveNotStarted で判定 (handler の StartOrReserve と同一述語)。
+	// premiere / test broadcast 中は ActualStartTime が先行して立つことがあるため、ScheduledStartTime も併用する。
+	if details != nil && usecase.IsLiveNotStarted(*details, now) {
+		logging.Log(ctx, "info", "MONITOR", "tick: RESERVED, waiting (videoId=%s actualStart=%s scheduled=%s)",
+			st.VideoID, details.ActualStartTime.Format(time.RFC3339), details.ScheduledStartTime.Format(time.RFC3339))
+		metrics.MonitorTicks.Inc("wait", "ok")
+		return interval, true
+	}
+	return 0, false
+}
+
+// watchLive は配信中に期限の来た確認 (チャット切替・視聴者数・メタデータ・終了) を行い、ENDED に遷移したら true を返す。
+// liveStreamingDetails は期限の来た確認がある起床でだけ 1 度取得し、すべての確認に渡す。
+// サンプリングだけのための取得は SampleYT (低優先度) で行い、予算が厳しければ見送る。
+func (m *Monitor) watchLive(ctx context.Context, st domain.LiveState) bool {
+	now := m.Clock.Now()
+	chat := m.RefreshChat != nil && !now.Before(m.nextChatCheck)
+	meta := m.RefreshMetadata != nil && !now.Before(m.nextMetadataCheck)
+	end := m.CheckEnd != nil && !now.Before(m.nextEndCheck)
+	sample := m.SampleViewers != nil && m.SampleViewers.Due(now)
+	if !chat && !meta && !end && !sample {
+		return false
+	}
+	if chat {
+		m.nextChatCheck = now.Add(orDefault(m.ChatCheckInterval, DefaultChatCheckInterval))
+	}
+	if meta {
+		m.nextMetadataCheck = now.Add(orDefault(m.MetadataCheckInterval, DefaultMetadataCheckInterval))
+	}
+	if end {
+		m.nextEndCheck = now.Add(orDefault(m.EndCheckInterval, DefaultEndCheckInterval))
+	}
+
+	in := usecase.LiveDetailsInput{VideoID: st.VideoID}
+	fetcher := m.YT
+	if !chat && !meta && !end && m.SampleYT != nil {
+		fetcher = m.SampleYT
+	}
+	if fetcher != nil {
+		details, err := fetcher.GetVideoLiveDetails(ctx, st.VideoID)
+		if err != nil {
+			logging.Log(ctx, "warn", "MONITOR", "get_video_live_details failed: %v", err)
+			metrics.MonitorTicks.Inc("live_details", "error")
+			return false
+		}
+		in.Details = &details
+	}
+
+	if chat {
+		m.refreshChat(ctx, in)
+	}
+	if sample {
+		m.sampleViewers(ctx, in)
+	}
+	if meta {
+		m.refreshMetadata(ctx, in)
+	}
+	return end && m.checkEnd(ctx, in)
+}
+
+// orDefault は d が 0 なら def を返す。
+func orDefault(d, def time.Duration) time.Duration {
+	if d == 0 {
+		return def
+	}
+	return d
+}
+
+// refreshChat は activeLiveChatId の変化を確認し、変わっていれば新しいチャットへ切り替える。
+func (m *Monitor) refreshChat(ctx context.Context, in usecase.LiveDetailsInput) {
+	out, err := m.RefreshChat.Execute(ctx, in)
+	if err != nil {
+		logging.Log(ctx, "warn", "MONITOR", "refresh live chat failed: %v", err)
+		metrics.MonitorTicks.Inc("refresh_chat", "error")
+		return
+	}
+	if out.Changed {
+		logging.Log(ctx, "info", "MONITOR", "tick: live chat changed %s → %s (videoId=%s)", out.OldLiveChatID, out.State.LiveChatID, out.State.VideoID)
+		metrics.MonitorTicks.Inc("refresh_chat", "ok")
+		if m.Poll != nil {
+			m.Poll.Reset()
+		}
+	}
+}
+
+// sampleViewers は同時視聴者数などをサンプリングする。
+func (m *Monitor) sampleViewers(ctx context.Context, in usecase.LiveDetailsInput) {
+	out, err := m.SampleViewers.Execute(ctx, in)
+	if err != nil {
+		logging.Log(ctx, "warn", "MONITOR", "sample viewers failed: %v", err)
+		metrics.MonitorTicks.Inc("sample_viewers", "error")
+		return
+	}
+	if out.Sampled {
+		metrics.MonitorTicks.Inc("sample_viewers", "ok")
+	}
+}
+
+// refreshMetadata はタイトル・概要欄・予定開始時刻を取り直し、変更を履歴に残す。
+func (m *Monitor) refreshMetadata(ctx context.Context, in usecase.LiveDetailsInput) {
+	out, err := m.RefreshMetadata.Execute(ctx, in)
+	if err != nil {
+		logging.Log(ctx, "warn", "MONITOR", "refresh metadata failed: %v", err)
+		metrics.MonitorTicks.Inc("refresh_metadata", "error")
+		return
+	}
+	if out.Changed {
+		metrics.MonitorTicks.Inc("refresh_metadata", "ok")
+	}
+}
+
+// checkEnd は配信の終了を確認し、ENDED に遷移したら true を返す。
+// 終了済みで猶予中なら、猶予の終わりに確認し直す。
+func (m *Monitor) checkEnd(ctx context.Context, in usecase.LiveDetailsInput) bool {
+	out, err := m.CheckEnd.Execute(ctx, in)
+	if err != nil {
+		logging.Log(ctx, "warn", "MONITOR", "check stream end failed: %v", err)
+		metrics.MonitorTicks.Inc("check_end", "error")
+		return false
+	}
+	metrics.MonitorTicks.Inc("check_end", "ok")
+	switch {
+	case out.Ended:
+		logging.Log(ctx, "info", "MONITOR", "tick: stream ended at %s → ENDED (videoId=%s)", out.ActualEndTime.Format(time.RFC3339), out.State.VideoID)
+		return true
+	case !out.EndsAt.IsZero():
+		logging.Log(ctx, "info", "MONITOR", "tick: stream ended at %s, pulling trailing comments until %s (videoId=%s)",
+			out.ActualEndTime.Format(time.RFC3339), out.EndsAt.Format(time.RFC3339), out.State.VideoID)
+		if out.EndsAt.Before(m.nextEndCheck) {
+			m.nextEndCheck = out.EndsAt
+		}
+	}
+	return false
+}
+
+// promote は予約キューの先頭を RESERVED に昇格し、昇格したかを返す。Promote 未設定なら何もしない。
+func (m *Monitor) promote(ctx context.Context) bool {
+	if m.Promote == nil {
+		return false
+	}
+	out, err := m.Promote.Execute(ctx)
+	if err != nil {
+		logging.Log(ctx, "warn", "MONITOR", "promote reservation failed: %v", err)
+		metrics.MonitorTicks.Inc("promote", "error")
+		return false
+	}
+	if out.Promoted == nil {
+		return false
+	}
+	logging.Log(ctx, "info", "MONITOR", "tick: promoted queued reservation (videoId=%s)", out.Promoted.VideoID)
+	metrics.MonitorTicks.Inc("promote", "ok")
+	return true
+}
+
+// detect は監視中のチャンネルの配信を検出して Reserve / SwitchVideo し、検出したかを返す。Detect 未設定なら何もしない。
+func (m *Monitor) detect(ctx context.Context) bool {
+	if m.Detect == nil {
+		return false
+	}
+	out, err := m.Detect.Execute(ctx)
+	if err != nil {
+		logging.Log(ctx, "warn", "MONITOR", "detect broadcast failed: %v", err)
+		metrics.MonitorTicks.Inc("detect", "error")
+		return false
+	}
+	if out.Detected == nil {
+		return false
+	}
+	logging.Log(ctx, "info", "MONITOR", "tick: detected broadcast on %s → %s (videoId=%s)", out.Channel, out.Dispatched, out.Detected.VideoID)
+	metrics.MonitorTicks.Inc("detect", "ok")
+	if out.Dispatched == "switch" && m.Poll != nil {
+		m.Poll.Reset()
 	}
+	return true
 }
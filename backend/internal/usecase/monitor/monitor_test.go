@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
@@ -47,11 +48,6 @@ func (f *fakePuller) Execute(_ context.Context) (usecase.PullOutput, error) {
 	return usecase.PullOutput{}, f.err
 }
 
-// fixedClock は Clock interface の固定時刻実装。
-type fixedClock struct{ t time.Time }
-
-func (c fixedClock) Now() time.Time { return c.t }
-
 // buildMonitor は tick channel inject 済みの Monitor を生成するヘルパー。
 // yt が nil の場合は actualStartTime 判定をスキップする (= 古い挙動)。
 func buildMonitor(sw *fakeSwitcher, pl *fakePuller, yt *fakeYT, state *memory.StateRepo, tickC <-chan time.Time) *monitor.Monitor {
@@ -59,7 +55,7 @@ func buildMonitor(sw *fakeSwitcher, pl *fakePuller, yt *fakeYT, state *memory.St
 		SwitchVideo: sw,
 		Pull:        pl,
 		State:       state,
-		Clock:       fixedClock{t: time.Now()},
+		Clock:       system.NewManualClock(time.Now()),
 		TickC:       tickC,
 	}
 	if yt != nil {
@@ -238,7 +234,7 @@ func TestMonitor_ScheduledStartTime_SleepSkippedIfPast(t *testing.T) {
 		Pull:        pl,
 		YT:          yt,
 		State:       state,
-		Clock:       fixedClock{t: time.Now()},
+		Clock:       system.NewManualClock(time.Now()),
 		Buffer:      5 * time.Minute,
 		TickC:       tickC,
 	}
@@ -292,7 +288,7 @@ func TestMonitor_ScheduledStartTime_SleepsUntilBuffer(t *testing.T) {
 		SwitchVideo: sw,
 		Pull:        pl,
 		State:       state,
-		Clock:       fixedClock{t: now},
+		Clock:       system.NewManualClock(now),
 		Buffer:      buffer,
 		TickC:       tickC,
 	}
@@ -439,3 +435,415 @@ func TestMonitor_Reserved_ActualStartTimeSet_Switches(t *testing.T) {
 		t.Errorf("SwitchVideo.Execute should be called when actualStartTime is set, got %d", sw.calls.Load())
 	}
 }
+
+// fakePromoter は Promote の呼び出し回数を数える fake。promoted なら昇格した結果を返す。
+type fakePromoter struct {
+	calls    atomic.Int32
+	promoted bool
+}
+
+func (f *fakePromoter) Execute(_ context.Context) (usecase.PromoteReservationOutput, error) {
+	f.calls.Add(1)
+	if !f.promoted {
+		return usecase.PromoteReservationOutput{}, nil
+	}
+	return usecase.PromoteReservationOutput{Promoted: &domain.Reservation{VideoID: "next"}}, nil
+}
+
+// TestMonitor_EndedOrWaiting_PromotesQueue: 配信が無い (WAITING / ENDED) tick で予約キューの昇格を試み、
+// 配信中は昇格しない。
+func TestMonitor_EndedOrWaiting_PromotesQueue(t *testing.T) {
+	for _, tc := range []struct {
+		status domain.Status
+		want   bool
+	}{
+		{domain.StatusEnded, true},
+		{domain.StatusWaiting, true},
+		{domain.StatusPaused, false},
+	} {
+		t.Run(string(tc.status), func(t *testing.T) {
+			state := memory.NewStateRepo()
+			_ = state.Set(context.Background(), domain.LiveState{Status: tc.status, VideoID: "vid"})
+			pr := &fakePromoter{promoted: true}
+			m := buildMonitor(&fakeSwitcher{}, &fakePuller{}, nil, state, make(chan time.Time))
+			m.Promote = pr
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				m.Run(ctx)
+			}()
+			time.Sleep(20 * time.Millisecond)
+			cancel()
+			<-done
+
+			if got := pr.calls.Load() > 0; got != tc.want {
+				t.Errorf("%s: promote called = %v, want %v", tc.status, got, tc.want)
+			}
+		})
+	}
+}
+
+// fakeDetector は Detect の呼び出し回数を数える fake。
+type fakeDetector struct {
+	calls atomic.Int32
+}
+
+func (f *fakeDetector) Execute(_ context.Context) (usecase.DetectBroadcastOutput, error) {
+	f.calls.Add(1)
+	return usecase.DetectBroadcastOutput{Channel: "@ch", Detected: &port.Broadcast{VideoID: "found"}, Dispatched: "reserve"}, nil
+}
+
+// TestMonitor_Waiting_DetectsWhenQueueEmpty: 予約キューに昇格する配信が無いときだけチャンネル監視の検出を行う。
+func TestMonitor_Waiting_DetectsWhenQueueEmpty(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		promoted bool
+		want     bool
+	}{
+		{"queue empty", false, true},
+		{"queue promoted", true, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := memory.NewStateRepo()
+			_ = state.Set(context.Background(), domain.LiveState{Status: domain.StatusWaiting})
+			det := &fakeDetector{}
+			m := buildMonitor(&fakeSwitcher{}, &fakePuller{}, nil, state, make(chan time.Time))
+			m.Promote = &fakePromoter{promoted: tc.promoted}
+			m.Detect = det
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				m.Run(ctx)
+			}()
+			time.Sleep(20 * time.Millisecond)
+			cancel()
+			<-done
+
+			if got := det.calls.Load() > 0; got != tc.want {
+				t.Errorf("detect called = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// fakeRescheduler は Reschedule の入力を記録する fake。
+type fakeRescheduler struct {
+	calls atomic.Int32
+	in    usecase.RescheduleInput
+	state *memory.StateRepo
+}
+
+func (f *fakeRescheduler) Execute(ctx context.Context, in usecase.RescheduleInput) (usecase.RescheduleOutput, error) {
+	f.calls.Add(1)
+	f.in = in
+	st, _ := f.state.Get(ctx)
+	st.ScheduledStartTime = in.ScheduledStartTime
+	_ = f.state.Set(ctx, st)
+	return usecase.RescheduleOutput{Changed: true, State: st}, nil
+}
+
+// TestMonitor_Reserved_RescheduleIsPersistedAndSlept: 予定が後ろ倒しされたら State に反映し、
+// 再確認の間隔までは API を呼ばずに待つ (SwitchVideo もしない)。
+func TestMonitor_Reserved_RescheduleIsPersistedAndSlept(t *testing.T) {
+	now := time.Now()
+	later := now.Add(2 * time.Hour)
+	sw := &fakeSwitcher{}
+	yt := &fakeYT{details: port.VideoLiveDetails{ScheduledStartTime: later}}
+	state := memory.NewStateRepo()
+	// 予約時の予定は過ぎているが、配信者が 2 時間後に変更済み
+	_ = state.Set(context.Background(), domain.LiveState{
+		Status:             domain.StatusReserved,
+		VideoID:            "vid_moved",
+		ScheduledStartTime: now.Add(-time.Minute),
+	})
+	re := &fakeRescheduler{state: state}
+
+	tickC := make(chan time.Time, 2)
+	m := buildMonitor(sw, &fakePuller{}, yt, state, tickC)
+	m.Reschedule = re
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.Run(ctx)
+	}()
+	tickC <- now
+	tickC <- now
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	<-done
+
+	if re.calls.Load() != 1 || !re.in.ScheduledStartTime.Equal(later) {
+		t.Errorf("Reschedule calls = %d in = %+v, want 1 call with %s", re.calls.Load(), re.in, later)
+	}
+	if got, _ := state.Get(context.Background()); !got.ScheduledStartTime.Equal(later) {
+		t.Errorf("ScheduledStartTime = %s, want %s", got.ScheduledStartTime, later)
+	}
+	if sw.calls.Load() != 0 {
+		t.Errorf("SwitchVideo calls = %d, want 0 (stream moved to later)", sw.calls.Load())
+	}
+	if yt.calls.Load() != 1 {
+		t.Errorf("GetVideoLiveDetails calls = %d, want 1 (later ticks sleep until the recheck)", yt.calls.Load())
+	}
+}
+
+// fakeChatRefresher は RefreshChat の呼び出し回数を数える fake。
+type fakeChatRefresher struct {
+	calls atomic.Int32
+}
+
+func (f *fakeChatRefresher) Execute(_ context.Context, _ usecase.LiveDetailsInput) (usecase.RefreshLiveChatOutput, error) {
+	f.calls.Add(1)
+	return usecase.RefreshLiveChatOutput{Changed: true, OldLiveChatID: "chat-1", State: domain.LiveState{VideoID: "v", LiveChatID: "chat-2"}}, nil
+}
+
+// TestMonitor_ActiveAM_RefreshesChatPerInterval: 配信中のチャット確認は ChatCheckInterval に 1 回だけ行う。
+func TestMonitor_ActiveAM_RefreshesChatPerInterval(t *testing.T) {
+	state := memory.NewStateRepo()
+	_ = state.Set(context.Background(), domain.LiveState{Status: domain.StatusActive, VideoID: "v", LiveChatID: "chat-1", AutonomousMonitoring: true})
+	pl := &fakePuller{}
+	rc := &fakeChatRefresher{}
+	tickC := make(chan time.Time, 3)
+	m := buildMonitor(&fakeSwitcher{}, pl, nil, state, tickC)
+	m.RefreshChat = rc
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.Run(ctx)
+	}()
+	for range 3 {
+		tickC <- time.Now()
+	}
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	<-done
+
+	if pl.calls.Load() < 3 {
+		t.Errorf("Pull calls = %d, want >= 3", pl.calls.Load())
+	}
+	if rc.calls.Load() != 1 {
+		t.Errorf("RefreshChat calls = %d, want 1 (fixed clock stays within ChatCheckInterval)", rc.calls.Load())
+	}
+}
+
+// fakeEndChecker は最初の確認で猶予中、以降は終了を返す fake。
+type fakeEndChecker struct {
+	calls  atomic.Int32
+	endsAt time.Time
+}
+
+func (f *fakeEndChecker) Execute(_ context.Context, _ usecase.LiveDetailsInput) (usecase.CheckStreamEndOutput, error) {
+	if f.calls.Add(1) == 1 {
+		return usecase.CheckStreamEndOutput{ActualEndTime: f.endsAt.Add(-time.Minute), EndsAt: f.endsAt}, nil
+	}
+	return usecase.CheckStreamEndOutput{Ended: true}, nil
+}
+
+// TestMonitor_ActiveAM_ChecksEndAndPromotes: 猶予の終わりに確認し直し、ENDED になったら予約キューを昇格する。
+func TestMonitor_ActiveAM_ChecksEndAndPromotes(t *testing.T) {
+	now := time.Now()
+	state := memory.NewStateRepo()
+	_ = state.Set(context.Background(), domain.LiveState{Status: domain.StatusActive, VideoID: "v", AutonomousMonitoring: true})
+	clock := system.NewManualClock(now)
+	ec := &fakeEndChecker{endsAt: now.Add(30 * time.Second)}
+	pr := &fakePromoter{promoted: true}
+	tickC := make(chan time.Time)
+	m := buildMonitor(&fakeSwitcher{}, &fakePuller{}, nil, state, tickC)
+	m.Clock = clock
+	m.CheckEnd = ec
+	m.Promote = pr
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.Run(ctx)
+	}()
+	tickC <- now // 猶予中 (EndCheckInterval 内)
+	if ec.calls.Load() != 1 || pr.calls.Load() != 0 {
+		t.Errorf("within grace: check calls = %d promote calls = %d, want 1 and 0", ec.calls.Load(), pr.calls.Load())
+	}
+	cancel()
+	<-done
+
+	clock.Set(now.Add(30 * time.Second)) // 猶予の終わり (EndCheckInterval より前)
+	ctx, cancel = context.WithCancel(context.Background())
+	done = make(chan struct{})
+	go func() {
+		defer close(done)
+		m.Run(ctx)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	<-done
+	if ec.calls.Load() != 2 || pr.calls.Load() != 1 {
+		t.Errorf("after grace: check calls = %d promote calls = %d, want 2 and 1", ec.calls.Load(), pr.calls.Load())
+	}
+}
+
+// TestMonitor_ManualOrPaused_ChecksEnd: 手動 Pull の ACTIVE / PAUSED でも Pull はせずに配信の終了を確認する。
+func TestMonitor_ManualOrPaused_ChecksEnd(t *testing.T) {
+	for _, st := range []domain.LiveState{
+		{Status: domain.StatusActive, VideoID: "v"},
+		{Status: domain.StatusPaused, VideoID: "v", AutonomousMonitoring: true},
+	} {
+		now := time.Now()
+		state := memory.NewStateRepo()
+		_ = state.Set(context.Background(), st)
+		pl := &fakePuller{}
+		ec := &fakeEndChecker{endsAt: now.Add(30 * time.Second)}
+		tickC := make(chan time.Time)
+		m := buildMonitor(&fakeSwitcher{}, pl, nil, state, tickC)
+		m.Clock = system.NewManualClock(now)
+		m.CheckEnd = ec
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			m.Run(ctx)
+		}()
+		tickC <- now
+		cancel()
+		<-done
+		if ec.calls.Load() != 1 || pl.calls.Load() != 0 {
+			t.Errorf("%s (AM=%v): check calls = %d pull calls = %d, want 1 and 0", st.Status, st.AutonomousMonitoring, ec.calls.Load(), pl.calls.Load())
+		}
+	}
+}
+
+// detailsRecorder は配信中の確認 4 つを兼ね、受け取った LiveDetailsInput を記録する fake。
+type detailsRecorder struct {
+	due    bool
+	inputs map[string]usecase.LiveDetailsInput
+}
+
+func (f *detailsRecorder) record(name string, in usecase.LiveDetailsInput) {
+	if f.inputs == nil {
+		f.inputs = map[string]usecase.LiveDetailsInput{}
+	}
+	f.inputs[name] = in
+}
+
+type recordingChat struct{ *detailsRecorder }
+
+func (f recordingChat) Execute(_ context.Context, in usecase.LiveDetailsInput) (usecase.RefreshLiveChatOutput, error) {
+	f.record("chat", in)
+	return usecase.RefreshLiveChatOutput{}, nil
+}
+
+type recordingSampler struct{ *detailsRecorder }
+
+func (f recordingSampler) Due(time.Time) bool { return f.due }
+
+func (f recordingSampler) Execute(_ context.Context, in usecase.LiveDetailsInput) (usecase.SampleViewersOutput, error) {
+	f.record("sample", in)
+	return usecase.SampleViewersOutput{Sampled: true}, nil
+}
+
+type recordingMetadata struct{ *detailsRecorder }
+
+func (f recordingMetadata) Execute(_ context.Context, in usecase.LiveDetailsInput) (usecase.RefreshMetadataOutput, error) {
+	f.record("metadata", in)
+	return usecase.RefreshMetadataOutput{}, nil
+}
+
+type recordingEnd struct{ *detailsRecorder }
+
+func (f recordingEnd) Execute(_ context.Context, in usecase.LiveDetailsInput) (usecase.CheckStreamEndOutput, error) {
+	f.record("end", in)
+	return usecase.CheckStreamEndOutput{}, nil
+}
+
+// TestMonitor_LiveChecksShareOneDetailsFetch: 期限の来た確認は 1 回の取得を共有し、サンプリングだけなら SampleYT で取得する。
+func TestMonitor_LiveChecksShareOneDetailsFetch(t *testing.T) {
+	now := time.Now()
+	state := memory.NewStateRepo()
+	_ = state.Set(context.Background(), domain.LiveState{Status: domain.StatusActive, VideoID: "v", AutonomousMonitoring: true})
+	yt := &fakeYT{details: port.VideoLiveDetails{LiveChatID: "chat", ConcurrentViewers: 42}}
+	sampleYT := &fakeYT{}
+	rec := &detailsRecorder{due: true}
+	m := buildMonitor(&fakeSwitcher{}, &fakePuller{}, yt, state, make(chan time.Time))
+	m.Clock = system.NewManualClock(now)
+	m.SampleYT = sampleYT
+	m.RefreshChat = recordingChat{rec}
+	m.SampleViewers = recordingSampler{rec}
+	m.RefreshMetadata = recordingMetadata{rec}
+	m.CheckEnd = recordingEnd{rec}
+	// runOnce は起動直後の 1 回だけ実行させる
+	runOnce := func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			m.Run(ctx)
+		}()
+		cancel()
+		<-done
+	}
+
+	// 4 つとも期限切れ → 1 回の取得を共有する
+	runOnce()
+	if yt.calls.Load() != 1 || sampleYT.calls.Load() != 0 {
+		t.Errorf("details calls = %d (sample-only %d), want 1 shared fetch", yt.calls.Load(), sampleYT.calls.Load())
+	}
+	for _, name := range []string{"chat", "sample", "metadata", "end"} {
+		in, ok := rec.inputs[name]
+		if !ok || in.VideoID != "v" || in.Details == nil || in.Details.ConcurrentViewers != 42 {
+			t.Errorf("%s input = %+v (called=%v), want shared details of v", name, in, ok)
+		}
+	}
+
+	// 他の確認の間隔内でサンプリングだけ期限切れ → 低優先度の fetcher で取得する
+	runOnce()
+	if yt.calls.Load() != 1 || sampleYT.calls.Load() != 1 {
+		t.Errorf("details calls = %d (sample-only %d), want one sample-only fetch via SampleYT", yt.calls.Load(), sampleYT.calls.Load())
+	}
+
+	// 期限の来た確認が無ければ取得しない
+	rec.due = false
+	runOnce()
+	if yt.calls.Load() != 1 || sampleYT.calls.Load() != 1 {
+		t.Errorf("details calls = %d (sample-only %d), want no fetch when nothing is due", yt.calls.Load(), sampleYT.calls.Load())
+	}
+}
+
+// fakeBreaker は固定の circuit breaker 状態を返す fake。
+type fakeBreaker struct{ st domain.BreakerStatus }
+
+func (f fakeBreaker) BreakerStatus() domain.BreakerStatus { return f.st }
+
+// TestMonitor_BreakerOpen_SkipsUntilRetry: circuit breaker が open の間は Pull / SwitchVideo を呼ばない。
+func TestMonitor_BreakerOpen_SkipsUntilRetry(t *testing.T) {
+	now := time.Now()
+	state := memory.NewStateRepo()
+	_ = state.Set(context.Background(), domain.LiveState{Status: domain.StatusActive, VideoID: "v", AutonomousMonitoring: true})
+	pl := &fakePuller{}
+	tickC := make(chan time.Time, 1)
+	m := buildMonitor(&fakeSwitcher{}, pl, nil, state, tickC)
+	m.Clock = system.NewManualClock(now)
+	retryAt := now.Add(time.Hour)
+	m.Breaker = fakeBreaker{st: domain.BreakerStatus{State: domain.BreakerOpen, Reason: domain.ErrCodeQuotaExceeded, RetryAt: &retryAt}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.Run(ctx)
+	}()
+	tickC <- now
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	<-done
+
+	if pl.calls.Load() != 0 {
+		t.Errorf("Pull calls = %d, want 0 while the breaker is open", pl.calls.Load())
+	}
+}
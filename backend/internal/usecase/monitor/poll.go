@@ -0,0 +1,110 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+)
+
+const (
+	// DefaultPollMin は quota を守れる最小間隔 (usecase.MinPollInterval) です。
+	DefaultPollMin = usecase.MinPollInterval
+	DefaultPollMax = 120 * time.Second
+	// DefaultTargetMessages は 1 回の Pull で取得したいメッセージ数の目安です。
+	// 直近のチャット速度からこの件数が溜まる時間を次の間隔にします。
+	DefaultTargetMessages = 200
+	// DefaultQuotaPaceBelow は quota 残量がこの割合を下回ったら、リセットまで持つよう間隔を延ばす閾値です。
+	DefaultQuotaPaceBelow = 0.25
+
+	// velocityAlpha はチャット速度の指数移動平均の重みです。
+	velocityAlpha = 0.5
+)
+
+// PollPolicy は Pull の結果から次の Pull までの待ち時間を決める。
+// YouTube の pollingIntervalMillis を下限として尊重し、チャット速度が速いほど短く、
+// 静かなほど長く、quota が残り少ないほど長くする。結果は [Min, Max] に収めるが、
+// quota のペース配分が求める間隔は Max より長くても優先する (リセットまで予算を持たせるため)。
+// Monitor の goroutine からのみ呼ばれる前提で、排他はしない。
+type PollPolicy struct {
+	Min            time.Duration // 0 なら DefaultPollMin
+	Max            time.Duration // 0 なら DefaultPollMax
+	TargetMessages float64       // 0 なら DefaultTargetMessages
+	QuotaPaceBelow float64       // 0 なら DefaultQuotaPaceBelow
+
+	velocity float64 // messages/sec (EWMA)
+	lastAt   time.Time
+}
+
+// Next は now 時点の Pull 結果 out と quota 消費状況 (nil 可) から次の待ち時間を返す。
+func (p *PollPolicy) Next(now time.Time, out usecase.PullOutput, quota *domain.QuotaUsage) time.Duration {
+	minD, maxD := p.bounds()
+	p.observe(now, out.AddedCount)
+
+	next := maxD
+	switch {
+	case out.PageFull:
+		// バックログが残っているので最短で取りに行く
+		next = minD
+	case p.velocity > 0:
+		target := p.TargetMessages
+		if target <= 0 {
+			target = DefaultTargetMessages
+		}
+		next = time.Duration(target / p.velocity * float64(time.Second))
+	}
+
+	// YouTube が指定する最小間隔より短くはしない
+	if server := time.Duration(out.ServerPollingIntervalMillis) * time.Millisecond; next < server {
+		next = server
+	}
+
+	return max(min(max(next, minD), maxD), p.quotaPace(now, quota, out.ChatsPerPull))
+}
+
+// observe は前回 Pull からの新規メッセージ数でチャット速度を更新する。
+func (p *PollPolicy) observe(now time.Time, added int) {
+	if !p.lastAt.IsZero() {
+		if elapsed := now.Sub(p.lastAt).Seconds(); elapsed > 0 {
+			rate := float64(added) / elapsed
+			p.velocity = velocityAlpha*rate + (1-velocityAlpha)*p.velocity
+		}
+	}
+	p.lastAt = now
+}
+
+// quotaPace は残量が QuotaPaceBelow を下回ったとき、次のリセットまで Pull を均等に呼べる間隔を返す。余裕があれば 0。
+// 1 回の Pull はチャットごとに liveChatMessages.list を呼ぶため、chats (0 なら 1) 倍の quota を消費する。
+func (p *PollPolicy) quotaPace(now time.Time, quota *domain.QuotaUsage, chats int) time.Duration {
+	if quota == nil || quota.Budget <= 0 {
+		return 0
+	}
+	below := p.QuotaPaceBelow
+	if below <= 0 {
+		below = DefaultQuotaPaceBelow
+	}
+	if float64(quota.Budget-quota.Used) >= float64(quota.Budget)*below {
+		return 0
+	}
+	return quota.PacedInterval(now, 1, domain.QuotaCost["liveChatMessages.list"]*max(chats, 1))
+}
+
+// Reset はチャット速度の履歴を捨てる (配信切替時など)。
+func (p *PollPolicy) Reset() {
+	p.velocity = 0
+	p.lastAt = time.Time{}
+}
+
+func (p *PollPolicy) bounds() (time.Duration, time.Duration) {
+	minD, maxD := p.Min, p.Max
+	if minD <= 0 {
+		minD = DefaultPollMin
+	}
+	if maxD <= 0 {
+		maxD = DefaultPollMax
+	}
+	if maxD < minD {
+		maxD = minD
+	}
+	return minD, maxD
+}
@@ -0,0 +1,85 @@
+package monitor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/monitor"
+)
+
+var pollBase = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestPollPolicy_PageFullPullsAtMin(t *testing.T) {
+	p := &monitor.PollPolicy{}
+	got := p.Next(pollBase, usecase.PullOutput{AddedCount: 2000, PageFull: true}, nil)
+	if got != monitor.DefaultPollMin {
+		t.Errorf("Next = %v, want %v", got, monitor.DefaultPollMin)
+	}
+}
+
+func TestPollPolicy_QuietStreamBacksOffToMax(t *testing.T) {
+	p := &monitor.PollPolicy{}
+	p.Next(pollBase, usecase.PullOutput{}, nil)
+	got := p.Next(pollBase.Add(time.Minute), usecase.PullOutput{AddedCount: 0}, nil)
+	if got != monitor.DefaultPollMax {
+		t.Errorf("Next = %v, want %v", got, monitor.DefaultPollMax)
+	}
+}
+
+func TestPollPolicy_VelocityAndServerFloor(t *testing.T) {
+	p := &monitor.PollPolicy{Min: time.Second, Max: 10 * time.Minute, TargetMessages: 100}
+	p.Next(pollBase, usecase.PullOutput{}, nil)
+
+	// 60s で 600 件 → EWMA 5 msg/s → 100 件溜まるのは 20s
+	got := p.Next(pollBase.Add(time.Minute), usecase.PullOutput{AddedCount: 600}, nil)
+	if got != 20*time.Second {
+		t.Errorf("Next = %v, want 20s", got)
+	}
+
+	// サーバー指定の pollingIntervalMillis より短くはしない
+	got = p.Next(pollBase.Add(80*time.Second), usecase.PullOutput{AddedCount: 100, ServerPollingIntervalMillis: 45000}, nil)
+	if got != 45*time.Second {
+		t.Errorf("Next = %v, want server floor 45s", got)
+	}
+}
+
+func TestPollPolicy_PacesWhenQuotaLow(t *testing.T) {
+	p := &monitor.PollPolicy{}
+	quota := &domain.QuotaUsage{
+		Budget:  10000,
+		Used:    9900, // 残り 100 units = 20 回分
+		ResetAt: pollBase.Add(time.Hour),
+	}
+	got := p.Next(pollBase, usecase.PullOutput{PageFull: true}, quota)
+	if got != 3*time.Minute {
+		t.Errorf("Next = %v, want 3m (1h / 20 calls, beyond Max so the budget lasts until reset)", got)
+	}
+
+	// イベント配信を 3 本取り込んでいれば 1 回の Pull は 4 チャット分 (20 units) → 1h / 5 calls = 12m
+	got = p.Next(pollBase, usecase.PullOutput{PageFull: true, ChatsPerPull: 4}, quota)
+	if got != 12*time.Minute {
+		t.Errorf("Next = %v, want 12m with 4 chats per pull", got)
+	}
+
+	quota.Used = 8000 // 残り 2000 units = 400 回 → 9s 間隔 (下限の方が長い)
+	got = p.Next(pollBase, usecase.PullOutput{PageFull: true}, quota)
+	if got != monitor.DefaultPollMin {
+		t.Errorf("Next = %v, want %v", got, monitor.DefaultPollMin)
+	}
+
+	// 下限を 1s にすれば 9s のペースが効く
+	p.Min = time.Second
+	got = p.Next(pollBase, usecase.PullOutput{PageFull: true}, quota)
+	if got != 9*time.Second {
+		t.Errorf("Next = %v, want 9s", got)
+	}
+	p.Min = 0
+
+	quota.Used = 1000 // 余裕あり → ペース制限なし
+	got = p.Next(pollBase, usecase.PullOutput{PageFull: true}, quota)
+	if got != monitor.DefaultPollMin {
+		t.Errorf("Next = %v, want %v", got, monitor.DefaultPollMin)
+	}
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+type PauseOutput struct {
+	State domain.LiveState
+}
+
+// Pause は ACTIVE の配信の Pull を一時停止する (PAUSED)。users / comments / NextPageToken は保持する。
+type Pause struct {
+	State port.StateRepo
+	Clock port.Clock
+	Snap  snapshot.Coordinator
+}
+
+// Execute: ACTIVE → PAUSED。それ以外の状態からは conflict。
+func (uc *Pause) Execute(ctx context.Context) (PauseOutput, error) {
+	st, err := updateState(ctx, uc.State, func(cur domain.LiveState) (domain.LiveState, error) {
+		if cur.Status == domain.StatusPaused {
+			return cur, &domain.APIError{Code: domain.ErrCodeConflict, Message: "stream is already paused"}
+		}
+		next := cur
+		next.Status = domain.StatusPaused
+		return cur.Transition(next, uc.Clock.Now(), "pause")
+	})
+	if err != nil {
+		return PauseOutput{}, err
+	}
+
+	uc.Snap.MarkDirty()
+	if err := uc.Snap.Flush(ctx); err != nil {
+		logging.Log(ctx, "warn", "SNAPSHOT", "pause: snapshot flush failed: %v", err)
+	}
+	return PauseOutput{State: st}, nil
+}
+
+type ResumeOutput struct {
+	State domain.LiveState
+}
+
+// Resume は PAUSED の配信を ACTIVE に戻し、保持していた NextPageToken から Pull を再開する。
+type Resume struct {
+	State port.StateRepo
+	Clock port.Clock
+	Snap  snapshot.Coordinator
+}
+
+// Execute: PAUSED → ACTIVE。それ以外の状態からは conflict。
+func (uc *Resume) Execute(ctx context.Context) (ResumeOutput, error) {
+	st, err := updateState(ctx, uc.State, func(cur domain.LiveState) (domain.LiveState, error) {
+		if cur.Status != domain.StatusPaused {
+			return cur, &domain.APIError{Code: domain.ErrCodeConflict, Message: "stream is not paused"}
+		}
+		next := cur
+		next.Status = domain.StatusActive
+		return cur.Transition(next, uc.Clock.Now(), "resume")
+	})
+	if err != nil {
+		return ResumeOutput{}, err
+	}
+
+	uc.Snap.MarkDirty()
+	if err := uc.Snap.Flush(ctx); err != nil {
+		logging.Log(ctx, "warn", "SNAPSHOT", "resume: snapshot flush failed: %v", err)
+	}
+	return ResumeOutput{State: st}, nil
+}
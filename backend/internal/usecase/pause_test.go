@@ -0,0 +1,94 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+func TestPauseResume_SuspendsPullAndKeepsToken(t *testing.T) {
+	ctx := context.Background()
+	clock := system.NewManualClock(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC))
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v1", LiveChatID: "chat", NextPageToken: "tok-5"})
+	yt := &fakeYT{next: "nxt", pollMs: 1500}
+	pull := &usecase.Pull{YT: yt, Users: memory.NewUserRepo(), Comments: memory.NewCommentRepo(), State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
+	pause := &usecase.Pause{State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
+	resume := &usecase.Resume{State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
+
+	if out, err := pause.Execute(ctx); err != nil || out.State.Status != domain.StatusPaused {
+		t.Fatalf("Pause = %+v, %v", out, err)
+	}
+	if out, err := pull.Execute(ctx); err != nil || out.AddedCount != 0 {
+		t.Errorf("Pull while paused = %+v, %v, want no-op", out, err)
+	}
+	st, _ := state.Get(ctx)
+	if st.NextPageToken != "tok-5" {
+		t.Errorf("NextPageToken = %q, want tok-5 (kept while paused)", st.NextPageToken)
+	}
+
+	clock.Advance(time.Minute)
+	out, err := resume.Execute(ctx)
+	if err != nil || out.State.Status != domain.StatusActive || out.State.NextPageToken != "tok-5" {
+		t.Fatalf("Resume = %+v, %v", out, err)
+	}
+	if n := len(out.State.Transitions); n != 2 || out.State.Transitions[1].Reason != "resume" {
+		t.Errorf("Transitions = %+v, want pause then resume", out.State.Transitions)
+	}
+}
+
+func TestPauseResume_RejectInvalidTransitions(t *testing.T) {
+	ctx := context.Background()
+	clock := system.NewManualClock(time.Time{})
+	for _, tc := range []struct {
+		name string
+		from domain.Status
+		run  func(s *memory.StateRepo) error
+	}{
+		{"WAITING は pause できない", domain.StatusWaiting, func(s *memory.StateRepo) error {
+			_, err := (&usecase.Pause{State: s, Clock: clock, Snap: &snapshot.NopCoordinator{}}).Execute(ctx)
+			return err
+		}},
+		{"ENDED は pause できない", domain.StatusEnded, func(s *memory.StateRepo) error {
+			_, err := (&usecase.Pause{State: s, Clock: clock, Snap: &snapshot.NopCoordinator{}}).Execute(ctx)
+			return err
+		}},
+		{"ACTIVE は resume できない", domain.StatusActive, func(s *memory.StateRepo) error {
+			_, err := (&usecase.Resume{State: s, Clock: clock, Snap: &snapshot.NopCoordinator{}}).Execute(ctx)
+			return err
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := memory.NewStateRepo()
+			_ = state.Set(ctx, domain.LiveState{Status: tc.from})
+			err := tc.run(state)
+			var apiErr *domain.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeConflict {
+				t.Errorf("err = %v, want conflict", err)
+			}
+			if st, _ := state.Get(ctx); st.Status != tc.from {
+				t.Errorf("Status = %s, want unchanged %s", st.Status, tc.from)
+			}
+		})
+	}
+}
+
+func TestReserve_RejectsWhilePaused(t *testing.T) {
+	ctx := context.Background()
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusPaused, VideoID: "v1"})
+	uc := &usecase.Reserve{YT: &fakeYT{}, State: state, Clock: system.NewManualClock(time.Time{}), Snap: &snapshot.NopCoordinator{}}
+
+	_, err := uc.Execute(ctx, usecase.ReserveInput{VideoID: "v2"})
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeConflict {
+		t.Errorf("err = %v, want conflict (paused session must be reset first)", err)
+	}
+}
@@ -3,19 +3,37 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/metrics"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/analytics"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
 )
 
+// MinPollInterval は quota を守れる Pull の最小間隔です (monitor の既定の下限とフロントエンド向けの間隔)。
+// liveChatMessages.list は 1 回 5 units のため、60 秒間隔なら 24 時間連続でも約 1,440 回 (7,200 units) で
+// 既定の 10,000 units/day に収まり、他 API 呼び出し分の余裕も残る。
+const MinPollInterval = 60 * time.Second
+
 type PullOutput struct {
 	AddedCount            int
 	SkippedCount          int
 	AutoReset             bool
-	PollingIntervalMillis int64
+	PollingIntervalMillis int64 // フロントエンド向け (quota 保護のため MinPollInterval 以上に切り上げ)
+
+	// ServerPollingIntervalMillis は YouTube が返した pollingIntervalMillis そのもの (monitor の間隔調整用)
+	ServerPollingIntervalMillis int64
+	// PageFull は取得ページが port.LiveChatMaxResults 件に達した (バックログが残っている) ことを示す
+	PageFull bool
+	// PagesDrained は今回の Pull で取得したページ数 (追いつきモードで 2 以上になる)
+	PagesDrained int
+	// ChatsPerPull は次の Pull で liveChatMessages.list を呼ぶチャット数 (主配信 + 終了していないイベント配信)
+	ChatsPerPull int
 }
 
 type Pull struct {
@@ -25,9 +43,39 @@ type Pull struct {
 	State    port.StateRepo
 	Clock    port.Clock
 	Snap     snapshot.Coordinator // 必須 (GCS 不要な場合は NopCoordinator を渡す)
+
+	Reactions port.ReactionRepo  // 任意: nil の場合はリアクション集計を行わない
+	Lexicon   *analytics.Lexicon // 任意: nil の場合は analytics.DefaultLexicon を使う
+
+	// Resolver は任意: 設定時はチャンネル名・ハンドルを inline で API 解決せず、
+	// キャッシュ済みの値だけを使って未解決分を非同期解決 (後から backfill) に回す
+	Resolver ChannelResolveQueue
+
+	// Lock は任意: 設定時はページ保存を Reset / SwitchVideo の repo の置き換えと排他する (同じセッションで共有)
+	Lock *StoreLock
+
+	// MaxDrainPages は 1 回の Pull で辿る最大ページ数。0 / 1 なら従来どおり 1 ページのみ取得する。
+	// 再起動・GCS 復元・コメント急増でバックログが溜まったとき、満杯のページが続く間は
+	// nextPageToken を辿って追いつく (token の失効前に消化するため)
+	MaxDrainPages int
+	// Quota は任意: 設定時は quota 残量が DrainQuotaReserve を下回ったら追いつきを止める
+	Quota port.QuotaReporter
+	// DrainQuotaReserve は追いつきを続けるのに必要な quota 残量の割合。0 なら DefaultDrainQuotaReserve
+	DrainQuotaReserve float64
 }
 
-// Execute: コメント取得・ユーザー追加、終了検知→WAITING へ（autoReset）。
+// DefaultDrainQuotaReserve は追いつき (2 ページ目以降の取得) を止める quota 残量の割合です。
+const DefaultDrainQuotaReserve = 0.25
+
+// ChannelResolveQueue はチャンネル名・ハンドル解決の非同期キューです (resolver.Resolver)。
+type ChannelResolveQueue interface {
+	// Lookup はキャッシュ済みの情報を返し、未解決の channelID をキューに積みます。API 呼び出しは行いません。
+	// nameIDs は表示名が @handle で届いたため名前も解決したい channelID です。
+	Lookup(channelIDs, nameIDs []string) map[string]domain.ChannelInfo
+}
+
+// Execute: コメント取得・ユーザー追加、終了検知→ENDED へ（autoReset）。
+// MaxDrainPages > 1 の場合、ページが満杯 (バックログあり) の間は nextPageToken を辿って追いつく。
 func (uc *Pull) Execute(ctx context.Context) (PullOutput, error) {
 	// 現在の状態を取得
 	state, err := uc.State.Get(ctx)
@@ -40,35 +88,234 @@ func (uc *Pull) Execute(ctx context.Context) (PullOutput, error) {
 		return PullOutput{AddedCount: 0, AutoReset: false}, nil
 	}
 
-	// YouTube APIからメッセージを取得（ページトークン対応）
-	items, nextToken, pollMs, skippedCount, isEnded, err := uc.YT.ListLiveChatMessages(ctx, state.LiveChatID, state.NextPageToken)
+	var out PullOutput
+	var pollMs int64
+	for {
+		// YouTube APIからメッセージを取得（ページトークン対応）
+		items, nextToken, pageMs, skippedCount, isEnded, err := uc.YT.ListLiveChatMessages(ctx, state.LiveChatID, state.NextPageToken)
+		if err != nil {
+			if out.PagesDrained > 0 {
+				// 追いつき途中の失敗: 取得済みページの結果は保存済みなので、ここまでで打ち切る
+				logging.Log(ctx, "warn", "PULL", "drain stopped after %d pages: %v", out.PagesDrained, err)
+				break
+			}
+			return PullOutput{}, fmt.Errorf("list_messages: %w", err)
+		}
+
+		// 配信終了検知
+		if isEnded {
+			// 配信の再開で activeLiveChatId が変わっただけなら、終了にせず新しいチャットから続けて取得する
+			if next, ok := uc.rotateChat(ctx, state); ok {
+				state = next
+				continue
+			}
+			return uc.endStream(ctx, state, out)
+		}
+
+		added, err := uc.storeCurrent(ctx, state, items, state.VideoID)
+		if err != nil {
+			return PullOutput{}, err
+		}
+		metrics.PullMessages.Add(float64(added), "added")
+		metrics.PullMessages.Add(float64(skippedCount), "skipped")
+
+		// 最終取得日時と次ページトークンを更新 (ページごとに保存し、途中で失敗しても進捗を失わない)。
+		// 取得中に Reset / 切替などで State が変わっていたら、古い State で上書きせず競合として返す
+		state.LastPulledAt = uc.Clock.Now()
+		state.NextPageToken = nextToken
+		if state, err = uc.State.CompareAndSet(ctx, state); err != nil {
+			return PullOutput{}, fmt.Errorf("state_set: %w", err)
+		}
+
+		// 差分あり（新規ユーザー追加 or コメント追加）の場合にスナップショット dirty フラグを立てる
+		if added > 0 || len(items) > 0 {
+			uc.Snap.MarkDirty()
+		}
+
+		out.AddedCount += added
+		out.SkippedCount += skippedCount
+		out.PagesDrained++
+		out.PageFull = len(items)+skippedCount >= port.LiveChatMaxResults
+		pollMs = pageMs
+
+		if !out.PageFull || nextToken == "" || !uc.canDrain(out.PagesDrained) || ctx.Err() != nil {
+			break
+		}
+	}
+	if out.PagesDrained > 1 {
+		logging.Log(ctx, "info", "PULL", "drained %d pages (added=%d pageFull=%v)", out.PagesDrained, out.AddedCount, out.PageFull)
+	}
+
+	// イベントに追加した配信のチャットも同じ User / Comment に取り込む
+	added, skipped, openStreams, err := uc.pullEventStreams(ctx, state)
 	if err != nil {
-		return PullOutput{}, fmt.Errorf("list_messages: %w", err)
+		return PullOutput{}, err
 	}
+	out.AddedCount += added
+	out.SkippedCount += skipped
+	out.ChatsPerPull = 1 + openStreams
 
-	// 配信終了検知
-	if isEnded {
-		// 配信中の users/comments はメモリに保持したまま snapshot へ永続化する
-		// （同じ videoId で再度「切替」を押したら復元できるようにするため）
-		uc.Snap.MarkDirty()
-		if err := uc.Snap.Flush(ctx); err != nil {
-			logging.Log(ctx, "warn", "SNAPSHOT", "pull: snapshot flush on stream end failed: %v", err)
-			// Flush 失敗は警告のみ、終了処理は継続
-		}
-
-		// WAITINGに戻す（現在時刻を終了時刻として設定）
-		// Users / Comments は意図的にクリアしない
-		state.Status = domain.StatusWaiting
-		state.EndedAt = uc.Clock.Now()
-		state.NextPageToken = ""
-		state.AutonomousMonitoring = false // monitor の Pull tick を停止
-		if err := uc.State.Set(ctx, state); err != nil {
-			return PullOutput{}, fmt.Errorf("state_set: %w", err)
+	out.ServerPollingIntervalMillis = pollMs
+	out.PollingIntervalMillis = max(pollMs, MinPollInterval.Milliseconds())
+
+	return out, nil
+}
+
+// canDrain は pages ページ取得済みの状態で、追いつきのために次のページを取得してよいかを返す。
+func (uc *Pull) canDrain(pages int) bool {
+	if pages >= uc.MaxDrainPages {
+		return false
+	}
+	if uc.Quota == nil {
+		return true
+	}
+	usage := uc.Quota.Usage()
+	if usage.Budget <= 0 {
+		return true
+	}
+	reserve := uc.DrainQuotaReserve
+	if reserve <= 0 {
+		reserve = DefaultDrainQuotaReserve
+	}
+	remaining := usage.Budget - usage.Used - domain.QuotaCost["liveChatMessages.list"]
+	return float64(remaining) >= float64(usage.Budget)*reserve
+}
+
+// rotateChat はチャット終了を受けて動画の activeLiveChatId を確認し、新しいチャットに切り替えたら (新しい State, true) を返す。
+func (uc *Pull) rotateChat(ctx context.Context, state domain.LiveState) (domain.LiveState, bool) {
+	details, err := uc.YT.GetVideoLiveDetails(ctx, state.VideoID)
+	if err != nil {
+		logging.Log(ctx, "warn", "PULL", "check for restarted live chat failed: %v", err)
+		return state, false
+	}
+	out, err := (&RefreshLiveChat{YT: uc.YT, State: uc.State, Snap: uc.Snap}).rotate(ctx, state, details)
+	if err != nil {
+		logging.Log(ctx, "warn", "PULL", "switch to restarted live chat failed: %v", err)
+		return state, false
+	}
+	return out.State, out.Changed
+}
+
+// endStream は配信終了を検知したときに snapshot を永続化して ENDED へ遷移する (コメントは閲覧用に残す)。
+// out はそれまでに取得したページの集計。
+func (uc *Pull) endStream(ctx context.Context, state domain.LiveState, out PullOutput) (PullOutput, error) {
+	// 配信中の users/comments はメモリに保持したまま snapshot へ永続化する
+	// （同じ videoId で再度「切替」を押したら復元できるようにするため）
+	uc.Snap.MarkDirty()
+	if err := uc.Snap.Flush(ctx); err != nil {
+		logging.Log(ctx, "warn", "SNAPSHOT", "pull: snapshot flush on stream end failed: %v", err)
+		// Flush 失敗は警告のみ、終了処理は継続
+	}
+
+	// ENDED に遷移（現在時刻を終了時刻として設定）
+	// Users / Comments は意図的にクリアしない (ENDED は読み取り専用で閲覧できる)
+	now := uc.Clock.Now()
+	ended := state
+	ended.Status = domain.StatusEnded
+	ended.EndedAt = now
+	ended.NextPageToken = ""
+	ended.AutonomousMonitoring = false // monitor の Pull tick を停止
+	ended, err := state.Transition(ended, now, "stream_ended")
+	if err != nil {
+		return PullOutput{}, err
+	}
+	if _, err := uc.State.CompareAndSet(ctx, ended); err != nil {
+		return PullOutput{}, fmt.Errorf("state_set: %w", err)
+	}
+
+	return PullOutput{
+		AddedCount:   out.AddedCount,
+		SkippedCount: out.SkippedCount,
+		AutoReset:    true,
+		PagesDrained: out.PagesDrained,
+	}, nil
+}
+
+// pullEventStreams はイベントに追加した配信のチャットを 1 ページずつ取得し、主配信と同じ User / Comment に取り込む。
+// 追加配信の取得失敗は警告のみとし (主配信の Pull は成功させる)、チャットが終了した配信は Ended にする。
+// openStreams は取得後も終了していない追加配信の数です。
+func (uc *Pull) pullEventStreams(ctx context.Context, state domain.LiveState) (added, skipped, openStreams int, err error) {
+	if len(state.EventStreams) == 0 {
+		return 0, 0, 0, nil
+	}
+	next := state
+	next.EventStreams = slices.Clone(state.EventStreams)
+	changed, received := false, 0
+	for i, es := range next.EventStreams {
+		if es.Ended || ctx.Err() != nil {
+			continue
+		}
+		items, nextToken, _, skippedCount, isEnded, err := uc.YT.ListLiveChatMessages(ctx, es.LiveChatID, es.NextPageToken)
+		if err != nil {
+			logging.Log(ctx, "warn", "PULL", "event stream %s: list_messages failed: %v", es.VideoID, err)
+			continue
 		}
+		changed = true
+		if isEnded {
+			logging.Log(ctx, "info", "PULL", "event stream %s ended", es.VideoID)
+			next.EventStreams[i].Ended = true
+			next.EventStreams[i].NextPageToken = ""
+			continue
+		}
+		n, err := uc.storeCurrent(ctx, state, items, es.VideoID)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		metrics.PullMessages.Add(float64(n), "added")
+		metrics.PullMessages.Add(float64(skippedCount), "skipped")
+		next.EventStreams[i].NextPageToken = nextToken
+		added += n
+		skipped += skippedCount
+		received += len(items)
+	}
+	for _, es := range next.EventStreams {
+		if !es.Ended {
+			openStreams++
+		}
+	}
+	if !changed {
+		return added, skipped, openStreams, nil
+	}
+	if _, err := uc.State.CompareAndSet(ctx, next); err != nil {
+		return 0, 0, 0, fmt.Errorf("state_set: %w", err)
+	}
+	if added > 0 || received > 0 {
+		uc.Snap.MarkDirty()
+	}
+	return added, skipped, openStreams, nil
+}
 
-		return PullOutput{AddedCount: 0, AutoReset: true, PollingIntervalMillis: 0}, nil
+// storeCurrent は State が state から変わっていなければページを保存します。
+// 取得中に Reset / 切替が走っていたら、クリア済みの repo に旧配信のコメントを書き戻さないよう保存せずに競合として返す。
+// 確認から保存までは Lock で repo の置き換えと排他する (確認直後にクリアされないように)。
+func (uc *Pull) storeCurrent(ctx context.Context, state domain.LiveState, items []port.ChatMessage, videoID string) (int, error) {
+	unlock := uc.Lock.acquire()
+	defer unlock()
+	if err := uc.ensureCurrent(ctx, state); err != nil {
+		return 0, err
 	}
+	return uc.storePage(ctx, items, videoID)
+}
 
+// ensureCurrent は State が state を読んだ時点から変わっていないことを確かめます。
+// 変わっていれば (Reset / SwitchVideo など) ErrCodeConflict を返し、呼び出し側はページを保存しません。
+func (uc *Pull) ensureCurrent(ctx context.Context, state domain.LiveState) error {
+	cur, err := uc.State.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("state_get: %w", err)
+	}
+	if cur.Version != state.Version || cur.VideoID != state.VideoID {
+		return &domain.APIError{
+			Code:    domain.ErrCodeConflict,
+			Message: fmt.Sprintf("live state changed during pull (version %d, now %d)", state.Version, cur.Version),
+		}
+	}
+	return nil
+}
+
+// storePage は 1 ページ分のメッセージの表示名を解決し、User / Comment に保存して新規件数を返す。
+// videoID はメッセージの投稿先の配信 (イベントでは主配信以外のこともある)。
+func (uc *Pull) storePage(ctx context.Context, items []port.ChatMessage, videoID string) (int, error) {
 	// チャンネルIDを収集（重複排除）
 	seen := make(map[string]bool)
 	var allChannelIDs []string
@@ -83,20 +330,30 @@ func (uc *Pull) Execute(ctx context.Context) (PullOutput, error) {
 		}
 	}
 	var channelNames map[string]string
-	if len(handleChannelIDs) > 0 {
-		var err error
-		channelNames, err = uc.YT.GetChannelDisplayNames(ctx, handleChannelIDs)
-		if err != nil {
-			logging.Log(ctx, "warn", "PULL", "Failed to resolve channel display names: %v", err)
-		}
-	}
-	// 全チャンネルのハンドルを取得（GetChannelDisplayNames のキャッシュを活用）
 	var channelHandles map[string]string
-	if len(allChannelIDs) > 0 {
-		var err error
-		channelHandles, err = uc.YT.GetChannelHandles(ctx, allChannelIDs)
-		if err != nil {
-			logging.Log(ctx, "warn", "PULL", "Failed to resolve channel handles: %v", err)
+	if uc.Resolver != nil {
+		// 非同期解決: 既知の値だけ使い、未解決分は resolver が後から User / Comment を backfill する
+		channelNames = make(map[string]string)
+		channelHandles = make(map[string]string)
+		for id, info := range uc.Resolver.Lookup(allChannelIDs, handleChannelIDs) {
+			channelNames[id] = info.Title
+			channelHandles[id] = info.Handle
+		}
+	} else {
+		if len(handleChannelIDs) > 0 {
+			var err error
+			channelNames, err = uc.YT.GetChannelDisplayNames(ctx, handleChannelIDs)
+			if err != nil {
+				logging.Log(ctx, "warn", "PULL", "Failed to resolve channel display names: %v", err)
+			}
+		}
+		// 全チャンネルのハンドルを取得（GetChannelDisplayNames のキャッシュを活用）
+		if len(allChannelIDs) > 0 {
+			var err error
+			channelHandles, err = uc.YT.GetChannelHandles(ctx, allChannelIDs)
+			if err != nil {
+				logging.Log(ctx, "warn", "PULL", "Failed to resolve channel handles: %v", err)
+			}
 		}
 	}
 
@@ -113,24 +370,38 @@ func (uc *Pull) Execute(ctx context.Context) (PullOutput, error) {
 
 	// ユーザー追加 - メッセージIDによる重複チェックを使用
 	addedCount := 0
-	now := uc.Clock.Now()
+	lexicon := uc.Lexicon
+	if uc.Reactions != nil && lexicon == nil {
+		lexicon = analytics.DefaultLexicon()
+	}
+	streams, _ := uc.Users.(port.UserStreamRecorder)
+	profiles, _ := uc.Users.(port.UserProfileUpdater)
 	for _, msg := range items {
 		// UpsertWithMessageUpdatedを使用してメッセージIDによる重複チェックを実行し、実際に更新された場合のみカウント
 		updated, err := uc.Users.UpsertWithMessageUpdated(msg.ChannelID, msg.DisplayName, msg.PublishedAt, msg.ID)
 		if err != nil {
-			return PullOutput{}, fmt.Errorf("user_upsert: %w", err)
+			return 0, fmt.Errorf("user_upsert: %w", err)
+		}
+		if streams != nil && videoID != "" {
+			streams.RecordStream(msg.ChannelID, videoID)
+		}
+		// @プレフィックスの正規化: CustomUrl は通常 @username 形式だが、
+		// 付いていない場合は付けて出力する。空の場合はそのまま空文字。
+		handle := domain.NormalizeHandle(channelHandles[msg.ChannelID])
+		// 解決済み (キャッシュヒット含む) のハンドルはユーザーにも反映する。
+		// resolver はキャッシュヒット分を backfill しないため、ここで設定しないと User.Handle が空のまま残る
+		if profiles != nil && handle != "" {
+			profiles.UpdateProfile(msg.ChannelID, "", handle)
 		}
 		if updated {
 			addedCount++
+			// 重複メッセージ (再取得分) を二重に数えないよう、新規メッセージのみ集計する
+			if uc.Reactions != nil {
+				uc.Reactions.Record(msg.PublishedAt, lexicon.Classify(msg.Message))
+			}
 		}
 
 		// コメント保存
-		handle := channelHandles[msg.ChannelID]
-		// @プレフィックスの正規化: CustomUrl は通常 @username 形式だが、
-		// 付いていない場合は付けて出力する。空の場合はそのまま空文字。
-		if handle != "" && !strings.HasPrefix(handle, "@") {
-			handle = "@" + handle
-		}
 		if err := uc.Comments.Add(domain.Comment{
 			ID:          msg.ID,
 			ChannelID:   msg.ChannelID,
@@ -138,29 +409,11 @@ func (uc *Pull) Execute(ctx context.Context) (PullOutput, error) {
 			Handle:      handle,
 			Message:     msg.Message,
 			PublishedAt: msg.PublishedAt,
+			VideoID:     videoID,
 		}); err != nil {
-			return PullOutput{}, fmt.Errorf("comment_add: %w", err)
+			return 0, fmt.Errorf("comment_add: %w", err)
 		}
 	}
 
-	// 最終取得日時と次ページトークンを更新
-	state.LastPulledAt = now
-	state.NextPageToken = nextToken
-	if err := uc.State.Set(ctx, state); err != nil {
-		return PullOutput{}, fmt.Errorf("state_set: %w", err)
-	}
-
-	// minPollingIntervalMillis はYouTube Data API v3の無料枠制限（10,000 units/day）を考慮した最小ポーリング間隔
-	// 1回のliveChatMessages.list呼び出しは5 unitsを消費するため、60秒間隔で運用することで24時間連続稼働でも約1,440回（7,200 units）に収まり、他 API 呼び出し分の余裕も確保する
-	const minPollingIntervalMillis = 60000
-	if pollMs < minPollingIntervalMillis {
-		pollMs = minPollingIntervalMillis
-	}
-
-	// 差分あり（新規ユーザー追加 or コメント追加）の場合にスナップショット dirty フラグを立てる
-	if addedCount > 0 || len(items) > 0 {
-		uc.Snap.MarkDirty()
-	}
-
-	return PullOutput{AddedCount: addedCount, SkippedCount: skippedCount, AutoReset: false, PollingIntervalMillis: pollMs}, nil
+	return addedCount, nil
 }
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/metrics"
+)
+
+// pullExecutor は Pull usecase の依存を抽象化する (test fake 注入用)。
+type pullExecutor interface {
+	Execute(ctx context.Context) (PullOutput, error)
+}
+
+// PullScheduler は Pull の実行を 1 本に直列化する single-flight coordinator です。
+// 手動 /pull と monitor の自動 Pull が同時に走ると、同じ NextPageToken で同じページを取得し
+// State.Set の競合で token が巻き戻るため、すべての Pull はこれを経由させる。
+// 実行中に届いた要求は新たに Pull せず、実行中の Pull の結果を共有する。
+type PullScheduler struct {
+	Pull pullExecutor
+
+	mu       sync.Mutex
+	inflight *pullCall
+	ctx      context.Context // 実行中の Pull の親 (Stop で cancel する)。初回の Execute で作る
+	cancel   context.CancelFunc
+}
+
+// pullCall は実行中の Pull 1 回分です。done が close されたら out / err が確定する。
+type pullCall struct {
+	done chan struct{}
+	out  PullOutput
+	err  error
+}
+
+// NewPullScheduler は pull を直列化する PullScheduler を返します。
+func NewPullScheduler(pull *Pull) *PullScheduler {
+	return &PullScheduler{Pull: pull}
+}
+
+// Execute は「今すぐ Pull」を要求します。Pull が実行中ならその完了を待って結果を共有し、
+// そうでなければ新たに Pull を実行します。
+// 実行中の Pull は最初の呼び出し元の ctx がキャンセルされても中断しない
+// (他の待機者と State の整合性のため)。待機者は自身の ctx のキャンセルで先に戻れる。
+// 実行中の Pull は scheduler の Stop (セッションの終了・shutdown) で中断する。
+func (s *PullScheduler) Execute(ctx context.Context) (PullOutput, error) {
+	s.mu.Lock()
+	call := s.inflight
+	leader := call == nil
+	var runCtx context.Context
+	var stop func()
+	if leader {
+		call = &pullCall{done: make(chan struct{})}
+		s.inflight = call
+		// 呼び出し元の値 (ログの collector など) は引き継ぎ、キャンセルは scheduler の ctx に従う
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithCancel(context.WithoutCancel(ctx))
+		stopAfter := context.AfterFunc(s.ownCtxLocked(), cancel)
+		stop = func() { stopAfter(); cancel() }
+	}
+	s.mu.Unlock()
+
+	if leader {
+		go s.run(runCtx, stop, call)
+	} else {
+		metrics.PullCoalesced.Inc()
+	}
+
+	select {
+	case <-call.done:
+		return call.out, call.err
+	case <-ctx.Done():
+		return PullOutput{}, ctx.Err()
+	}
+}
+
+// run は Pull を実行して結果を call に書き込み、inflight を空ける。
+func (s *PullScheduler) run(ctx context.Context, stop func(), call *pullCall) {
+	defer func() {
+		stop()
+		s.mu.Lock()
+		s.inflight = nil
+		s.mu.Unlock()
+		close(call.done)
+	}()
+	call.out, call.err = s.Pull.Execute(ctx)
+}
+
+// Stop は実行中の Pull を中断して終了を待ちます。以後の Execute の Pull はすぐにキャンセルされます。
+func (s *PullScheduler) Stop() {
+	s.mu.Lock()
+	s.ownCtxLocked()
+	s.cancel()
+	call := s.inflight
+	s.mu.Unlock()
+	if call != nil {
+		<-call.done
+	}
+}
+
+// ownCtxLocked は scheduler の ctx を返します (未作成なら作る)。s.mu を保持して呼ぶこと。
+func (s *PullScheduler) ownCtxLocked() context.Context {
+	if s.ctx == nil {
+		s.ctx, s.cancel = context.WithCancel(context.Background())
+	}
+	return s.ctx
+}
@@ -0,0 +1,140 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+)
+
+// blockingPull は release が close されるまで Execute をブロックする fake。
+type blockingPull struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingPull() *blockingPull {
+	return &blockingPull{started: make(chan struct{}, 16), release: make(chan struct{})}
+}
+
+func (b *blockingPull) Execute(ctx context.Context) (usecase.PullOutput, error) {
+	n := b.calls.Add(1)
+	b.started <- struct{}{}
+	<-b.release
+	return usecase.PullOutput{AddedCount: int(n)}, nil
+}
+
+func TestPullScheduler_CoalescesConcurrentCallers(t *testing.T) {
+	pull := newBlockingPull()
+	s := &usecase.PullScheduler{Pull: pull}
+
+	const callers = 5
+	results := make(chan usecase.PullOutput, callers)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		out, _ := s.Execute(context.Background())
+		results <- out
+	}()
+	<-pull.started // 1 本目が実行中になってから残りを投げる
+	for range callers - 1 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out, _ := s.Execute(context.Background())
+			results <- out
+		}()
+	}
+	time.Sleep(20 * time.Millisecond) // 待機者が inflight に合流するのを待つ
+	close(pull.release)
+	wg.Wait()
+	close(results)
+
+	if got := pull.calls.Load(); got != 1 {
+		t.Errorf("Pull.Execute calls = %d, want 1", got)
+	}
+	for out := range results {
+		if out.AddedCount != 1 {
+			t.Errorf("caller got %+v, want shared result of the single pull", out)
+		}
+	}
+
+	// 完了後の要求は新しい Pull を実行する
+	pull.release = make(chan struct{})
+	close(pull.release)
+	if out, _ := s.Execute(context.Background()); out.AddedCount != 2 || pull.calls.Load() != 2 {
+		t.Errorf("after completion: out=%+v calls=%d, want a fresh pull", out, pull.calls.Load())
+	}
+}
+
+func TestPullScheduler_LeaderCancelDoesNotAbortPull(t *testing.T) {
+	pull := newBlockingPull()
+	s := &usecase.PullScheduler{Pull: pull}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := s.Execute(ctx)
+		errc <- err
+	}()
+	<-pull.started
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled caller err = %v, want context.Canceled", err)
+	}
+
+	// 実行中の Pull は続いており、後続の呼び出しはその結果を受け取る
+	done := make(chan usecase.PullOutput, 1)
+	go func() {
+		out, _ := s.Execute(context.Background())
+		done <- out
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(pull.release)
+	if out := <-done; out.AddedCount != 1 || pull.calls.Load() != 1 {
+		t.Errorf("out=%+v calls=%d, want the in-flight pull's result", out, pull.calls.Load())
+	}
+}
+
+// ctxPull は ctx がキャンセルされるまで戻らない pullExecutor。
+type ctxPull struct {
+	started chan struct{}
+}
+
+func (p *ctxPull) Execute(ctx context.Context) (usecase.PullOutput, error) {
+	close(p.started)
+	<-ctx.Done()
+	return usecase.PullOutput{}, ctx.Err()
+}
+
+func TestPullScheduler_StopCancelsInflightPull(t *testing.T) {
+	pull := &ctxPull{started: make(chan struct{})}
+	s := &usecase.PullScheduler{Pull: pull}
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := s.Execute(context.Background())
+		errc <- err
+	}()
+	<-pull.started
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop did not cancel the in-flight pull")
+	}
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("caller err = %v, want context.Canceled from the stopped pull", err)
+	}
+}
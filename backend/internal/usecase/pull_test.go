@@ -2,77 +2,21 @@ package usecase_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
 )
 
-type fakeYTForPull struct {
-	items []port.ChatMessage
-	ended bool
-}
-
-// ページトークンを返すフェイク
-type fakeYTWithToken struct {
-	items                 []port.ChatMessage
-	messages              []port.ChatMessage
-	nextPageToken         string
-	pollingIntervalMillis int64
-}
-
-func (f *fakeYTWithToken) GetActiveLiveChatID(ctx context.Context, videoID string) (port.VideoMeta, error) {
-	return port.VideoMeta{}, nil
-}
-func (f *fakeYTWithToken) GetChannelDisplayNames(ctx context.Context, channelIDs []string) (map[string]string, error) {
-	return nil, nil
-}
-func (f *fakeYTWithToken) GetChannelHandles(ctx context.Context, channelIDs []string) (map[string]string, error) {
-	return nil, nil
-}
-func (f *fakeYTWithToken) GetVideoLiveDetails(ctx context.Context, videoID string) (port.VideoLiveDetails, error) {
-	return port.VideoLiveDetails{}, nil
-}
-func (f *fakeYTWithToken) ListLiveChatMessages(ctx context.Context, liveChatID string, pageToken string) ([]port.ChatMessage, string, int64, int, bool, error) {
-	// messagesフィールドがある場合はそれを使用（新しいテスト用）
-	if f.messages != nil {
-		return f.messages, f.nextPageToken, f.pollingIntervalMillis, 0, false, nil
-	}
-	// 既存のテストとの互換性のため、itemsフィールドを使用
-	return f.items, "nxt", 1500, 0, false, nil
-}
-
 // ページトークンを保存・読み出しする簡易フェイク（必要なら）
 // removed unused tokenStateRepo to satisfy linter
 
-func (f *fakeYTForPull) GetActiveLiveChatID(ctx context.Context, videoID string) (port.VideoMeta, error) {
-	return port.VideoMeta{LiveChatID: "live:abc"}, nil
-}
-func (f *fakeYTForPull) ListLiveChatMessages(ctx context.Context, liveChatID string, pageToken string) ([]port.ChatMessage, string, int64, int, bool, error) {
-	return f.items, "", 0, 0, f.ended, nil
-}
-func (f *fakeYTForPull) GetChannelDisplayNames(ctx context.Context, channelIDs []string) (map[string]string, error) {
-	return nil, nil
-}
-func (f *fakeYTForPull) GetChannelHandles(ctx context.Context, channelIDs []string) (map[string]string, error) {
-	return nil, nil
-}
-func (f *fakeYTForPull) GetVideoLiveDetails(ctx context.Context, videoID string) (port.VideoLiveDetails, error) {
-	return port.VideoLiveDetails{}, nil
-}
-
-type fakeClock struct {
-	now time.Time
-}
-
-func (f *fakeClock) Now() time.Time {
-	return f.now
-}
-
 // TestPull_OnStreamEnd_ClearsAutonomousMonitoring: 配信終了検知で AutonomousMonitoring=false がクリアされる。
 // monitor の Pull tick を確実に停止する core 仕様。
 func TestPull_OnStreamEnd_ClearsAutonomousMonitoring(t *testing.T) {
@@ -85,8 +29,8 @@ func TestPull_OnStreamEnd_ClearsAutonomousMonitoring(t *testing.T) {
 		LiveChatID:           "live:abc",
 		AutonomousMonitoring: true,
 	})
-	yt := &fakeYTForPull{ended: true} // 配信終了
-	clock := &fakeClock{now: time.Date(2026, 6, 21, 12, 0, 0, 0, time.UTC)}
+	yt := &fakeYT{ended: true} // 配信終了
+	clock := system.NewManualClock(time.Date(2026, 6, 21, 12, 0, 0, 0, time.UTC))
 
 	comments := memory.NewCommentRepo()
 	uc := &usecase.Pull{YT: yt, Users: users, Comments: comments, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
@@ -100,8 +44,8 @@ func TestPull_OnStreamEnd_ClearsAutonomousMonitoring(t *testing.T) {
 	}
 
 	got, _ := state.Get(ctx)
-	if got.Status != domain.StatusWaiting {
-		t.Errorf("Status = %v, want WAITING", got.Status)
+	if got.Status != domain.StatusEnded {
+		t.Errorf("Status = %v, want ENDED", got.Status)
 	}
 	if got.AutonomousMonitoring {
 		t.Error("AutonomousMonitoring = true, want false (stream end should clear)")
@@ -113,8 +57,8 @@ func TestPull_AddsUsers_NormalFlow(t *testing.T) {
 	users := memory.NewUserRepo()
 	state := memory.NewStateRepo()
 	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v", LiveChatID: "live:abc"})
-	yt := &fakeYTForPull{items: []port.ChatMessage{{ID: "msg1", ChannelID: "ch1", DisplayName: "Alice", PublishedAt: time.Date(2023, 1, 1, 11, 30, 0, 0, time.UTC)}}, ended: false}
-	clock := &fakeClock{now: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)}
+	yt := &fakeYT{items: []port.ChatMessage{{ID: "msg1", ChannelID: "ch1", DisplayName: "Alice", PublishedAt: time.Date(2023, 1, 1, 11, 30, 0, 0, time.UTC)}}, ended: false}
+	clock := system.NewManualClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
 
 	comments := memory.NewCommentRepo()
 	uc := &usecase.Pull{YT: yt, Users: users, Comments: comments, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
@@ -155,12 +99,12 @@ func TestPull_MultipleComments_IncrementCount(t *testing.T) {
 	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v", LiveChatID: "live:abc"})
 
 	// ch1が2回、ch2が1回コメントするシナリオ
-	yt := &fakeYTForPull{items: []port.ChatMessage{
+	yt := &fakeYT{items: []port.ChatMessage{
 		{ID: "msg1", ChannelID: "ch1", DisplayName: "Alice", PublishedAt: time.Date(2023, 1, 1, 11, 30, 0, 0, time.UTC)},
 		{ID: "msg2", ChannelID: "ch2", DisplayName: "Bob", PublishedAt: time.Date(2023, 1, 1, 11, 35, 0, 0, time.UTC)},
 		{ID: "msg3", ChannelID: "ch1", DisplayName: "Alice", PublishedAt: time.Date(2023, 1, 1, 11, 40, 0, 0, time.UTC)},
 	}, ended: false}
-	clock := &fakeClock{now: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)}
+	clock := system.NewManualClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
 
 	comments := memory.NewCommentRepo()
 	uc := &usecase.Pull{YT: yt, Users: users, Comments: comments, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
@@ -208,9 +152,9 @@ func TestPull_Ended_AutoReset(t *testing.T) {
 		LiveChatID: "live:abc",
 		StartedAt:  startedAt,
 	})
-	yt := &fakeYTForPull{items: nil, ended: true}
+	yt := &fakeYT{items: nil, ended: true}
 	endedAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
-	clock := &fakeClock{now: endedAt}
+	clock := system.NewManualClock(endedAt)
 
 	comments := memory.NewCommentRepo()
 	uc := &usecase.Pull{YT: yt, Users: users, Comments: comments, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
@@ -229,10 +173,10 @@ func TestPull_Ended_AutoReset(t *testing.T) {
 		t.Errorf("Users.Count() = %d, want 1 (preserved on stream end)", users.Count())
 	}
 
-	// StateがWAITINGに戻ったか確認
+	// StateがENDEDに遷移したか確認
 	currentState, _ := state.Get(ctx)
-	if currentState.Status != domain.StatusWaiting {
-		t.Errorf("State.Status = %v, want %v", currentState.Status, domain.StatusWaiting)
+	if currentState.Status != domain.StatusEnded {
+		t.Errorf("State.Status = %v, want %v", currentState.Status, domain.StatusEnded)
 	}
 
 	// EndedAt時刻が正確に設定されているか確認
@@ -246,8 +190,8 @@ func TestPull_WaitingState_NoOperation(t *testing.T) {
 	users := memory.NewUserRepo()
 	state := memory.NewStateRepo()
 	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusWaiting, VideoID: "v", LiveChatID: ""})
-	yt := &fakeYTForPull{items: []port.ChatMessage{{ID: "msg1", ChannelID: "ch1", DisplayName: "Alice", PublishedAt: time.Date(2023, 1, 1, 11, 30, 0, 0, time.UTC)}}, ended: false}
-	clock := &fakeClock{now: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)}
+	yt := &fakeYT{items: []port.ChatMessage{{ID: "msg1", ChannelID: "ch1", DisplayName: "Alice", PublishedAt: time.Date(2023, 1, 1, 11, 30, 0, 0, time.UTC)}}, ended: false}
+	clock := system.NewManualClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
 
 	comments := memory.NewCommentRepo()
 	uc := &usecase.Pull{YT: yt, Users: users, Comments: comments, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
@@ -271,10 +215,10 @@ func TestPull_WaitingState_NoOperation(t *testing.T) {
 func TestPull_SavesNextPageToken(t *testing.T) {
 	users := memory.NewUserRepo()
 	state := memory.NewStateRepo()
-	clock := &fakeClock{now: time.Date(2023, 1, 1, 11, 30, 0, 0, time.UTC)}
+	clock := system.NewManualClock(time.Date(2023, 1, 1, 11, 30, 0, 0, time.UTC))
 
 	// フェイクYT: 1ページ返して nextToken は "nxt"
-	yt := &fakeYTWithToken{items: []port.ChatMessage{{ID: "m1", ChannelID: "ch1", DisplayName: "Alice", PublishedAt: time.Date(2023, 1, 1, 11, 30, 0, 0, time.UTC)}}}
+	yt := &fakeYT{items: []port.ChatMessage{{ID: "m1", ChannelID: "ch1", DisplayName: "Alice", PublishedAt: time.Date(2023, 1, 1, 11, 30, 0, 0, time.UTC)}}, next: "nxt", pollMs: 1500}
 
 	// Active状態にして実行
 	if err := state.Set(context.Background(), domain.LiveState{Status: domain.StatusActive, LiveChatID: "lc1"}); err != nil {
@@ -327,11 +271,11 @@ func TestPull_MinimumPollingInterval(t *testing.T) {
 			// セットアップ
 			users := memory.NewUserRepo()
 			state := memory.NewStateRepo()
-			clock := &fakeClock{}
+			clock := system.NewManualClock(time.Time{})
 
 			// APIのモックを作成（pollingIntervalMillisを返す）
-			yt := &fakeYTWithToken{
-				messages: []port.ChatMessage{
+			yt := &fakeYT{
+				items: []port.ChatMessage{
 					{
 						ID:          "msg1",
 						ChannelID:   "UC001",
@@ -339,8 +283,8 @@ func TestPull_MinimumPollingInterval(t *testing.T) {
 						PublishedAt: time.Now(),
 					},
 				},
-				nextPageToken:         "token123",
-				pollingIntervalMillis: tt.apiPollingMillis,
+				next:   "token123",
+				pollMs: tt.apiPollingMillis,
 			}
 
 			// 初期状態をACTIVEに設定
@@ -372,6 +316,14 @@ func TestPull_MinimumPollingInterval(t *testing.T) {
 				t.Errorf("PollingIntervalMillis = %d, want %d",
 					output.PollingIntervalMillis, tt.expectedPollingMillis)
 			}
+			// monitor の間隔調整用にサーバー指定値はそのまま返す
+			if output.ServerPollingIntervalMillis != tt.apiPollingMillis {
+				t.Errorf("ServerPollingIntervalMillis = %d, want %d",
+					output.ServerPollingIntervalMillis, tt.apiPollingMillis)
+			}
+			if output.PageFull {
+				t.Error("PageFull should be false for a single message")
+			}
 		})
 	}
 }
@@ -398,8 +350,8 @@ func TestPull_Ended_FlushesSnapshotAndPreservesData(t *testing.T) {
 		LiveChatID: "live:abc",
 		StartedAt:  time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC),
 	})
-	yt := &fakeYTForPull{items: nil, ended: true}
-	clock := &fakeClock{now: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)}
+	yt := &fakeYT{items: nil, ended: true}
+	clock := system.NewManualClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
 	spy := &spyCoordinator{}
 
 	uc := &usecase.Pull{YT: yt, Users: users, Comments: comments, State: state, Clock: clock, Snap: spy}
@@ -417,3 +369,183 @@ func TestPull_Ended_FlushesSnapshotAndPreservesData(t *testing.T) {
 		t.Errorf("Users.Count() = %d, want 1 (preserved on stream end)", users.Count())
 	}
 }
+
+// TestPull_RecordsReactions: 新規メッセージのみリアクション分類してカウンタに加算する (再取得分は二重計上しない)
+func TestPull_RecordsReactions(t *testing.T) {
+	ctx := context.Background()
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v", LiveChatID: "live:abc"})
+	at := time.Date(2023, 1, 1, 11, 30, 10, 0, time.UTC)
+	yt := &fakeYT{items: []port.ChatMessage{
+		{ID: "msg1", ChannelID: "ch1", DisplayName: "Alice", Message: "草", PublishedAt: at},
+		{ID: "msg2", ChannelID: "ch2", DisplayName: "Bob", Message: "８８８８", PublishedAt: at.Add(time.Second)},
+		{ID: "msg3", ChannelID: "ch3", DisplayName: "Carol", Message: "こんにちは", PublishedAt: at.Add(2 * time.Second)},
+	}}
+	reactions := memory.NewReactionRepo()
+	uc := &usecase.Pull{
+		YT: yt, Users: memory.NewUserRepo(), Comments: memory.NewCommentRepo(), State: state,
+		Clock: system.NewManualClock(at.Add(time.Minute)), Snap: &snapshot.NopCoordinator{}, Reactions: reactions,
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := uc.Execute(ctx); err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+	}
+
+	timeline := reactions.Timeline()
+	if len(timeline) != 1 {
+		t.Fatalf("len(timeline) = %d, want 1: %+v", len(timeline), timeline)
+	}
+	if timeline[0].Counts["laugh"] != 1 || timeline[0].Counts["applause"] != 1 {
+		t.Errorf("counts = %v, want laugh=1 applause=1", timeline[0].Counts)
+	}
+}
+
+// pagedYT はページごとにメッセージ数を返す fakeYT を返す。pages[i] 件のページを順に返し、
+// 受け取ったページトークンを tokens に記録する。
+func pagedYT(pages []int) (yt *fakeYT, tokens *[]string) {
+	tokens = new([]string)
+	yt = &fakeYT{listMessages: func(_ context.Context, _ string, pageToken string) ([]port.ChatMessage, string, int64, int, bool, error) {
+		page := len(*tokens)
+		*tokens = append(*tokens, pageToken)
+		if page >= len(pages) {
+			return nil, pageToken, 2000, 0, false, nil
+		}
+		items := make([]port.ChatMessage, pages[page])
+		for i := range items {
+			items[i] = port.ChatMessage{
+				ID:          fmt.Sprintf("p%d-m%d", page, i),
+				ChannelID:   fmt.Sprintf("UC%d", i%50),
+				DisplayName: "User",
+				PublishedAt: time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+			}
+		}
+		return items, fmt.Sprintf("tok-%d", page+1), 2000, 0, false, nil
+	}}
+	return yt, tokens
+}
+
+func newDrainPull(yt port.YouTubePort, maxPages int) (*usecase.Pull, port.StateRepo) {
+	state := memory.NewStateRepo()
+	_ = state.Set(context.Background(), domain.LiveState{Status: domain.StatusActive, LiveChatID: "chat", NextPageToken: "tok-0"})
+	return &usecase.Pull{
+		YT:            yt,
+		Users:         memory.NewUserRepo(),
+		Comments:      memory.NewCommentRepo(),
+		State:         state,
+		Clock:         system.NewManualClock(time.Time{}),
+		Snap:          &snapshot.NopCoordinator{},
+		MaxDrainPages: maxPages,
+	}, state
+}
+
+func TestPull_DrainsFullPages(t *testing.T) {
+	full := port.LiveChatMaxResults
+	yt, tokens := pagedYT([]int{full, full, 10})
+	uc, state := newDrainPull(yt, 5)
+
+	out, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if out.PagesDrained != 3 || out.AddedCount != 2*full+10 || out.PageFull {
+		t.Errorf("out = %+v, want 3 pages, %d added, not full", out, 2*full+10)
+	}
+	if fmt.Sprint(*tokens) != "[tok-0 tok-1 tok-2]" {
+		t.Errorf("tokens = %v", *tokens)
+	}
+	if st, _ := state.Get(context.Background()); st.NextPageToken != "tok-3" {
+		t.Errorf("NextPageToken = %q, want tok-3", st.NextPageToken)
+	}
+}
+
+func TestPull_DrainStopsAtPageCap(t *testing.T) {
+	full := port.LiveChatMaxResults
+	yt, _ := pagedYT([]int{full, full, full})
+	uc, _ := newDrainPull(yt, 2)
+
+	out, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if out.PagesDrained != 2 || !out.PageFull {
+		t.Errorf("out = %+v, want 2 pages and PageFull (backlog remains)", out)
+	}
+}
+
+func TestPull_DrainStopsWhenQuotaLow(t *testing.T) {
+	full := port.LiveChatMaxResults
+	yt, _ := pagedYT([]int{full, full})
+	uc, _ := newDrainPull(yt, 5)
+	uc.Quota = &fakeQuotaReporter{usage: domain.QuotaUsage{Used: 8000, Budget: 10000}}
+
+	out, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if out.PagesDrained != 1 {
+		t.Errorf("PagesDrained = %d, want 1 (quota below reserve)", out.PagesDrained)
+	}
+}
+
+func TestPull_SinglePageByDefault(t *testing.T) {
+	yt, tokens := pagedYT([]int{port.LiveChatMaxResults, port.LiveChatMaxResults})
+	uc, _ := newDrainPull(yt, 0)
+
+	out, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if out.PagesDrained != 1 || len(*tokens) != 1 {
+		t.Errorf("PagesDrained = %d calls = %d, want 1 (drain disabled)", out.PagesDrained, len(*tokens))
+	}
+}
+
+// cachedResolver は Lookup で固定のキャッシュ済み情報を返す ChannelResolveQueue です。
+type cachedResolver struct {
+	known map[string]domain.ChannelInfo
+}
+
+func (r *cachedResolver) Lookup(channelIDs, _ []string) map[string]domain.ChannelInfo {
+	out := make(map[string]domain.ChannelInfo)
+	for _, id := range channelIDs {
+		if info, ok := r.known[id]; ok {
+			out[id] = info
+		}
+	}
+	return out
+}
+
+func TestPull_ResolverCacheHitSetsUserHandle(t *testing.T) {
+	ctx := context.Background()
+	users := memory.NewUserRepo()
+	comments := memory.NewCommentRepo()
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v", LiveChatID: "live:abc"})
+	yt := &fakeYT{items: []port.ChatMessage{
+		{ID: "msg1", ChannelID: "ch1", DisplayName: "@alice", PublishedAt: time.Date(2023, 1, 1, 11, 30, 0, 0, time.UTC)},
+		{ID: "msg2", ChannelID: "ch2", DisplayName: "Bob", PublishedAt: time.Date(2023, 1, 1, 11, 31, 0, 0, time.UTC)},
+	}}
+	uc := &usecase.Pull{
+		YT: yt, Users: users, Comments: comments, State: state,
+		Clock: system.NewManualClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)), Snap: &snapshot.NopCoordinator{},
+		Resolver: &cachedResolver{known: map[string]domain.ChannelInfo{
+			"ch1": {ChannelID: "ch1", Title: "Alice", Handle: "@alice"},
+		}},
+	}
+	if _, err := uc.Execute(ctx); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	byID := map[string]domain.User{}
+	for _, u := range users.ListUsersSortedByJoinTime() {
+		byID[u.ChannelID] = u
+	}
+	if u := byID["ch1"]; u.DisplayName != "Alice" || u.Handle != "@alice" {
+		t.Errorf("cached user = %+v, want name Alice / handle @alice", u)
+	}
+	if u := byID["ch2"]; u.Handle != "" {
+		t.Errorf("unresolved user handle = %q, want empty", u.Handle)
+	}
+}
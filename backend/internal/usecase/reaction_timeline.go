@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/analytics"
+)
+
+// ReactionTimelineInput は ReactionTimeline の入力です。
+type ReactionTimelineInput struct {
+	Since time.Time // zero なら全期間。overlay のポーリングでは直近数分だけを取得する
+}
+
+// ReactionTimelineOutput は ReactionTimeline の出力です。
+type ReactionTimelineOutput struct {
+	VideoID  string
+	Buckets  []string                // lexicon の定義順
+	Timeline []domain.ReactionMinute // Since 以降の分単位カウンタ (古い順)
+	Totals   map[string]int          // 全期間の bucket 別合計 (Since に関係なく集計)
+}
+
+// ReactionTimeline は Pull が集計したリアクション (草 / 888 等) の分単位カウンタを返します。
+type ReactionTimeline struct {
+	Reactions port.ReactionRepo
+	State     port.StateRepo
+	Lexicon   *analytics.Lexicon // 任意: nil の場合は analytics.DefaultLexicon を使う
+}
+
+// Execute は現在の配信のリアクション timeline と合計を返します。
+func (uc *ReactionTimeline) Execute(ctx context.Context, in ReactionTimelineInput) (ReactionTimelineOutput, error) {
+	state, err := uc.State.Get(ctx)
+	if err != nil {
+		return ReactionTimelineOutput{}, fmt.Errorf("state_get: %w", err)
+	}
+	lexicon := uc.Lexicon
+	if lexicon == nil {
+		lexicon = analytics.DefaultLexicon()
+	}
+
+	buckets := lexicon.Buckets()
+	totals := make(map[string]int, len(buckets))
+	for _, b := range buckets {
+		totals[b] = 0
+	}
+
+	all := uc.Reactions.Timeline()
+	timeline := make([]domain.ReactionMinute, 0, len(all))
+	for _, m := range all {
+		for b, n := range m.Counts {
+			totals[b] += n
+		}
+		// Since を含む分の枠も返す (Since が分の途中でも取りこぼさない)
+		if in.Since.IsZero() || !m.Minute.Before(in.Since.Truncate(time.Minute)) {
+			timeline = append(timeline, m)
+		}
+	}
+
+	return ReactionTimelineOutput{
+		VideoID:  state.VideoID,
+		Buckets:  buckets,
+		Timeline: timeline,
+		Totals:   totals,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+)
+
+func TestReactionTimeline_Execute(t *testing.T) {
+	ctx := context.Background()
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v1"})
+	reactions := memory.NewReactionRepo()
+	base := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	reactions.Record(base, []string{"laugh"})
+	reactions.Record(base.Add(2*time.Minute), []string{"laugh", "applause"})
+
+	uc := &usecase.ReactionTimeline{Reactions: reactions, State: state}
+
+	t.Run("全期間", func(t *testing.T) {
+		out, err := uc.Execute(ctx, usecase.ReactionTimelineInput{})
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		if out.VideoID != "v1" || len(out.Timeline) != 2 {
+			t.Fatalf("out = %+v", out)
+		}
+		if out.Totals["laugh"] != 2 || out.Totals["applause"] != 1 || out.Totals["kawaii"] != 0 {
+			t.Errorf("Totals = %v", out.Totals)
+		}
+		if _, ok := out.Totals["kawaii"]; !ok {
+			t.Error("Totals should contain zero-count buckets")
+		}
+	})
+
+	t.Run("since 以降のみ timeline に含め、totals は全期間", func(t *testing.T) {
+		out, err := uc.Execute(ctx, usecase.ReactionTimelineInput{Since: base.Add(2*time.Minute + 30*time.Second)})
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		if len(out.Timeline) != 1 || !out.Timeline[0].Minute.Equal(base.Add(2*time.Minute)) {
+			t.Errorf("Timeline = %+v", out.Timeline)
+		}
+		if out.Totals["laugh"] != 2 {
+			t.Errorf("Totals = %v", out.Totals)
+		}
+	})
+}
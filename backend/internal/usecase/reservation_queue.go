@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+type ReservationInput struct {
+	VideoID  string
+	Position int // ReorderReservation 用: QUEUED 内の移動先 (0 始まり、範囲外は先頭 / 末尾に丸める)
+}
+
+type ReservationQueueOutput struct {
+	Reservations []domain.Reservation
+}
+
+// EnqueueReservation は連続配信用の予約キューに配信を追加する。
+// Reserve と違い配信中 (ACTIVE) でも追加でき、キューは ScheduledStartTime 順に並ぶ。
+// 実際の予約 (RESERVED) への昇格は現在の配信が終わった後に PromoteReservation (monitor) が行う。
+type EnqueueReservation struct {
+	YT    port.YouTubePort
+	Queue port.ReservationRepo
+	Clock port.Clock
+}
+
+// Execute: 非 live なら invalid argument、同じ videoID が順番待ち中なら conflict。
+func (uc *EnqueueReservation) Execute(ctx context.Context, in ReservationInput) (ReservationQueueOutput, error) {
+	if in.VideoID == "" {
+		return ReservationQueueOutput{}, &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: "videoId is required"}
+	}
+	details, err := uc.YT.GetVideoLiveDetails(ctx, in.VideoID)
+	if err != nil {
+		return ReservationQueueOutput{}, fmt.Errorf("get_video_live_details: %w", err)
+	}
+	if !details.IsLiveContent {
+		return ReservationQueueOutput{}, &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: "video is not a live stream"}
+	}
+
+	now := uc.Clock.Now()
+	entry := domain.Reservation{
+		VideoID:            in.VideoID,
+		ScheduledStartTime: details.ScheduledStartTime,
+		QueuedAt:           now,
+		Status:             domain.ReservationQueued,
+		UpdatedAt:          now,
+	}
+	return updateQueue(ctx, uc.Queue, func(queued, history []domain.Reservation) ([]domain.Reservation, []domain.Reservation, error) {
+		if indexQueued(queued, in.VideoID) >= 0 {
+			return nil, nil, &domain.APIError{Code: domain.ErrCodeConflict, Message: fmt.Sprintf("video %s is already queued", in.VideoID)}
+		}
+		// 同じ videoID の過去の件 (取消済みなど) は新しい件で置き換える
+		history = slices.DeleteFunc(history, func(r domain.Reservation) bool { return r.VideoID == in.VideoID })
+		// 予定開始時刻順に挿入する (予定時刻が無いものは末尾)
+		i := slices.IndexFunc(queued, func(r domain.Reservation) bool {
+			return !entry.ScheduledStartTime.IsZero() && (r.ScheduledStartTime.IsZero() || r.ScheduledStartTime.After(entry.ScheduledStartTime))
+		})
+		if i < 0 {
+			i = len(queued)
+		}
+		logging.Log(ctx, "info", "RESERVATIONS", "queued %s at position %d (scheduled=%s)", in.VideoID, i, entry.ScheduledStartTime)
+		return slices.Insert(queued, i, entry), history, nil
+	})
+}
+
+// ReorderReservation は順番待ちの予約を指定位置へ移動する。
+type ReorderReservation struct {
+	Queue port.ReservationRepo
+}
+
+// Execute: videoID の QUEUED 件を Position へ移動する。順番待ちでなければ video_not_found。
+func (uc *ReorderReservation) Execute(ctx context.Context, in ReservationInput) (ReservationQueueOutput, error) {
+	return updateQueue(ctx, uc.Queue, func(queued, history []domain.Reservation) ([]domain.Reservation, []domain.Reservation, error) {
+		i := indexQueued(queued, in.VideoID)
+		if i < 0 {
+			return nil, nil, notQueued(in.VideoID)
+		}
+		entry := queued[i]
+		queued = slices.Delete(queued, i, i+1)
+		pos := min(max(in.Position, 0), len(queued))
+		return slices.Insert(queued, pos, entry), history, nil
+	})
+}
+
+// CancelReservation は順番待ちの予約を取り消す (CANCELED として履歴に残す)。
+type CancelReservation struct {
+	Queue port.ReservationRepo
+	Clock port.Clock
+}
+
+// Execute: videoID の QUEUED 件を取り消す。順番待ちでなければ video_not_found。
+func (uc *CancelReservation) Execute(ctx context.Context, in ReservationInput) (ReservationQueueOutput, error) {
+	now := uc.Clock.Now()
+	return updateQueue(ctx, uc.Queue, func(queued, history []domain.Reservation) ([]domain.Reservation, []domain.Reservation, error) {
+		i := indexQueued(queued, in.VideoID)
+		if i < 0 {
+			return nil, nil, notQueued(in.VideoID)
+		}
+		entry := finish(queued[i], domain.ReservationCanceled, now, "")
+		return slices.Delete(queued, i, i+1), append([]domain.Reservation{entry}, history...), nil
+	})
+}
+
+type PromoteReservationOutput struct {
+	Promoted *domain.Reservation // 昇格した件 (無ければ nil)
+	State    domain.LiveState
+}
+
+// PromoteReservation は現在の配信が無い (WAITING / ENDED) とき、予約キューの先頭を Reserve で RESERVED に昇格する。
+// 予約できない件 (非 live・動画なし) は FAILED にして次の件を試す。
+type PromoteReservation struct {
+	Queue   port.ReservationRepo
+	State   port.StateRepo
+	Reserve *Reserve
+	Clock   port.Clock
+}
+
+// Execute: 配信中・予約中、またはキューが空なら何もしない。
+// Reserve (API 呼び出し) の間はキューをロックせず、結果はその時点の最新のキューに反映する
+// (昇格中に追加・取消された件を古い一覧で上書きしない)。
+func (uc *PromoteReservation) Execute(ctx context.Context) (PromoteReservationOutput, error) {
+	st, err := uc.State.Get(ctx)
+	if err != nil {
+		return PromoteReservationOutput{}, fmt.Errorf("state_get: %w", err)
+	}
+	if st.Status.InSession() || st.Status == domain.StatusReserved {
+		return PromoteReservationOutput{State: st}, nil
+	}
+
+	for {
+		list, err := uc.Queue.List(ctx)
+		if err != nil {
+			return PromoteReservationOutput{}, fmt.Errorf("reservations_list: %w", err)
+		}
+		queued, _ := splitQueue(list)
+		if len(queued) == 0 {
+			return PromoteReservationOutput{State: st}, nil
+		}
+		next := queued[0]
+		out, err := uc.Reserve.Execute(ctx, ReserveInput{VideoID: next.VideoID})
+		if err != nil {
+			var apiErr *domain.APIError
+			if errors.As(err, &apiErr) && (apiErr.Code == domain.ErrCodeInvalidArgument || apiErr.Code == domain.ErrCodeVideoNotFound) {
+				logging.Log(ctx, "warn", "RESERVATIONS", "promote: %s cannot be reserved, skipping: %v", next.VideoID, err)
+				if _, err := uc.settle(ctx, next, domain.ReservationFailed, apiErr.Message); err != nil {
+					// 保存できないまま次の件へ進むと同じ件を繰り返し試すため、次の tick で再試行する
+					return PromoteReservationOutput{}, err
+				}
+				continue
+			}
+			// 一時的な失敗 (API エラー・競合) は次の tick で再試行する
+			return PromoteReservationOutput{}, err
+		}
+		promoted, err := uc.settle(ctx, next, domain.ReservationPromoted, "")
+		if err != nil {
+			logging.Log(ctx, "warn", "RESERVATIONS", "promote: save queue failed: %v", err)
+		}
+		logging.Log(ctx, "info", "RESERVATIONS", "promoted %s to RESERVED", next.VideoID)
+		return PromoteReservationOutput{Promoted: &promoted, State: out.State}, nil
+	}
+}
+
+// settle は next を最新のキューの順番待ちから外し、status の履歴として保存する。
+// Reserve の間に取り消されていても、実際に行った昇格・失敗を履歴に残す。
+func (uc *PromoteReservation) settle(ctx context.Context, next domain.Reservation, status domain.ReservationStatus, reason string) (domain.Reservation, error) {
+	entry := finish(next, status, uc.Clock.Now(), reason)
+	_, err := updateQueue(ctx, uc.Queue, func(queued, history []domain.Reservation) ([]domain.Reservation, []domain.Reservation, error) {
+		if i := indexQueued(queued, next.VideoID); i >= 0 {
+			queued = slices.Delete(queued, i, i+1)
+		}
+		history = slices.DeleteFunc(history, func(r domain.Reservation) bool { return r.VideoID == next.VideoID })
+		return queued, append([]domain.Reservation{entry}, history...), nil
+	})
+	return entry, err
+}
+
+// splitQueue は予約キューを順番待ち (並び順) と履歴 (新しい順) に分ける。
+func splitQueue(list []domain.Reservation) (queued, history []domain.Reservation) {
+	for _, r := range list {
+		if r.Status == domain.ReservationQueued {
+			queued = append(queued, r)
+		} else {
+			history = append(history, r)
+		}
+	}
+	return queued, history
+}
+
+// updateQueue は最新の予約キューを順番待ちと履歴に分けて fn で書き換え、
+// 順番待ち → 履歴 (最大 MaxReservationHistory 件) の順で保存する。読み出しから保存までは repo が排他する。
+func updateQueue(ctx context.Context, repo port.ReservationRepo, fn func(queued, history []domain.Reservation) ([]domain.Reservation, []domain.Reservation, error)) (ReservationQueueOutput, error) {
+	var fnErr error
+	list, err := repo.Update(ctx, func(list []domain.Reservation) ([]domain.Reservation, error) {
+		queued, history, err := fn(splitQueue(list))
+		if err != nil {
+			fnErr = err
+			return nil, err
+		}
+		if len(history) > domain.MaxReservationHistory {
+			history = history[:domain.MaxReservationHistory]
+		}
+		next := make([]domain.Reservation, 0, len(queued)+len(history))
+		return append(append(next, queued...), history...), nil
+	})
+	if fnErr != nil {
+		return ReservationQueueOutput{}, fnErr
+	}
+	if err != nil {
+		return ReservationQueueOutput{}, fmt.Errorf("reservations_save: %w", err)
+	}
+	return ReservationQueueOutput{Reservations: list}, nil
+}
+
+func indexQueued(queued []domain.Reservation, videoID string) int {
+	return slices.IndexFunc(queued, func(r domain.Reservation) bool { return r.VideoID == videoID })
+}
+
+func notQueued(videoID string) error {
+	return &domain.APIError{Code: domain.ErrCodeVideoNotFound, Message: fmt.Sprintf("video %s is not queued", videoID)}
+}
+
+// finish は r を終了状態 status にした件を返す。
+func finish(r domain.Reservation, status domain.ReservationStatus, at time.Time, reason string) domain.Reservation {
+	r.Status = status
+	r.UpdatedAt = at
+	r.Reason = reason
+	return r
+}
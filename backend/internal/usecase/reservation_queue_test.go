@@ -0,0 +1,158 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// scheduleYT は videoID ごとに scheduled の予定開始時刻を返す fakeYT を返す。notLive の videoID は非 live 扱い。
+func scheduleYT(scheduled map[string]time.Time, notLive map[string]bool) *fakeYT {
+	return &fakeYT{liveDetails: func(videoID string) (port.VideoLiveDetails, error) {
+		return port.VideoLiveDetails{IsLiveContent: !notLive[videoID], ScheduledStartTime: scheduled[videoID]}, nil
+	}}
+}
+
+func queueOrder(list []domain.Reservation) []string {
+	var ids []string
+	for _, r := range list {
+		ids = append(ids, r.VideoID+":"+string(r.Status))
+	}
+	return ids
+}
+
+func TestReservationQueue_EnqueueOrdersByScheduleAndReorders(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	yt := scheduleYT(map[string]time.Time{
+		"morning": t0,
+		"evening": t0.Add(10 * time.Hour),
+		"noon":    t0.Add(3 * time.Hour),
+	}, nil)
+	queue := memory.NewReservationRepo(nil, memory.DefaultReservationKey)
+	clock := system.NewManualClock(t0.Add(-time.Hour))
+	enqueue := &usecase.EnqueueReservation{YT: yt, Queue: queue, Clock: clock}
+
+	for _, id := range []string{"evening", "morning", "noon"} {
+		if _, err := enqueue.Execute(ctx, usecase.ReservationInput{VideoID: id}); err != nil {
+			t.Fatalf("enqueue %s: %v", id, err)
+		}
+	}
+	list, _ := queue.List(ctx)
+	if got, want := queueOrder(list), []string{"morning:QUEUED", "noon:QUEUED", "evening:QUEUED"}; !slices.Equal(got, want) {
+		t.Errorf("queue = %v, want %v", got, want)
+	}
+
+	var apiErr *domain.APIError
+	if _, err := enqueue.Execute(ctx, usecase.ReservationInput{VideoID: "noon"}); !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeConflict {
+		t.Errorf("duplicate enqueue err = %v, want conflict", err)
+	}
+
+	out, err := (&usecase.ReorderReservation{Queue: queue}).Execute(ctx, usecase.ReservationInput{VideoID: "evening", Position: 0})
+	if err != nil {
+		t.Fatalf("reorder: %v", err)
+	}
+	if got, want := queueOrder(out.Reservations), []string{"evening:QUEUED", "morning:QUEUED", "noon:QUEUED"}; !slices.Equal(got, want) {
+		t.Errorf("after reorder = %v, want %v", got, want)
+	}
+
+	out, err = (&usecase.CancelReservation{Queue: queue, Clock: clock}).Execute(ctx, usecase.ReservationInput{VideoID: "morning"})
+	if err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	if got, want := queueOrder(out.Reservations), []string{"evening:QUEUED", "noon:QUEUED", "morning:CANCELED"}; !slices.Equal(got, want) {
+		t.Errorf("after cancel = %v, want %v", got, want)
+	}
+}
+
+func TestPromoteReservation_PromotesAfterStreamEndsAndSkipsInvalid(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	notLive := map[string]bool{}
+	yt := scheduleYT(map[string]time.Time{"gone": t0, "next": t0.Add(time.Hour)}, notLive)
+	queue := memory.NewReservationRepo(nil, memory.DefaultReservationKey)
+	state := memory.NewStateRepo()
+	clock := system.NewManualClock(t0)
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "current"})
+
+	enqueue := &usecase.EnqueueReservation{YT: yt, Queue: queue, Clock: clock}
+	for _, id := range []string{"gone", "next"} {
+		if _, err := enqueue.Execute(ctx, usecase.ReservationInput{VideoID: id}); err != nil {
+			t.Fatalf("enqueue while ACTIVE: %v", err)
+		}
+	}
+
+	promote := &usecase.PromoteReservation{
+		Queue:   queue,
+		State:   state,
+		Reserve: &usecase.Reserve{YT: yt, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}},
+		Clock:   clock,
+	}
+	// 配信中は昇格しない
+	if out, err := promote.Execute(ctx); err != nil || out.Promoted != nil {
+		t.Fatalf("promote while ACTIVE = %+v, %v; want no-op", out.Promoted, err)
+	}
+
+	// 配信終了後: 非 live になった先頭は FAILED にして次を昇格する
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusEnded, VideoID: "current"})
+	notLive["gone"] = true
+	out, err := promote.Execute(ctx)
+	if err != nil {
+		t.Fatalf("promote: %v", err)
+	}
+	if out.Promoted == nil || out.Promoted.VideoID != "next" || out.State.Status != domain.StatusReserved || out.State.VideoID != "next" {
+		t.Fatalf("promote = %+v state=%+v, want next RESERVED", out.Promoted, out.State)
+	}
+	list, _ := queue.List(ctx)
+	if got, want := queueOrder(list), []string{"next:PROMOTED", "gone:FAILED"}; !slices.Equal(got, want) {
+		t.Errorf("queue after promote = %v, want %v", got, want)
+	}
+}
+
+func TestPromoteReservation_KeepsEntriesQueuedDuringReserve(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	yt := scheduleYT(map[string]time.Time{"next": t0, "late": t0.Add(time.Hour)}, nil)
+	queue := memory.NewReservationRepo(nil, memory.DefaultReservationKey)
+	state := memory.NewStateRepo()
+	clock := system.NewManualClock(t0)
+	enqueue := &usecase.EnqueueReservation{YT: yt, Queue: queue, Clock: clock}
+	if _, err := enqueue.Execute(ctx, usecase.ReservationInput{VideoID: "next"}); err != nil {
+		t.Fatalf("enqueue next: %v", err)
+	}
+
+	// Reserve が next の詳細を取得している間に別の予約が追加される
+	details, hooked := yt.liveDetails, false
+	yt.liveDetails = func(videoID string) (port.VideoLiveDetails, error) {
+		if videoID == "next" && !hooked {
+			hooked = true
+			if _, err := enqueue.Execute(ctx, usecase.ReservationInput{VideoID: "late"}); err != nil {
+				t.Errorf("enqueue late during promote: %v", err)
+			}
+		}
+		return details(videoID)
+	}
+	promote := &usecase.PromoteReservation{
+		Queue:   queue,
+		State:   state,
+		Reserve: &usecase.Reserve{YT: yt, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}},
+		Clock:   clock,
+	}
+	out, err := promote.Execute(ctx)
+	if err != nil || out.Promoted == nil || out.Promoted.VideoID != "next" {
+		t.Fatalf("promote = %+v, %v; want next", out.Promoted, err)
+	}
+	list, _ := queue.List(ctx)
+	if got, want := queueOrder(list), []string{"late:QUEUED", "next:PROMOTED"}; !slices.Equal(got, want) {
+		t.Errorf("queue after promote = %v, want %v", got, want)
+	}
+}
@@ -38,7 +38,7 @@ func (uc *Reserve) Execute(ctx context.Context, in ReserveInput) (ReserveOutput,
 	if err != nil {
 		return ReserveOutput{}, fmt.Errorf("state_get: %w", err)
 	}
-	if cur.Status == domain.StatusActive {
+	if cur.Status.InSession() {
 		return ReserveOutput{}, &domain.APIError{Code: domain.ErrCodeConflict, Message: "stream is currently active, reset first"}
 	}
 
@@ -57,16 +57,22 @@ func (uc *Reserve) Execute(ctx context.Context, in ReserveInput) (ReserveOutput,
 	}
 
 	now := uc.Clock.Now()
-	newState := domain.LiveState{
-		Status:               domain.StatusReserved,
-		VideoID:              in.VideoID,
-		LiveChatID:           details.LiveChatID, // チャット未開なら空
-		AutonomousMonitoring: true,
-		ReservedAt:           now,
-		ScheduledStartTime:   details.ScheduledStartTime,
-	}
-	if err := uc.State.Set(ctx, newState); err != nil {
-		return ReserveOutput{}, fmt.Errorf("state_set: %w", err)
+	// 動画情報の取得中に他の操作で ACTIVE になっていないか、保存時の最新 State で再確認する
+	newState, err := updateState(ctx, uc.State, func(cur domain.LiveState) (domain.LiveState, error) {
+		if cur.Status.InSession() {
+			return cur, &domain.APIError{Code: domain.ErrCodeConflict, Message: "stream is currently active, reset first"}
+		}
+		return cur.Transition(domain.LiveState{
+			Status:               domain.StatusReserved,
+			VideoID:              in.VideoID,
+			LiveChatID:           details.LiveChatID, // チャット未開なら空
+			AutonomousMonitoring: true,
+			ReservedAt:           now,
+			ScheduledStartTime:   details.ScheduledStartTime,
+		}, now, "reserve")
+	})
+	if err != nil {
+		return ReserveOutput{}, err
 	}
 
 	uc.Snap.MarkDirty()
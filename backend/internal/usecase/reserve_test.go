@@ -7,40 +7,19 @@ import (
 	"time"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
 )
 
-// fakeYTForReserve は GetVideoLiveDetails の戻り値を制御できる fake。
-type fakeYTForReserve struct {
-	details port.VideoLiveDetails
-	err     error
-}
-
-func (f *fakeYTForReserve) GetActiveLiveChatID(_ context.Context, _ string) (port.VideoMeta, error) {
-	return port.VideoMeta{}, nil
-}
-func (f *fakeYTForReserve) ListLiveChatMessages(_ context.Context, _ string, _ string) ([]port.ChatMessage, string, int64, int, bool, error) {
-	return nil, "", 0, 0, false, nil
-}
-func (f *fakeYTForReserve) GetChannelDisplayNames(_ context.Context, _ []string) (map[string]string, error) {
-	return nil, nil
-}
-func (f *fakeYTForReserve) GetChannelHandles(_ context.Context, _ []string) (map[string]string, error) {
-	return nil, nil
-}
-func (f *fakeYTForReserve) GetVideoLiveDetails(_ context.Context, _ string) (port.VideoLiveDetails, error) {
-	return f.details, f.err
-}
-
 func TestReserve_WaitingAndIsLive_SetsReserved(t *testing.T) {
 	ctx := context.Background()
 
 	state := memory.NewStateRepo()
 	scheduled := time.Date(2026, 7, 1, 18, 0, 0, 0, time.UTC)
-	yt := &fakeYTForReserve{
+	yt := &fakeYT{
 		details: port.VideoLiveDetails{
 			LiveChatID:         "",
 			IsLiveContent:      true,
@@ -48,7 +27,7 @@ func TestReserve_WaitingAndIsLive_SetsReserved(t *testing.T) {
 		},
 	}
 	now := time.Date(2026, 6, 21, 10, 0, 0, 0, time.UTC)
-	clock := fixedClock{t: now}
+	clock := system.NewManualClock(now)
 
 	uc := &usecase.Reserve{YT: yt, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 
@@ -86,8 +65,8 @@ func TestReserve_ActiveStatus_ReturnsConflict(t *testing.T) {
 	state := memory.NewStateRepo()
 	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "current"})
 
-	yt := &fakeYTForReserve{details: port.VideoLiveDetails{IsLiveContent: true}}
-	clock := fixedClock{t: time.Now()}
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true}}
+	clock := system.NewManualClock(time.Now())
 
 	uc := &usecase.Reserve{YT: yt, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 
@@ -108,8 +87,8 @@ func TestReserve_EmptyVideoID_ReturnsInvalidArgument(t *testing.T) {
 	ctx := context.Background()
 
 	state := memory.NewStateRepo()
-	yt := &fakeYTForReserve{}
-	clock := fixedClock{t: time.Now()}
+	yt := &fakeYT{}
+	clock := system.NewManualClock(time.Now())
 
 	uc := &usecase.Reserve{YT: yt, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 
@@ -131,8 +110,8 @@ func TestReserve_NotLiveContent_ReturnsInvalidArgument(t *testing.T) {
 
 	state := memory.NewStateRepo()
 	// IsLiveContent = false: 通常動画に対する予約
-	yt := &fakeYTForReserve{details: port.VideoLiveDetails{IsLiveContent: false}}
-	clock := fixedClock{t: time.Now()}
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: false}}
+	clock := system.NewManualClock(time.Now())
 
 	uc := &usecase.Reserve{YT: yt, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 
@@ -153,8 +132,8 @@ func TestReserve_YTAPIError_ReturnsWrappedError(t *testing.T) {
 	ctx := context.Background()
 
 	state := memory.NewStateRepo()
-	yt := &fakeYTForReserve{err: errors.New("youtube api quota exceeded")}
-	clock := fixedClock{t: time.Now()}
+	yt := &fakeYT{detailsErr: errors.New("youtube api quota exceeded")}
+	clock := system.NewManualClock(time.Now())
 
 	uc := &usecase.Reserve{YT: yt, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 
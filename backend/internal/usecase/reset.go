@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
@@ -19,6 +18,12 @@ type Reset struct {
 	Comments port.CommentRepo
 	State    port.StateRepo
 	Snap     snapshot.Coordinator // 必須 (GCS 不要な場合は NopCoordinator を渡す)
+
+	Reactions port.ReactionRepo // 任意: 設定時は users / comments と一緒にクリアする
+	Viewers   port.ViewerRepo   // 任意: 設定時は users / comments と一緒にクリアする
+	Metadata  port.MetadataRepo // 任意: 設定時は users / comments と一緒にクリアする
+	Clock     port.Clock        // 任意: 遷移履歴の時刻 (nil なら time.Now)
+	Lock      *StoreLock        // 任意: Pull のページ保存と排他する (同じセッションの Pull / SwitchVideo と共有)
 }
 
 // Execute: Users クリア、State=WAITING
@@ -28,20 +33,32 @@ func (uc *Reset) Execute(ctx context.Context) (ResetOutput, error) {
 		logging.Log(ctx, "warn", "SNAPSHOT", "reset: snapshot flush (pre-reset) failed: %v", err)
 	}
 
-	// ユーザーとコメントをクリア
+	// ユーザーとコメントをクリア (State の更新までを Pull のページ保存と排他する)
+	unlock := uc.Lock.acquire()
 	uc.Users.Clear()
 	if uc.Comments != nil {
 		uc.Comments.Clear()
 	}
-
-	// StateをWAITINGに戻す
-	newState := domain.LiveState{
-		Status:        domain.StatusWaiting,
-		NextPageToken: "",
+	if uc.Reactions != nil {
+		uc.Reactions.Clear()
+	}
+	if uc.Viewers != nil {
+		uc.Viewers.Clear()
+	}
+	if uc.Metadata != nil {
+		uc.Metadata.Clear()
 	}
 
-	if err := uc.State.Set(ctx, newState); err != nil {
-		return ResetOutput{}, fmt.Errorf("state_set: %w", err)
+	// StateをWAITINGに戻す (どの状態からでも遷移できる。遷移履歴は引き継ぐ)
+	newState, err := updateState(ctx, uc.State, func(cur domain.LiveState) (domain.LiveState, error) {
+		return cur.Transition(domain.LiveState{
+			Status:        domain.StatusWaiting,
+			NextPageToken: "",
+		}, clockNow(uc.Clock), "reset")
+	})
+	unlock()
+	if err != nil {
+		return ResetOutput{}, err
 	}
 
 	// video unset 状態にして current.json を更新
@@ -0,0 +1,271 @@
+// Package resolver はチャンネル表示名・ハンドルの解決を Pull から切り離して非同期に行う
+// background goroutine を提供する。未解決の channelID を複数回の Pull に跨いで 50 件ずつの
+// channels.list にまとめ、解決後に保存済みの User / Comment を backfill する。
+package resolver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+const (
+	DefaultInterval    = 5 * time.Second
+	DefaultMaxAttempts = 5
+	// BatchSize は channels.list 1 回で解決できる channelId 数です。
+	BatchSize = 50
+	// maxOutageBackoff は API エラー (quota 超過・rate limit など) が続いたときのキュー全体の待ち時間の上限です。
+	maxOutageBackoff = 10 * time.Minute
+)
+
+// channelLookup は YouTubePort のチャンネル解決部分のサブセット (test fake 注入用)。
+type channelLookup interface {
+	GetChannelDisplayNames(ctx context.Context, channelIDs []string) (map[string]string, error)
+	GetChannelHandles(ctx context.Context, channelIDs []string) (map[string]string, error)
+}
+
+// pending は解決待ちの channelID 1 件分です。
+type pending struct {
+	resolveName bool      // 表示名が @handle だったため名前も置き換える
+	attempts    int       // 解決に失敗した回数
+	notBefore   time.Time // 次に試行してよい時刻 (失敗ごとに指数的に延ばす)
+}
+
+// Resolver は未解決の channelID を溜めて定期的にまとめて解決する。
+type Resolver struct {
+	YT          channelLookup
+	Cache       port.ChannelCache         // 任意: Lookup で即答するためのキャッシュ (YT と同じインスタンス)
+	Users       port.UserProfileUpdater   // 任意
+	Comments    port.CommentAuthorUpdater // 任意
+	Snap        snapshot.Coordinator      // 任意: backfill 後に MarkDirty する
+	Clock       port.Clock
+	Interval    time.Duration // 0 なら DefaultInterval
+	MaxAttempts int           // 0 なら DefaultMaxAttempts
+
+	// TickC を inject すると Interval 無視で外部 channel を使う (test 用)。
+	TickC <-chan time.Time
+
+	mu     sync.Mutex
+	queue  map[string]*pending
+	outage int // 連続して API エラーになった ResolvePending の回数 (キュー全体の backoff 用)
+}
+
+// Lookup はキャッシュ済みのチャンネル情報を返し、未キャッシュのものを解決キューに積みます。
+// nameIDs は表示名が @handle で届いたため名前も解決したい channelID です。API 呼び出しは行いません。
+func (r *Resolver) Lookup(channelIDs, nameIDs []string) map[string]domain.ChannelInfo {
+	needName := make(map[string]bool, len(nameIDs))
+	for _, id := range nameIDs {
+		needName[id] = true
+	}
+
+	known := make(map[string]domain.ChannelInfo, len(channelIDs))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.queue == nil {
+		r.queue = make(map[string]*pending)
+	}
+	for _, id := range channelIDs {
+		if r.Cache != nil {
+			if info, ok := r.Cache.Get(id); ok {
+				known[id] = info
+				continue
+			}
+		}
+		if p, ok := r.queue[id]; ok {
+			p.resolveName = p.resolveName || needName[id]
+			continue
+		}
+		r.queue[id] = &pending{resolveName: needName[id]}
+	}
+	return known
+}
+
+// Pending は解決待ちの件数を返します。
+func (r *Resolver) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.queue)
+}
+
+// Run は ctx.Done まで Interval ごとに ResolvePending を呼ぶ。
+func (r *Resolver) Run(ctx context.Context) {
+	tickC := r.TickC
+	if tickC == nil {
+		interval := r.Interval
+		if interval == 0 {
+			interval = DefaultInterval
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tickC = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tickC:
+			r.ResolvePending(ctx)
+		}
+	}
+}
+
+// ResolvePending は試行可能な channelID を BatchSize ずつ解決し、User / Comment を backfill します。
+// 応答に含まれなかった channelID は backoff 後に再試行し、MaxAttempts 回失敗したら諦めます。
+// API がエラーを返したとき (quota 超過・rate limit など) は channelID ごとの失敗に数えず、
+// 未解決のキュー全体を backoff させます。
+func (r *Resolver) ResolvePending(ctx context.Context) {
+	now := r.Clock.Now()
+	due := r.takeDue(now)
+	backfilled := 0
+	for i := 0; i < len(due); i += BatchSize {
+		batch := due[i:min(i+BatchSize, len(due))]
+		n, unresolved, err := r.resolveBatch(ctx, batch, now)
+		backfilled += n
+		if err != nil {
+			r.postpone(ctx, append(unresolved, due[min(i+BatchSize, len(due)):]...), now, err)
+			break
+		}
+		r.resetOutage()
+	}
+	if backfilled > 0 && r.Snap != nil {
+		r.Snap.MarkDirty()
+	}
+}
+
+// takeDue は notBefore を過ぎた channelID を返します (キューには残したまま)。
+func (r *Resolver) takeDue(now time.Time) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []string
+	for id, p := range r.queue {
+		if !now.Before(p.notBefore) {
+			due = append(due, id)
+		}
+	}
+	return due
+}
+
+// resolveBatch は batch を解決し、backfill した User / Comment の件数を返します。
+// API がエラーを返した場合は、解決できた分だけ backfill し、未解決の channelID とエラーを返します
+// (失敗回数の加算は呼び出し側に任せる)。
+func (r *Resolver) resolveBatch(ctx context.Context, batch []string, now time.Time) (backfilled int, unresolved []string, err error) {
+	// 表示名で解決済みかを判定する (ハンドル未設定のチャンネルは GetChannelHandles の結果に含まれないため)。
+	// GetChannelHandles は同じ channels.list の結果をキャッシュから返すので追加の quota は消費しない。
+	names, nameErr := r.YT.GetChannelDisplayNames(ctx, batch)
+	if nameErr != nil {
+		logging.Log(ctx, "warn", "RESOLVER", "channel display names failed (%d ids): %v", len(batch), nameErr)
+	}
+	var handles map[string]string
+	if len(names) > 0 {
+		var handleErr error
+		if handles, handleErr = r.YT.GetChannelHandles(ctx, batch); handleErr != nil {
+			logging.Log(ctx, "warn", "RESOLVER", "channel handles failed (%d ids): %v", len(batch), handleErr)
+		}
+	}
+
+	var failed []string
+	for _, id := range batch {
+		name, ok := names[id]
+		if !ok {
+			failed = append(failed, id)
+			continue
+		}
+		p := r.remove(id)
+		if p == nil {
+			continue
+		}
+		displayName := ""
+		if p.resolveName {
+			displayName = name
+		}
+		handle := domain.NormalizeHandle(handles[id])
+		if r.Users != nil && r.Users.UpdateProfile(id, displayName, handle) {
+			backfilled++
+		}
+		if r.Comments != nil {
+			backfilled += r.Comments.UpdateAuthor(id, displayName, handle)
+		}
+	}
+
+	if nameErr != nil {
+		return backfilled, failed, nameErr
+	}
+	if len(failed) > 0 {
+		r.retryLater(ctx, failed, now)
+	}
+	return backfilled, nil, nil
+}
+
+// postpone は API エラーで解決できなかった ids の次の試行を、失敗回数を加算せずに延ばします。
+// エラーが続くたびに待ち時間を倍にします (上限 maxOutageBackoff)。
+func (r *Resolver) postpone(ctx context.Context, ids []string, now time.Time, err error) {
+	interval := r.Interval
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.outage++
+	wait := min(interval<<uint(min(r.outage, 16)), maxOutageBackoff)
+	for _, id := range ids {
+		if p, ok := r.queue[id]; ok {
+			p.notBefore = now.Add(wait)
+		}
+	}
+	logging.Log(ctx, "warn", "RESOLVER", "postponed %d channels for %s after API error: %v", len(ids), wait, err)
+}
+
+// resetOutage は API が応答したときに postpone の待ち時間を戻します。
+func (r *Resolver) resetOutage() {
+	r.mu.Lock()
+	r.outage = 0
+	r.mu.Unlock()
+}
+
+// remove はキューから id を取り出します。
+func (r *Resolver) remove(id string) *pending {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p := r.queue[id]
+	delete(r.queue, id)
+	return p
+}
+
+// retryLater は失敗回数を加算して次の試行時刻を延ばし、上限に達したものを捨てます。
+func (r *Resolver) retryLater(ctx context.Context, ids []string, now time.Time) {
+	maxAttempts := r.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	interval := r.Interval
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	dropped := 0
+	for _, id := range ids {
+		p, ok := r.queue[id]
+		if !ok {
+			continue
+		}
+		p.attempts++
+		if p.attempts >= maxAttempts {
+			delete(r.queue, id)
+			dropped++
+			continue
+		}
+		p.notBefore = now.Add(interval << uint(p.attempts)) // 2x, 4x, 8x ...
+	}
+	if dropped > 0 {
+		logging.Log(ctx, "warn", "RESOLVER", "gave up resolving %d channels after %d attempts", dropped, maxAttempts)
+	}
+}
@@ -0,0 +1,237 @@
+package resolver_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/resolver"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// fakeYT はチャット 1 ページとチャンネル解決を返す port.YouTubePort の fake。
+// failNames > 0 の間は GetChannelDisplayNames がエラーを返す。
+type fakeYT struct {
+	items     []port.ChatMessage
+	names     map[string]string
+	handles   map[string]string
+	failNames int
+
+	nameCalls  int
+	batchSizes []int
+}
+
+func (f *fakeYT) GetActiveLiveChatID(context.Context, string) (port.VideoMeta, error) {
+	return port.VideoMeta{}, nil
+}
+func (f *fakeYT) ListLiveChatMessages(context.Context, string, string) ([]port.ChatMessage, string, int64, int, bool, error) {
+	return f.items, "", 0, 0, false, nil
+}
+func (f *fakeYT) GetChannelDisplayNames(_ context.Context, ids []string) (map[string]string, error) {
+	f.nameCalls++
+	f.batchSizes = append(f.batchSizes, len(ids))
+	if f.failNames > 0 {
+		f.failNames--
+		return nil, errors.New("quota deferred")
+	}
+	out := make(map[string]string)
+	for _, id := range ids {
+		if n, ok := f.names[id]; ok {
+			out[id] = n
+		}
+	}
+	return out, nil
+}
+func (f *fakeYT) GetChannelHandles(_ context.Context, ids []string) (map[string]string, error) {
+	out := make(map[string]string)
+	for _, id := range ids {
+		if h := f.handles[id]; h != "" {
+			out[id] = h
+		}
+	}
+	return out, nil
+}
+func (f *fakeYT) GetVideoLiveDetails(context.Context, string) (port.VideoLiveDetails, error) {
+	return port.VideoLiveDetails{}, nil
+}
+
+func (f *fakeYT) FindChannelBroadcasts(context.Context, string) ([]port.Broadcast, error) {
+	return nil, nil
+}
+
+// spyCoordinator は MarkDirty の呼び出し回数を数える。
+type spyCoordinator struct {
+	snapshot.NopCoordinator
+	dirty atomic.Int32
+}
+
+func (s *spyCoordinator) MarkDirty() { s.dirty.Add(1) }
+
+// TestResolver_BackfillsAfterPull: Pull は API を呼ばずフォールバック名で保存し、
+// ResolvePending が User / Comment の表示名・ハンドルを後から埋める。
+func TestResolver_BackfillsAfterPull(t *testing.T) {
+	ctx := context.Background()
+	clock := system.NewManualClock(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC))
+	yt := &fakeYT{
+		items: []port.ChatMessage{
+			{ID: "m1", ChannelID: "UC1", DisplayName: "@alice", Message: "hi", PublishedAt: clock.Now()},
+			{ID: "m2", ChannelID: "UC2", DisplayName: "Bob", Message: "yo", PublishedAt: clock.Now()},
+		},
+		names:   map[string]string{"UC1": "Alice", "UC2": "Bob"},
+		handles: map[string]string{"UC1": "alice", "UC2": "@bob"},
+	}
+	users, comments, state := memory.NewUserRepo(), memory.NewCommentRepo(), memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, LiveChatID: "chat"})
+	snap := &spyCoordinator{}
+	res := &resolver.Resolver{YT: yt, Users: users, Comments: comments, Snap: snap, Clock: clock}
+	pull := &usecase.Pull{YT: yt, Users: users, Comments: comments, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}, Resolver: res}
+
+	if _, err := pull.Execute(ctx); err != nil {
+		t.Fatalf("Pull: %v", err)
+	}
+	if yt.nameCalls != 0 {
+		t.Errorf("Pull called GetChannelDisplayNames %d times, want 0 (async)", yt.nameCalls)
+	}
+	if c := comments.ListSortedByPublishedAt(); c[0].Handle != "" || c[1].Handle != "" {
+		t.Fatalf("comments should be stored with empty handle before resolution: %+v", c)
+	}
+	if res.Pending() != 2 {
+		t.Fatalf("Pending = %d, want 2", res.Pending())
+	}
+
+	res.ResolvePending(ctx)
+
+	if res.Pending() != 0 {
+		t.Errorf("Pending after resolve = %d", res.Pending())
+	}
+	byID := map[string]domain.User{}
+	for _, u := range users.ListUsersSortedByJoinTime() {
+		byID[u.ChannelID] = u
+	}
+	if u := byID["UC1"]; u.DisplayName != "Alice" || u.Handle != "@alice" {
+		t.Errorf("UC1 user = %+v, want Alice/@alice", u)
+	}
+	if u := byID["UC2"]; u.DisplayName != "Bob" || u.Handle != "@bob" {
+		t.Errorf("UC2 user = %+v", u)
+	}
+	for _, c := range comments.ListSortedByPublishedAt() {
+		if c.ChannelID == "UC1" && (c.DisplayName != "Alice" || c.Handle != "@alice") {
+			t.Errorf("UC1 comment = %+v", c)
+		}
+		if c.ChannelID == "UC2" && c.Handle != "@bob" {
+			t.Errorf("UC2 comment = %+v", c)
+		}
+	}
+	if snap.dirty.Load() == 0 {
+		t.Error("backfill should mark snapshot dirty")
+	}
+}
+
+func TestResolver_BatchesAcrossPulls(t *testing.T) {
+	clock := system.NewManualClock(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC))
+	yt := &fakeYT{names: map[string]string{}}
+	res := &resolver.Resolver{YT: yt, Clock: clock}
+
+	for pull := range 3 {
+		var ids []string
+		for i := range 40 {
+			id := fmt.Sprintf("UC%d-%d", pull, i)
+			ids = append(ids, id)
+			yt.names[id] = id
+		}
+		res.Lookup(ids, nil)
+	}
+	res.ResolvePending(context.Background())
+
+	if len(yt.batchSizes) != 3 {
+		t.Fatalf("calls = %v, want 3 batches for 120 ids", yt.batchSizes)
+	}
+	for _, n := range yt.batchSizes {
+		if n > resolver.BatchSize {
+			t.Errorf("batch size %d exceeds %d", n, resolver.BatchSize)
+		}
+	}
+}
+
+func TestResolver_LookupServesCacheWithoutQueueing(t *testing.T) {
+	cache := memory.NewChannelCache(0, 0, nil)
+	cache.Put(domain.ChannelInfo{ChannelID: "UC1", Title: "Alice", Handle: "@alice"})
+	res := &resolver.Resolver{YT: &fakeYT{}, Cache: cache, Clock: system.NewManualClock(time.Time{})}
+
+	known := res.Lookup([]string{"UC1", "UC2"}, []string{"UC1"})
+	if known["UC1"].Title != "Alice" {
+		t.Errorf("known = %+v", known)
+	}
+	if res.Pending() != 1 {
+		t.Errorf("Pending = %d, want 1 (only UC2)", res.Pending())
+	}
+}
+
+func TestResolver_RetriesWithBackoffThenGivesUp(t *testing.T) {
+	ctx := context.Background()
+	clock := system.NewManualClock(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC))
+	yt := &fakeYT{names: map[string]string{"UC1": "Alice"}, failNames: 1}
+	res := &resolver.Resolver{YT: yt, Clock: clock, Interval: time.Second, MaxAttempts: 2}
+	res.Lookup([]string{"UC1", "UC-deleted"}, nil)
+
+	res.ResolvePending(ctx) // 1 回目: API エラー → 失敗に数えず両方 backoff (2s)
+	if res.Pending() != 2 || yt.nameCalls != 1 {
+		t.Fatalf("after failure: pending=%d calls=%d", res.Pending(), yt.nameCalls)
+	}
+
+	clock.Advance(time.Second)
+	res.ResolvePending(ctx) // backoff 中は呼ばない
+	if yt.nameCalls != 1 {
+		t.Errorf("called during backoff: calls=%d", yt.nameCalls)
+	}
+
+	clock.Advance(time.Second)
+	res.ResolvePending(ctx) // UC1 は解決、削除済みチャンネルは 1 回目の失敗
+	if res.Pending() != 1 {
+		t.Fatalf("pending = %d, want 1 (UC-deleted)", res.Pending())
+	}
+
+	clock.Advance(time.Minute)
+	res.ResolvePending(ctx) // 2 回目の失敗で諦める
+	if res.Pending() != 0 {
+		t.Errorf("pending = %d, want 0 after MaxAttempts", res.Pending())
+	}
+}
+
+// TestResolver_OutageDoesNotUseUpAttempts: quota 超過などの API エラーが MaxAttempts 回以上続いても
+// channelID を捨てず、キュー全体を延ばして復帰後に解決する
+func TestResolver_OutageDoesNotUseUpAttempts(t *testing.T) {
+	ctx := context.Background()
+	clock := system.NewManualClock(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC))
+	yt := &fakeYT{names: map[string]string{"UC1": "Alice"}, failNames: 5}
+	users := memory.NewUserRepo()
+	_ = users.UpsertWithJoinTime("UC1", "alice", clock.Now())
+	res := &resolver.Resolver{YT: yt, Users: users, Clock: clock, Interval: time.Second, MaxAttempts: 2}
+	ids := []string{"UC1"}
+	for i := range resolver.BatchSize {
+		ids = append(ids, fmt.Sprintf("UC-x%d", i))
+	}
+	res.Lookup(ids, []string{"UC1"})
+
+	for range 5 {
+		res.ResolvePending(ctx)
+		clock.Advance(time.Hour)
+	}
+	// エラー時は残りのバッチを呼ばない
+	if yt.nameCalls != 5 || res.Pending() != len(ids) {
+		t.Fatalf("during outage: calls=%d pending=%d, want 5 calls and nothing dropped", yt.nameCalls, res.Pending())
+	}
+
+	res.ResolvePending(ctx)
+	if got := users.ListUsersSortedByJoinTime(); got[0].DisplayName != "Alice" {
+		t.Errorf("user = %+v, want resolved after the outage", got[0])
+	}
+}
@@ -0,0 +1,44 @@
+package session
+
+import "github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+
+// Backfill は解決済みのチャンネル情報を全セッションの User / Comment に反映する
+// port.UserProfileUpdater / port.CommentAuthorUpdater です。
+// チャンネル解決 (resolver.Resolver) はセッション間で共有するため、これを Users / Comments に渡す
+// (resolver の Snap は nil にし、更新したセッションの MarkDirty はここで行う)。
+type Backfill struct {
+	Registry *Registry
+}
+
+// UpdateProfile は channelID のユーザーを持つ全セッションで displayName / handle を更新します。
+// いずれかのセッションで更新したら true を返します。
+func (b *Backfill) UpdateProfile(channelID, displayName, handle string) bool {
+	updated := false
+	for _, s := range b.Registry.List() {
+		u, ok := s.Users.(port.UserProfileUpdater)
+		if !ok {
+			continue
+		}
+		if u.UpdateProfile(channelID, displayName, handle) {
+			updated = true
+			s.Coord.MarkDirty()
+		}
+	}
+	return updated
+}
+
+// UpdateAuthor は全セッションの channelID のコメントの投稿者情報を更新し、変更した合計件数を返します。
+func (b *Backfill) UpdateAuthor(channelID, displayName, handle string) int {
+	total := 0
+	for _, s := range b.Registry.List() {
+		c, ok := s.Comments.(port.CommentAuthorUpdater)
+		if !ok {
+			continue
+		}
+		if n := c.UpdateAuthor(channelID, displayName, handle); n > 0 {
+			total += n
+			s.Coord.MarkDirty()
+		}
+	}
+	return total
+}
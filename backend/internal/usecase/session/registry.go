@@ -0,0 +1,321 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+)
+
+// ListKey は既定セッション以外のセッション ID 一覧の StateBlobStore 上の保存 key です。
+const ListKey = "sessions"
+
+// Registry は ID ごとのセッションを保持し、各セッションの monitor と snapshot coordinator を駆動する。
+type Registry struct {
+	New Factory
+	// Store は任意: 設定時は Open / Close のたびに既定以外のセッション ID 一覧を ListKey に保存し、
+	// 起動時に Reopen で開き直せるようにする
+	Store port.StateBlobStore
+
+	mu       sync.RWMutex
+	sessions map[string]*entry
+	runCtx   context.Context // Start 後に開いたセッションの monitor もこの ctx で動かす
+
+	saveMu sync.Mutex // 一覧の保存を直列化する (古い一覧で上書きしない)
+}
+
+// entry は登録済みセッションと、その background goroutine の停止関数です。
+type entry struct {
+	session *Session
+	cancel  context.CancelFunc // Start 前は nil
+}
+
+// NewRegistry は newFn でセッションを組み立てる Registry を返します。
+func NewRegistry(newFn Factory) *Registry {
+	return &Registry{New: newFn, sessions: make(map[string]*entry)}
+}
+
+// Add は組み立て済みのセッション (既定セッションなど) を登録します。同じ ID があれば置き換えません。
+func (r *Registry) Add(s *Session) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.sessions[s.ID]; ok {
+		return
+	}
+	e := &entry{session: s}
+	r.sessions[s.ID] = e
+	if r.runCtx != nil {
+		r.startLocked(e)
+	}
+}
+
+// Get は id のセッションを返します。
+func (r *Registry) Get(id string) (*Session, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	return e.session, true
+}
+
+// Default は既定セッションを返します (未登録なら nil)。
+func (r *Registry) Default() *Session {
+	s, _ := r.Get(DefaultID)
+	return s
+}
+
+// Len は登録済みセッションの数を返します。
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.sessions)
+}
+
+// List は登録済みセッションを ID 順で返します (既定セッションを先頭にする)。
+func (r *Registry) List() []*Session {
+	r.mu.RLock()
+	out := make([]*Session, 0, len(r.sessions))
+	for _, e := range r.sessions {
+		out = append(out, e.session)
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(out, func(a, b *Session) int {
+		switch {
+		case a.ID == DefaultID:
+			return -1
+		case b.ID == DefaultID:
+			return 1
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return out
+}
+
+// Open は id のセッションを返し、無ければ Factory で組み立てて登録します。
+// 新規作成した場合は created=true。Start 済みなら monitor / coordinator をすぐ起動します。
+// 既定セッションが同じ videoID を追跡中 (予約・配信中・一時停止中) なら、二重に追跡しないよう conflict を返します。
+func (r *Registry) Open(ctx context.Context, id string) (s *Session, created bool, err error) {
+	if id == "" {
+		return nil, false, &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: "session id is required"}
+	}
+	if s, ok, err := r.lookupForOpen(ctx, id); ok || err != nil {
+		return s, false, err
+	}
+	if r.New == nil {
+		return nil, false, errors.New("session: factory is not configured")
+	}
+	// Factory は GCS からの読み込みなどでブロックしうるため、ロックの外で組み立てる
+	s, err = r.New(id)
+	if err != nil {
+		return nil, false, fmt.Errorf("session: open %s: %w", id, err)
+	}
+
+	r.mu.Lock()
+	// 組み立て中に同じ ID が開かれていたらそちらを返し、既定セッションの追跡も確かめ直す
+	if e, ok := r.sessions[id]; ok {
+		r.mu.Unlock()
+		return e.session, false, nil
+	}
+	if err := r.checkDefaultLocked(ctx, id); err != nil {
+		r.mu.Unlock()
+		return nil, false, err
+	}
+	e := &entry{session: s}
+	r.sessions[id] = e
+	if r.runCtx != nil {
+		r.startLocked(e)
+	}
+	n := len(r.sessions)
+	r.mu.Unlock()
+	log.Printf("[SESSION] opened session %s (sessions=%d)", id, n)
+	r.saveIDs(ctx)
+	return s, true, nil
+}
+
+// lookupForOpen は登録済みの id のセッションを返します (ok=true)。未登録でも既定セッションが
+// 追跡中なら conflict を返します。
+func (r *Registry) lookupForOpen(ctx context.Context, id string) (s *Session, ok bool, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if e, ok := r.sessions[id]; ok {
+		return e.session, true, nil
+	}
+	return nil, false, r.checkDefaultLocked(ctx, id)
+}
+
+// checkDefaultLocked は既定セッションが videoID を追跡中なら conflict を返します。r.mu を保持して呼ぶこと。
+func (r *Registry) checkDefaultLocked(ctx context.Context, videoID string) error {
+	def, ok := r.sessions[DefaultID]
+	if !ok || videoID == DefaultID {
+		return nil
+	}
+	st, err := def.session.State.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("session: default state: %w", err)
+	}
+	if (st.Status.InSession() || st.Status == domain.StatusReserved) && slices.Contains(st.VideoIDs(), videoID) {
+		return &domain.APIError{
+			Code:    domain.ErrCodeConflict,
+			Message: fmt.Sprintf("video %s is already tracked by the default session (%s)", videoID, st.Status),
+		}
+	}
+	return nil
+}
+
+// Reopen は Store に保存された既定以外のセッションを開き直します (起動時、Start 前に呼ぶ)。
+// snapshot があれば復元し、無ければ StartOrReserve で追跡し直します。
+// 開き直しに失敗したセッションも登録は残し、警告のみとします (一時的な API エラーで一覧から消さない)。
+func (r *Registry) Reopen(ctx context.Context) error {
+	ids, err := r.loadIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		s, created, err := r.Open(ctx, id)
+		if err != nil {
+			log.Printf("[WARN] session %s: reopen failed: %v", id, err)
+			continue
+		}
+		if !created {
+			continue
+		}
+		restored, err := s.Coord.RestoreFor(ctx, id)
+		if err != nil {
+			log.Printf("[WARN] session %s: snapshot restore failed: %v", id, err)
+		}
+		if restored || s.StartOrReserve == nil {
+			continue
+		}
+		if _, err := s.StartOrReserve.Execute(ctx, usecase.StartOrReserveInput{VideoID: id, Autonomous: true}); err != nil {
+			log.Printf("[WARN] session %s: start failed on reopen: %v", id, err)
+		}
+	}
+	if len(ids) > 0 {
+		log.Printf("[SESSION] reopened %d sessions", len(ids))
+	}
+	return nil
+}
+
+// loadIDs は Store に保存された既定以外のセッション ID 一覧を返します。
+func (r *Registry) loadIDs(ctx context.Context) ([]string, error) {
+	if r.Store == nil {
+		return nil, nil
+	}
+	data, err := r.Store.LoadBlob(ctx, ListKey)
+	if err != nil {
+		return nil, fmt.Errorf("session: load list: %w", err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, fmt.Errorf("session: unmarshal list: %w", err)
+	}
+	return ids, nil
+}
+
+// saveIDs は既定以外のセッション ID 一覧を Store に保存します。失敗は警告のみ (メモリ上の登録はそのまま)。
+func (r *Registry) saveIDs(ctx context.Context) {
+	if r.Store == nil {
+		return
+	}
+	r.saveMu.Lock()
+	defer r.saveMu.Unlock()
+	ids := []string{}
+	for _, s := range r.List() {
+		if s.ID != DefaultID {
+			ids = append(ids, s.ID)
+		}
+	}
+	data, err := json.Marshal(ids)
+	if err != nil {
+		log.Printf("[WARN] session list marshal failed: %v", err)
+		return
+	}
+	if err := r.Store.SaveBlob(ctx, ListKey, data); err != nil {
+		log.Printf("[WARN] session list save failed: %v", err)
+	}
+}
+
+// Close は id のセッションの monitor を止め、snapshot を flush して登録を外します。
+// 既定セッションは閉じられません (conflict)。
+func (r *Registry) Close(ctx context.Context, id string) error {
+	if id == DefaultID {
+		return &domain.APIError{Code: domain.ErrCodeConflict, Message: "the default session cannot be closed"}
+	}
+	r.mu.Lock()
+	e, ok := r.sessions[id]
+	delete(r.sessions, id)
+	r.mu.Unlock()
+	if !ok {
+		return &domain.APIError{Code: domain.ErrCodeVideoNotFound, Message: fmt.Sprintf("session %s not found", id)}
+	}
+	r.saveIDs(ctx)
+	return stop(ctx, e)
+}
+
+// Start は登録済みの全セッションの monitor と snapshot coordinator を起動し、
+// 以降に Open / Add したセッションも ctx が終わるまで同様に起動します。
+func (r *Registry) Start(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runCtx = ctx
+	for _, e := range r.sessions {
+		r.startLocked(e)
+	}
+}
+
+// Shutdown は全セッションの snapshot を flush して coordinator を止めます (graceful shutdown 用)。
+func (r *Registry) Shutdown(ctx context.Context) {
+	r.mu.Lock()
+	entries := make([]*entry, 0, len(r.sessions))
+	for _, e := range r.sessions {
+		entries = append(entries, e)
+	}
+	r.mu.Unlock()
+	for _, e := range entries {
+		if err := stop(ctx, e); err != nil {
+			log.Printf("[WARN] session %s: %v", e.session.ID, err)
+		}
+	}
+}
+
+// startLocked は e の monitor と coordinator を runCtx の子 ctx で起動します。r.mu を保持して呼ぶこと。
+func (r *Registry) startLocked(e *entry) {
+	if e.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(r.runCtx)
+	e.cancel = cancel
+	e.session.Coord.Start(ctx)
+	if e.session.Monitor != nil {
+		go e.session.Monitor.Run(ctx)
+	}
+}
+
+// stop は e の background goroutine と実行中の Pull を止め、snapshot を flush します。
+func stop(ctx context.Context, e *entry) error {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	if e.session.PullNow != nil {
+		e.session.PullNow.Stop()
+	}
+	err := e.session.Coord.Flush(ctx)
+	e.session.Coord.Stop()
+	if err != nil {
+		return fmt.Errorf("snapshot flush: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/session"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// countingCoord は Start / Flush / Stop / MarkDirty の呼び出しを数える Coordinator。
+type countingCoord struct {
+	snapshot.NopCoordinator
+	started, flushed, stopped, dirty int
+}
+
+func (c *countingCoord) Start(context.Context)       { c.started++ }
+func (c *countingCoord) Flush(context.Context) error { c.flushed++; return nil }
+func (c *countingCoord) Stop()                       { c.stopped++ }
+func (c *countingCoord) MarkDirty()                  { c.dirty++ }
+
+func newMemSession(id string) (*session.Session, error) { return memSession(id), nil }
+
+func memSession(id string) *session.Session {
+	return &session.Session{
+		ID:       id,
+		Users:    memory.NewUserRepo(),
+		Comments: memory.NewCommentRepo(),
+		State:    memory.NewStateRepo(),
+		Coord:    &countingCoord{},
+	}
+}
+
+func TestRegistry_OpenIsolatesSessions(t *testing.T) {
+	r := session.NewRegistry(newMemSession)
+	r.Add(memSession(session.DefaultID))
+
+	a, created, err := r.Open(context.Background(), "videoA")
+	if err != nil || !created {
+		t.Fatalf("Open(videoA) = created=%v err=%v, want a new session", created, err)
+	}
+	again, created, err := r.Open(context.Background(), "videoA")
+	if err != nil || created || again != a {
+		t.Errorf("second Open(videoA) = %p created=%v err=%v, want the same session", again, created, err)
+	}
+	b, _, _ := r.Open(context.Background(), "videoB")
+
+	ctx := context.Background()
+	if err := a.State.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "videoA"}); err != nil {
+		t.Fatal(err)
+	}
+	if st, _ := b.State.Get(ctx); st.VideoID != "" {
+		t.Errorf("session B state = %+v, want untouched by session A", st)
+	}
+	if st, _ := r.Default().State.Get(ctx); st.VideoID != "" {
+		t.Errorf("default state = %+v, want untouched by session A", st)
+	}
+
+	var ids []string
+	for _, s := range r.List() {
+		ids = append(ids, s.ID)
+	}
+	if want := []string{session.DefaultID, "videoA", "videoB"}; len(ids) != 3 || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] {
+		t.Errorf("List() = %v, want %v", ids, want)
+	}
+}
+
+// TestRegistry_OpenBuildsOutsideLock: Factory (GCS からの読み込みなど) の実行中も他のリクエストはセッションを引ける
+func TestRegistry_OpenBuildsOutsideLock(t *testing.T) {
+	entered, release := make(chan struct{}), make(chan struct{})
+	r := session.NewRegistry(func(id string) (*session.Session, error) {
+		close(entered)
+		<-release
+		return memSession(id), nil
+	})
+	r.Add(memSession(session.DefaultID))
+
+	opened := make(chan *session.Session)
+	go func() {
+		s, _, _ := r.Open(context.Background(), "videoA")
+		opened <- s
+	}()
+	<-entered
+
+	listed := make(chan int)
+	go func() { listed <- len(r.List()) }()
+	select {
+	case n := <-listed:
+		if n != 1 {
+			t.Errorf("List() while building = %d sessions, want only the default", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("List() blocked while the factory was running")
+	}
+
+	close(release)
+	if s := <-opened; s == nil {
+		t.Fatal("Open(videoA) returned no session")
+	}
+	if s, ok := r.Get("videoA"); !ok || s.ID != "videoA" {
+		t.Errorf("Get(videoA) = %v, %v; want the opened session", s, ok)
+	}
+}
+
+func TestRegistry_StartAndClose(t *testing.T) {
+	r := session.NewRegistry(newMemSession)
+	def := memSession(session.DefaultID)
+	r.Add(def)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r.Start(ctx)
+	if c := def.Coord.(*countingCoord); c.started != 1 {
+		t.Errorf("default coordinator started %d times, want 1", c.started)
+	}
+
+	// Start 後に開いたセッションもすぐ起動する
+	s, _, _ := r.Open(context.Background(), "videoA")
+	c := s.Coord.(*countingCoord)
+	if c.started != 1 {
+		t.Errorf("late session coordinator started %d times, want 1", c.started)
+	}
+
+	if err := r.Close(ctx, "videoA"); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if c.flushed != 1 || c.stopped != 1 {
+		t.Errorf("closed session flushed=%d stopped=%d, want 1/1", c.flushed, c.stopped)
+	}
+	if _, ok := r.Get("videoA"); ok {
+		t.Error("closed session is still registered")
+	}
+
+	var apiErr *domain.APIError
+	if err := r.Close(ctx, session.DefaultID); !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeConflict {
+		t.Errorf("Close(default) err = %v, want conflict", err)
+	}
+	if err := r.Close(ctx, "missing"); !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeVideoNotFound {
+		t.Errorf("Close(missing) err = %v, want video_not_found", err)
+	}
+}
+
+func TestBackfill_UpdatesEverySession(t *testing.T) {
+	r := session.NewRegistry(newMemSession)
+	r.Add(memSession(session.DefaultID))
+	a, _, _ := r.Open(context.Background(), "videoA")
+	b, _, _ := r.Open(context.Background(), "videoB")
+	_ = a.Users.UpsertWithJoinTime("UC1", "@old", time.Now())
+	_ = b.Users.UpsertWithJoinTime("UC2", "@other", time.Now())
+
+	bf := &session.Backfill{Registry: r}
+	if !bf.UpdateProfile("UC1", "New Name", "@new") {
+		t.Fatal("UpdateProfile = false, want true")
+	}
+	if got := a.Coord.(*countingCoord).dirty; got != 1 {
+		t.Errorf("session A MarkDirty = %d, want 1", got)
+	}
+	if got := b.Coord.(*countingCoord).dirty; got != 0 {
+		t.Errorf("session B MarkDirty = %d, want 0 (no user UC1)", got)
+	}
+	if bf.UpdateProfile("UC-missing", "x", "") {
+		t.Error("UpdateProfile for unknown channel = true, want false")
+	}
+}
+
+// restoringCoord は RestoreFor で呼ばれた videoID を記録する Coordinator。
+type restoringCoord struct {
+	countingCoord
+	restoredFor []string
+}
+
+func (c *restoringCoord) RestoreFor(_ context.Context, videoID string) (bool, error) {
+	c.restoredFor = append(c.restoredFor, videoID)
+	return true, nil
+}
+
+func TestRegistry_ReopensPersistedSessions(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewBlobStore()
+
+	r := session.NewRegistry(newMemSession)
+	r.Store = store
+	r.Add(memSession(session.DefaultID))
+	_, _, _ = r.Open(ctx, "videoA")
+	_, _, _ = r.Open(ctx, "videoB")
+	if err := r.Close(ctx, "videoB"); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// 再起動: 同じ store から開き直し、各セッションの snapshot を復元する
+	coords := map[string]*restoringCoord{}
+	restarted := session.NewRegistry(func(id string) (*session.Session, error) {
+		s := memSession(id)
+		c := &restoringCoord{}
+		coords[id] = c
+		s.Coord = c
+		return s, nil
+	})
+	restarted.Store = store
+	restarted.Add(memSession(session.DefaultID))
+	if err := restarted.Reopen(ctx); err != nil {
+		t.Fatalf("Reopen: %v", err)
+	}
+
+	var ids []string
+	for _, s := range restarted.List() {
+		ids = append(ids, s.ID)
+	}
+	if len(ids) != 2 || ids[1] != "videoA" {
+		t.Errorf("reopened sessions = %v, want default and videoA", ids)
+	}
+	if c := coords["videoA"]; c == nil || len(c.restoredFor) != 1 || c.restoredFor[0] != "videoA" {
+		t.Errorf("videoA restore = %+v, want RestoreFor(videoA)", c)
+	}
+}
+
+func TestRegistry_OpenConflictsWithDefaultSession(t *testing.T) {
+	ctx := context.Background()
+	r := session.NewRegistry(newMemSession)
+	def := memSession(session.DefaultID)
+	r.Add(def)
+	_ = def.State.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "videoA"})
+
+	var apiErr *domain.APIError
+	if _, _, err := r.Open(ctx, "videoA"); !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeConflict {
+		t.Errorf("Open(videoA) err = %v, want conflict", err)
+	}
+	if _, ok := r.Get("videoA"); ok {
+		t.Error("conflicting session was registered")
+	}
+
+	// 既定セッションが終了済みなら別セッションで開ける
+	_ = def.State.Set(ctx, domain.LiveState{Status: domain.StatusEnded, VideoID: "videoA"})
+	if _, created, err := r.Open(ctx, "videoA"); err != nil || !created {
+		t.Errorf("Open(videoA) after end = created=%v err=%v", created, err)
+	}
+}
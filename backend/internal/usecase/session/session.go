@@ -0,0 +1,59 @@
+// Package session は複数の配信を並行して追跡するためのセッション管理を提供する。
+// セッションは videoID ごとに独立した repo 一式・State・snapshot coordinator・monitor を持ち、
+// 従来の単一配信向けルートは DefaultID のセッションを対象とする。
+package session
+
+import (
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/monitor"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// DefaultID は従来のルート (/status, /pull など) が対象とする既定セッションの ID です。
+const DefaultID = "default"
+
+// Session は 1 配信分の独立した repo / State / snapshot coordinator と、それを操作する usecase 一式です。
+// 組み立ては composition root (cmd/server) の Factory が行います。
+type Session struct {
+	ID string // 既定セッションは DefaultID、それ以外は追跡対象の videoID
+
+	Users        port.UserRepo
+	Comments     port.CommentRepo
+	State        port.StateRepo
+	Reactions    port.ReactionRepo     // 任意
+	Viewers      port.ViewerRepo       // 任意: 同時視聴者数・高評価数・再生数の時系列
+	Metadata     port.MetadataRepo     // 任意: タイトル・概要欄・予定開始時刻の変更履歴
+	Reservations port.ReservationRepo  // 任意: 連続配信の予約キュー
+	Watches      port.ChannelWatchRepo // 任意: 次の配信を自動検出するチャンネル監視
+	Coord        snapshot.Coordinator
+
+	Status             *usecase.Status
+	Pull               *usecase.Pull
+	PullNow            *usecase.PullScheduler
+	SwitchVideo        *usecase.SwitchVideo
+	StartOrReserve     *usecase.StartOrReserve
+	Reset              *usecase.Reset
+	Reserve            *usecase.Reserve
+	CancelReserve      *usecase.CancelReserve
+	Pause              *usecase.Pause
+	Resume             *usecase.Resume
+	AttachStream       *usecase.AttachStream
+	DetachStream       *usecase.DetachStream
+	EnqueueReservation *usecase.EnqueueReservation
+	ReorderReservation *usecase.ReorderReservation
+	CancelReservation  *usecase.CancelReservation
+	PromoteReservation *usecase.PromoteReservation
+	WatchChannel       *usecase.WatchChannel
+	UnwatchChannel     *usecase.UnwatchChannel
+	DetectBroadcast    *usecase.DetectBroadcast
+	Spikes             *usecase.ChatSpikes
+	ReactionStats      *usecase.ReactionTimeline
+	ViewerStats        *usecase.ViewerTimeline
+	Terms              *usecase.TopTerms
+
+	Monitor *monitor.Monitor // 任意: nil なら自動 Pull / 予約監視を行わない
+}
+
+// Factory は id のセッションを組み立てます (Registry.Open から呼ばれる)。
+type Factory func(id string) (*Session, error)
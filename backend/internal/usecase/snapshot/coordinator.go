@@ -4,15 +4,20 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"sync"
 	"time"
 
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/metrics"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/analytics"
 )
 
 // Coordinator はスナップショット永続化を調整します。
 // MarkDirty で dirty フラグを立て、throttle 経過後に自動 save します。
+// sink が port.JournalSink を実装していれば、全量 save の代わりに差分を journal chunk として追記し、
+// 一定間隔 (と Flush) で base snapshot に畳み込みます。
 type Coordinator interface {
 	Restore(ctx context.Context) error
 	// RestoreFor は指定 videoID の snapshot を GCS から読み込み、in-memory repo に復元します。
@@ -36,6 +41,18 @@ type coordinator struct {
 	stateRepo   port.StateRepo
 	throttle    time.Duration
 
+	reactionRepo port.ReactionSnapshotSource // 任意 (WithReactions)
+	viewerRepo   port.ViewerSnapshotSource   // 任意 (WithViewers)
+	metadataRepo port.MetadataSnapshotSource // 任意 (WithMetadata)
+	channels     port.ChannelSnapshotSource  // 任意 (WithChannels)
+	spikeConfig  analytics.SpikeConfig
+	noCurrent    bool // WithoutCurrentPointer
+
+	journal         port.JournalSink // sink が JournalSink を実装し、repo が変更を記録できれば非 nil
+	changes         changeSources    // journal chunk に載せる変更の取り出し元
+	journalInterval time.Duration    // WithJournal
+	compactEvery    time.Duration    // WithJournal
+
 	mu           sync.Mutex
 	saveMu       sync.Mutex // save を直列化する
 	videoID      string
@@ -45,10 +62,58 @@ type coordinator struct {
 	dirty        bool
 	lastSaved    time.Time
 
+	// journal の書き込み状態 (saveMu で保護)
+	journalVideo string            // base を書き込み済み (または復元済み) の video。空なら次は全量 save
+	pending      port.JournalChunk // repo から取り出したが、まだ書き込めていない変更
+	lastState    *domain.LiveState // 書き込み済みの State
+	seq          int               // 最後に書いた chunk の Seq
+	lastBase     time.Time         // 最後に base snapshot を書いた時刻
+	unreplayed   string            // journal を読み込めず base だけ復元した video。compaction も journal の削除もしない
+	seqSynced    bool              // unreplayed の既存 chunk の最終 Seq を seq に反映済み
+
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
 }
 
+// Option は NewCoordinator の任意設定です。
+// users / comments 以外の付随データを snapshot に載せる場合に使います。
+type Option func(*coordinator)
+
+// WithReactions はリアクションの分単位カウンタを snapshot に含めて永続化・復元します。
+func WithReactions(src port.ReactionSnapshotSource) Option {
+	return func(c *coordinator) { c.reactionRepo = src }
+}
+
+// WithViewers は視聴者数のサンプル (時系列) を snapshot に含めて永続化・復元します。
+func WithViewers(src port.ViewerSnapshotSource) Option {
+	return func(c *coordinator) { c.viewerRepo = src }
+}
+
+// WithMetadata は配信タイトル・概要欄・予定開始時刻の変更履歴を snapshot に含めて永続化・復元します。
+// 履歴がある場合、snapshot の VideoTitle は最新のタイトル (配信終了時点の最終タイトル) になります。
+func WithMetadata(src port.MetadataSnapshotSource) Option {
+	return func(c *coordinator) { c.metadataRepo = src }
+}
+
+// WithChannels はチャンネルメタデータのキャッシュを snapshot に含め、復元時に warm-load します。
+// 再起動後に同じ視聴者の channels.list を再度呼ばないためのもので、video を跨いでマージされます。
+// journal を使うには src が変更を記録できる必要があり、複数の coordinator で共有するキャッシュは
+// coordinator ごとの cursor (memory.ChannelCache.NewCursor) を渡します。
+func WithChannels(src port.ChannelSnapshotSource) Option {
+	return func(c *coordinator) { c.channels = src }
+}
+
+// WithSpikeConfig は配信終了後の save で同梱する spike 検出の設定を差し替えます (既定は analytics.DefaultSpikeConfig)。
+func WithSpikeConfig(cfg analytics.SpikeConfig) Option {
+	return func(c *coordinator) { c.spikeConfig = cfg }
+}
+
+// WithoutCurrentPointer は save 時に current pointer (起動時 Restore の対象) を更新しません。
+// 既定セッション以外の並行セッションが、既定セッションの復元先を上書きしないようにするためのものです。
+func WithoutCurrentPointer() Option {
+	return func(c *coordinator) { c.noCurrent = true }
+}
+
 // NewCoordinator は coordinator を生成します。
 func NewCoordinator(
 	sink port.SnapshotSink,
@@ -56,14 +121,28 @@ func NewCoordinator(
 	cr port.CommentSnapshotSource,
 	sr port.StateRepo,
 	throttle time.Duration,
+	opts ...Option,
 ) Coordinator {
-	return &coordinator{
+	c := &coordinator{
 		sink:        sink,
 		userRepo:    ur,
 		commentRepo: cr,
 		stateRepo:   sr,
 		throttle:    throttle,
+		spikeConfig: analytics.DefaultSpikeConfig(),
+
+		journalInterval: DefaultJournalInterval,
+		compactEvery:    DefaultCompactInterval,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if js, ok := sink.(port.JournalSink); ok {
+		if cs, ok := newChangeSources(c); ok {
+			c.journal, c.changes = js, cs
+		}
+	}
+	return c
 }
 
 // Restore は起動時に current pointer を読み、snapshot を in-memory repo に復元します。
@@ -86,9 +165,8 @@ func (c *coordinator) Restore(ctx context.Context) error {
 		log.Printf("[WARN] snapshot: current.json points to %s but snapshot not found", ptr.VideoID)
 		return nil
 	}
-
-	c.userRepo.LoadFrom(port.UserSnapshot{Users: snap.Users, ProcessedMsgs: snap.ProcessedMsgs})
-	c.commentRepo.LoadFrom(snap.Comments)
+	c.replay(ctx, snap)
+	c.loadRepos(snap)
 
 	if snap.State != nil && c.stateRepo != nil {
 		if err := c.stateRepo.Set(ctx, *snap.State); err != nil {
@@ -178,8 +256,12 @@ func (c *coordinator) Start(ctx context.Context) {
 			case <-innerCtx.Done():
 				return
 			case <-ticker.C:
+				every := c.throttle
+				if c.journal != nil {
+					every = c.journalInterval
+				}
 				c.mu.Lock()
-				shouldSave := c.dirty && time.Since(c.lastSaved) > c.throttle
+				shouldSave := c.dirty && time.Since(c.lastSaved) > every
 				videoID := c.videoID
 				liveChatID := c.liveChatID
 				videoTitle := c.videoTitle
@@ -187,7 +269,7 @@ func (c *coordinator) Start(ctx context.Context) {
 				c.mu.Unlock()
 
 				if shouldSave && videoID != "" {
-					if err := c.save(innerCtx, videoID, liveChatID, videoTitle, channelTitle); err != nil {
+					if err := c.persist(innerCtx, videoID, liveChatID, videoTitle, channelTitle); err != nil {
 						log.Printf("[WARN] snapshot: background save failed: %v", err)
 						// save 失敗時は dirty を維持して次 tick で再試行
 					} else {
@@ -221,9 +303,8 @@ func (c *coordinator) RestoreFor(ctx context.Context, videoID string) (bool, err
 	if snap == nil {
 		return false, nil
 	}
-
-	c.userRepo.LoadFrom(port.UserSnapshot{Users: snap.Users, ProcessedMsgs: snap.ProcessedMsgs})
-	c.commentRepo.LoadFrom(snap.Comments)
+	c.replay(ctx, snap)
+	c.loadRepos(snap)
 
 	if snap.State != nil && c.stateRepo != nil {
 		if err := c.stateRepo.Set(ctx, *snap.State); err != nil {
@@ -242,6 +323,31 @@ func (c *coordinator) RestoreFor(ctx context.Context, videoID string) (bool, err
 	return true, nil
 }
 
+// loadRepos は snapshot の内容を in-memory repo 群に書き戻します (Restore / RestoreFor 共通)。
+func (c *coordinator) loadRepos(snap *port.Snapshot) {
+	c.userRepo.LoadFrom(port.UserSnapshot{Users: snap.Users, ProcessedMsgs: snap.ProcessedMsgs})
+	c.commentRepo.LoadFrom(snap.Comments)
+	if c.reactionRepo != nil {
+		c.reactionRepo.LoadFrom(snap.Reactions)
+	}
+	if c.viewerRepo != nil {
+		c.viewerRepo.LoadFrom(snap.Viewers)
+	}
+	if c.metadataRepo != nil {
+		c.metadataRepo.LoadFrom(snap.Metadata)
+	}
+	if c.channels != nil {
+		c.channels.LoadFrom(snap.Channels)
+	}
+	if c.journal != nil {
+		// repo の内容は sink の内容と同じになったため、LoadFrom の記録は chunk に載せない
+		c.saveMu.Lock()
+		c.changes.take()
+		c.pending = port.JournalChunk{}
+		c.saveMu.Unlock()
+	}
+}
+
 // LastSavedAt は最終 save 成功時刻を返します。zero は未保存を意味します。
 // Restore で復元した snap.SavedAt も「最終 save 時刻」として扱い、起動直後の
 // background save throttle 起点にもなります (memory == GCS 状態のため即 save 不要)。
@@ -253,23 +359,42 @@ func (c *coordinator) LastSavedAt() time.Time {
 
 // save は snapshot を組み立てて sink に書き込みます。
 // saveMu で直列化し、並列 save による上書き race を防ぎます。
+// journal を読み込めなかった video は、残っている chunk を base で上書き・削除しないよう追記だけにします。
 func (c *coordinator) save(ctx context.Context, videoID, liveChatID, videoTitle, channelTitle string) error {
 	c.saveMu.Lock()
 	defer c.saveMu.Unlock()
+	if c.journal != nil && c.unreplayed == videoID {
+		if err := c.appendLocked(ctx, videoID, liveChatID, videoTitle, channelTitle); err != nil {
+			return err
+		}
+		return c.saveCurrentLocked(ctx, videoID)
+	}
+	return c.saveLocked(ctx, videoID, liveChatID, videoTitle, channelTitle)
+}
 
-	userSnap := c.userRepo.Dump()
-	comments := c.commentRepo.Dump()
-
-	var liveState *domain.LiveState
-	if c.stateRepo != nil {
-		st, err := c.stateRepo.Get(ctx)
+// saveLocked は save の本体です。saveMu を保持して呼ぶこと。
+// journal を使う場合は base snapshot に畳み込んだ chunk を削除し、以降の差分の起点にします。
+func (c *coordinator) saveLocked(ctx context.Context, videoID, liveChatID, videoTitle, channelTitle string) (err error) {
+	start := time.Now()
+	defer func() {
+		metrics.SnapshotSaveDuration.Observe(time.Since(start).Seconds())
 		if err != nil {
-			log.Printf("[WARN] snapshot: state.Get failed, saving without state: %v", err)
-		} else {
-			liveState = &st
+			metrics.SnapshotSaveFailures.Inc()
 		}
+	}()
+
+	if c.journal != nil {
+		// base に含まれる変更を捨てる。Dump より先に取り出すので、並行する書き込みは次の chunk に載る
+		c.changes.take()
+		c.pending = port.JournalChunk{}
+		c.journalVideo = "" // save に失敗したら次も全量 save する
 	}
 
+	userSnap := c.userRepo.Dump()
+	comments := c.commentRepo.Dump()
+
+	liveState := c.currentState(ctx)
+
 	snap := &port.Snapshot{
 		SchemaVersion: 1,
 		VideoID:       videoID,
@@ -282,11 +407,53 @@ func (c *coordinator) save(ctx context.Context, videoID, liveChatID, videoTitle,
 		ProcessedMsgs: userSnap.ProcessedMsgs,
 		State:         liveState,
 	}
+	if c.reactionRepo != nil {
+		snap.Reactions = c.reactionRepo.Dump()
+	}
+	if c.viewerRepo != nil {
+		snap.Viewers = c.viewerRepo.Dump()
+	}
+	if c.metadataRepo != nil {
+		snap.Metadata = c.metadataRepo.Dump()
+		// 復元後は videoTitle を持たないため、履歴の最新タイトルを優先する
+		if n := len(snap.Metadata); n > 0 && snap.Metadata[n-1].Title != "" {
+			snap.VideoTitle = snap.Metadata[n-1].Title
+		}
+	}
+	if c.channels != nil {
+		snap.Channels = c.channels.Dump()
+	}
+
+	// 配信終了済み (ENDED) の場合のみ spike を確定させて同梱する
+	if liveState != nil && liveState.Status == domain.StatusEnded {
+		snap.Spikes = analytics.DetectSpikes(comments, liveState.SpikeOrigin(), videoID, c.spikeConfig)
+	}
+
+	if c.journal != nil {
+		snap.JournalSeq = c.seq
+	}
 
 	if err := c.sink.Save(ctx, snap); err != nil {
 		return fmt.Errorf("save snapshot: %w", err)
 	}
+	if c.journal != nil {
+		c.journalVideo = videoID
+		c.lastState = liveState
+		c.lastBase = time.Now()
+		// 残っても Seq で読み飛ばされるため、削除の失敗は警告のみ
+		if err := c.journal.DeleteJournal(ctx, videoID); err != nil {
+			log.Printf("[WARN] snapshot: delete compacted journal %s failed: %v", videoID, err)
+		}
+	}
 
+	return c.saveCurrentLocked(ctx, videoID)
+}
+
+// saveCurrentLocked は current pointer を videoID に更新します (WithoutCurrentPointer なら何もしない)。saveMu を保持して呼ぶこと。
+func (c *coordinator) saveCurrentLocked(ctx context.Context, videoID string) error {
+	if c.noCurrent {
+		return nil
+	}
 	ptr := &port.CurrentPointer{VideoID: videoID, SavedAt: time.Now()}
 	if err := c.sink.SaveCurrent(ctx, ptr); err != nil {
 		return fmt.Errorf("save current pointer: %w", err)
@@ -295,6 +462,140 @@ func (c *coordinator) save(ctx context.Context, videoID, liveChatID, videoTitle,
 	return nil
 }
 
+// currentState は save 時点の State を返します。取得できなければ nil (State なしで保存する)。
+func (c *coordinator) currentState(ctx context.Context) *domain.LiveState {
+	if c.stateRepo == nil {
+		return nil
+	}
+	st, err := c.stateRepo.Get(ctx)
+	if err != nil {
+		log.Printf("[WARN] snapshot: state.Get failed, saving without state: %v", err)
+		return nil
+	}
+	return &st
+}
+
+// persist は background save の 1 回分です。journal を使う場合は差分の追記、使わない場合は全量 save します。
+func (c *coordinator) persist(ctx context.Context, videoID, liveChatID, videoTitle, channelTitle string) error {
+	if c.journal == nil {
+		return c.save(ctx, videoID, liveChatID, videoTitle, channelTitle)
+	}
+	return c.appendJournal(ctx, videoID, liveChatID, videoTitle, channelTitle)
+}
+
+// appendJournal は repo が記録した変更を journal chunk として追記します。
+// base が無い (video 切替直後など)・compaction の時期・削除を伴う変更 (Clear) のときは全量 save します。
+func (c *coordinator) appendJournal(ctx context.Context, videoID, liveChatID, videoTitle, channelTitle string) error {
+	c.saveMu.Lock()
+	defer c.saveMu.Unlock()
+
+	if c.unreplayed != videoID && (c.journalVideo != videoID || time.Since(c.lastBase) >= c.compactEvery) {
+		return c.saveLocked(ctx, videoID, liveChatID, videoTitle, channelTitle)
+	}
+	return c.appendLocked(ctx, videoID, liveChatID, videoTitle, channelTitle)
+}
+
+// appendLocked は appendJournal の本体です。saveMu を保持して呼ぶこと。
+// 追記に失敗した変更は pending に残し、次の chunk にまとめて書きます。
+func (c *coordinator) appendLocked(ctx context.Context, videoID, liveChatID, videoTitle, channelTitle string) error {
+	changes, reset := c.changes.take()
+	mergeChunk(&c.pending, changes)
+	if reset {
+		if c.unreplayed != videoID {
+			return c.saveLocked(ctx, videoID, liveChatID, videoTitle, channelTitle)
+		}
+		// 読み込めなかった chunk は base で上書きできないため、削除は表せないが現在の全内容を追記して失わないようにする
+		mergeChunk(&c.pending, c.dumpChunk())
+	}
+	if c.unreplayed == videoID && !c.seqSynced {
+		// 既存の chunk を同じ Seq で上書きしないよう、その最終 Seq より後から書く
+		chunks, err := c.journal.LoadJournal(ctx, videoID)
+		if err != nil {
+			return fmt.Errorf("load journal %s for seq: %w", videoID, err)
+		}
+		if n := len(chunks); n > 0 {
+			c.seq = max(c.seq, chunks[n-1].Seq)
+		}
+		c.seqSynced = true
+	}
+	if st := c.currentState(ctx); st != nil && (c.lastState == nil || !reflect.DeepEqual(*st, *c.lastState)) {
+		c.pending.State = st
+	}
+	if emptyChunk(c.pending) {
+		return nil
+	}
+
+	chunk := c.pending
+	chunk.VideoID = videoID
+	chunk.Seq = c.seq + 1
+	chunk.WrittenAt = time.Now()
+	if err := c.journal.AppendJournal(ctx, &chunk); err != nil {
+		metrics.SnapshotJournalWrites.Inc("error")
+		return fmt.Errorf("append journal: %w", err)
+	}
+	metrics.SnapshotJournalWrites.Inc("ok")
+	c.seq = chunk.Seq
+	if chunk.State != nil {
+		c.lastState = chunk.State
+	}
+	c.pending = port.JournalChunk{}
+	return nil
+}
+
+// dumpChunk は repo の現在の全内容を chunk として返します。
+func (c *coordinator) dumpChunk() port.JournalChunk {
+	userSnap := c.userRepo.Dump()
+	chunk := port.JournalChunk{Users: userSnap.Users, Comments: c.commentRepo.Dump(), ProcessedMsgs: userSnap.ProcessedMsgs}
+	if c.reactionRepo != nil {
+		chunk.Reactions = c.reactionRepo.Dump()
+	}
+	if c.viewerRepo != nil {
+		chunk.Viewers = c.viewerRepo.Dump()
+	}
+	if c.metadataRepo != nil {
+		chunk.Metadata = c.metadataRepo.Dump()
+	}
+	if c.channels != nil {
+		chunk.Channels = c.channels.Dump()
+	}
+	return chunk
+}
+
+// replay は journal を使う場合、snap にまだ取り込まれていない chunk を適用し、journal の書き込み状態を snap に合わせます。
+// journal を読み込めなければ base だけで復元し、その video では残っている chunk を compaction で上書き・削除せず、
+// 以後の変更を既存の chunk の後ろに追記します (次に replay できたときにまとめて取り込まれる)。
+func (c *coordinator) replay(ctx context.Context, snap *port.Snapshot) {
+	if c.journal == nil {
+		return
+	}
+	chunks, err := c.journal.LoadJournal(ctx, snap.VideoID)
+
+	c.saveMu.Lock()
+	defer c.saveMu.Unlock()
+	c.seq = max(c.seq, snap.JournalSeq)
+	c.lastState = snap.State
+	c.pending = port.JournalChunk{}
+	if err != nil {
+		log.Printf("[WARN] snapshot: load journal %s failed, restoring base only and keeping the journal: %v", snap.VideoID, err)
+		c.unreplayed, c.seqSynced = snap.VideoID, false
+		c.journalVideo = ""
+		return
+	}
+
+	baseSeq := snap.JournalSeq
+	lastSeq := replayJournal(snap, chunks)
+	if lastSeq > baseSeq {
+		log.Printf("[INFO] snapshot: replayed journal videoId=%s seq=%d..%d", snap.VideoID, baseSeq+1, lastSeq)
+	}
+	if c.unreplayed == snap.VideoID {
+		c.unreplayed = ""
+	}
+	c.journalVideo = snap.VideoID
+	c.lastState = snap.State
+	c.seq = max(c.seq, lastSeq)
+	c.lastBase = snap.SavedAt
+}
+
 // NopCoordinator は GCS_BUCKET が空の場合に使う no-op 実装です。
 type NopCoordinator struct{}
 
@@ -246,6 +246,162 @@ func TestSave_includesState(t *testing.T) {
 	}
 }
 
+// TestSave_endedStreamIncludesSpikes: 配信終了後 (EndedAt あり) の save のみ spikes を同梱する
+func TestSave_endedStreamIncludesSpikes(t *testing.T) {
+	ctx := context.Background()
+	sink := newFakeSink()
+	ur, cr := newTestRepos()
+	sr := memory.NewStateRepo()
+
+	startedAt := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 20; i++ {
+		_ = cr.Add(domain.Comment{ID: fmt.Sprintf("b%d", i), Message: "hi", PublishedAt: startedAt.Add(time.Duration(i) * 30 * time.Second)})
+	}
+	for i := 0; i < 30; i++ {
+		_ = cr.Add(domain.Comment{ID: fmt.Sprintf("s%d", i), Message: "草", PublishedAt: startedAt.Add(5*time.Minute + time.Duration(i)*500*time.Millisecond)})
+	}
+
+	c := snapshot.NewCoordinator(sink, ur, cr, sr, 30*time.Second)
+	c.SetVideo("vid-spike", "chat", "", "")
+
+	// ACTIVE 中は spikes なし
+	_ = sr.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "vid-spike", StartedAt: startedAt})
+	if err := c.Flush(ctx); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if saved, _ := sink.Load(ctx, "vid-spike"); len(saved.Spikes) != 0 {
+		t.Errorf("active snapshot Spikes = %v, want none", saved.Spikes)
+	}
+
+	// 終了後は spikes を同梱
+	_ = sr.Set(ctx, domain.LiveState{Status: domain.StatusEnded, VideoID: "vid-spike", StartedAt: startedAt, EndedAt: startedAt.Add(time.Hour)})
+	if err := c.Flush(ctx); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	saved, _ := sink.Load(ctx, "vid-spike")
+	if len(saved.Spikes) != 1 || saved.Spikes[0].OffsetSec != 300 {
+		t.Errorf("ended snapshot Spikes = %+v, want one spike at 300s", saved.Spikes)
+	}
+}
+
+// TestWithReactions_roundTrip: WithReactions 指定時は reactions が save され、RestoreFor で復元される
+func TestWithReactions_roundTrip(t *testing.T) {
+	sink := newFakeSink()
+	ur, cr := newTestRepos()
+	rr := memory.NewReactionRepo()
+	at := time.Date(2026, 6, 1, 12, 0, 30, 0, time.UTC)
+	rr.Record(at, []string{"laugh", "applause"})
+
+	c := snapshot.NewCoordinator(sink, ur, cr, nil, 30*time.Second, snapshot.WithReactions(rr))
+	c.SetVideo("vid-react", "chat-react", "", "")
+	c.MarkDirty()
+	if err := c.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	saved, _ := sink.Load(context.Background(), "vid-react")
+	if saved == nil || len(saved.Reactions) != 1 || saved.Reactions[0].Counts["laugh"] != 1 {
+		t.Fatalf("saved reactions = %+v", saved)
+	}
+
+	rr.Clear()
+	restored, err := c.RestoreFor(context.Background(), "vid-react")
+	if err != nil || !restored {
+		t.Fatalf("RestoreFor = %v, %v", restored, err)
+	}
+	timeline := rr.Timeline()
+	if len(timeline) != 1 || timeline[0].Counts["applause"] != 1 {
+		t.Errorf("restored timeline = %+v", timeline)
+	}
+}
+
+// TestWithViewers_roundTrip: WithViewers 指定時は視聴者数の時系列が save され、RestoreFor で復元される
+func TestWithViewers_roundTrip(t *testing.T) {
+	sink := newFakeSink()
+	ur, cr := newTestRepos()
+	vr := memory.NewViewerRepo()
+	at := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	vr.Record(domain.ViewerSample{At: at, ConcurrentViewers: 120, LikeCount: 8, ViewCount: 300})
+
+	c := snapshot.NewCoordinator(sink, ur, cr, nil, 30*time.Second, snapshot.WithViewers(vr))
+	c.SetVideo("vid-viewers", "chat-viewers", "", "")
+	c.MarkDirty()
+	if err := c.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	saved, _ := sink.Load(context.Background(), "vid-viewers")
+	if saved == nil || len(saved.Viewers) != 1 || saved.Viewers[0].ConcurrentViewers != 120 {
+		t.Fatalf("saved viewers = %+v", saved)
+	}
+
+	vr.Clear()
+	restored, err := c.RestoreFor(context.Background(), "vid-viewers")
+	if err != nil || !restored {
+		t.Fatalf("RestoreFor = %v, %v", restored, err)
+	}
+	if samples := vr.Samples(); len(samples) != 1 || !samples[0].At.Equal(at) || samples[0].LikeCount != 8 {
+		t.Errorf("restored samples = %+v", samples)
+	}
+}
+
+// TestWithMetadata_finalTitle: WithMetadata 指定時はメタデータ履歴が save され、VideoTitle は最新のタイトルになる
+func TestWithMetadata_finalTitle(t *testing.T) {
+	sink := newFakeSink()
+	ur, cr := newTestRepos()
+	mr := memory.NewMetadataRepo()
+	at := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	mr.Record(domain.MetadataRevision{At: at, Title: "【雑談】朝活", DescriptionHash: "aaaa"})
+	mr.Record(domain.MetadataRevision{At: at.Add(time.Hour), Title: "【雑談】朝活 → 歌枠", DescriptionHash: "aaaa"})
+
+	c := snapshot.NewCoordinator(sink, ur, cr, nil, 30*time.Second, snapshot.WithMetadata(mr))
+	c.SetVideo("vid-meta", "chat-meta", "【雑談】朝活", "")
+	c.MarkDirty()
+	if err := c.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	saved, _ := sink.Load(context.Background(), "vid-meta")
+	if saved == nil || len(saved.Metadata) != 2 || saved.VideoTitle != "【雑談】朝活 → 歌枠" {
+		t.Fatalf("saved = %+v, want 2 revisions and the final title", saved)
+	}
+
+	mr.Clear()
+	restored, err := c.RestoreFor(context.Background(), "vid-meta")
+	if err != nil || !restored {
+		t.Fatalf("RestoreFor = %v, %v", restored, err)
+	}
+	if revs := mr.Revisions(); len(revs) != 2 || !revs[0].At.Equal(at) {
+		t.Errorf("restored revisions = %+v", revs)
+	}
+}
+
+// TestWithChannels_warmLoadOnRestore: WithChannels 指定時はチャンネルキャッシュが save され、
+// 再起動相当の新しいキャッシュに Restore で warm-load される
+func TestWithChannels_warmLoadOnRestore(t *testing.T) {
+	sink := newFakeSink()
+	ur, cr := newTestRepos()
+	cache := memory.NewChannelCache(0, 0, nil)
+	cache.Put(domain.ChannelInfo{ChannelID: "UC1", Title: "User1", Handle: "@user1", AvatarURL: "https://example.com/a.jpg"})
+
+	c := snapshot.NewCoordinator(sink, ur, cr, nil, 30*time.Second, snapshot.WithChannels(cache))
+	c.SetVideo("vid-ch", "chat-ch", "", "")
+	c.MarkDirty()
+	if err := c.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	restartedCache := memory.NewChannelCache(0, 0, nil)
+	restarted := snapshot.NewCoordinator(sink, ur, cr, nil, 30*time.Second, snapshot.WithChannels(restartedCache))
+	if err := restarted.Restore(context.Background()); err != nil {
+		t.Fatalf("Restore returned error: %v", err)
+	}
+	info, ok := restartedCache.Get("UC1")
+	if !ok || info.Handle != "@user1" || info.AvatarURL != "https://example.com/a.jpg" {
+		t.Errorf("warm-loaded channel = %+v, %v", info, ok)
+	}
+}
+
 // TestRestore_noCurrent: current.json なし → 空 state で続行（エラーなし）
 func TestRestore_noCurrent(t *testing.T) {
 	t.Helper()
@@ -636,3 +792,24 @@ func TestRestoreFor_DoesNotUpdateLastSavedAt(t *testing.T) {
 		t.Errorf("LastSavedAt should be zero after RestoreFor (not startup Restore), got %v", savedAt)
 	}
 }
+
+// TestWithoutCurrentPointer_keepsDefaultPointer: 並行セッションの save は current pointer を動かさない
+func TestWithoutCurrentPointer_keepsDefaultPointer(t *testing.T) {
+	sink := newFakeSink()
+	sink.current = &port.CurrentPointer{VideoID: "default-vid"}
+	ur, cr := newTestRepos()
+
+	c := snapshot.NewCoordinator(sink, ur, cr, nil, time.Minute, snapshot.WithoutCurrentPointer())
+	c.SetVideo("session-vid", "chat", "", "")
+	c.MarkDirty()
+	if err := c.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if sink.getSaveCount() != 1 {
+		t.Errorf("saves = %d, want 1", sink.getSaveCount())
+	}
+	if ptr, _ := sink.LoadCurrent(context.Background()); ptr.VideoID != "default-vid" {
+		t.Errorf("current = %q, want default-vid untouched", ptr.VideoID)
+	}
+}
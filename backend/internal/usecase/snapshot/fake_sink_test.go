@@ -102,3 +102,60 @@ func (f *fakeSink) setLoadError(err error) {
 	defer f.mu.Unlock()
 	f.loadError = err
 }
+
+// fakeJournalSink は port.JournalSink も実装する fakeSink です。
+type fakeJournalSink struct {
+	*fakeSink
+	chunks      map[string][]port.JournalChunk // videoID -> 追記順
+	appends     int
+	loadJournal error // nil でない場合、LoadJournal でこのエラーを返す
+}
+
+func newFakeJournalSink() *fakeJournalSink {
+	return &fakeJournalSink{fakeSink: newFakeSink(), chunks: make(map[string][]port.JournalChunk)}
+}
+
+func (f *fakeJournalSink) AppendJournal(_ context.Context, chunk *port.JournalChunk) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.forceError != nil {
+		return f.forceError
+	}
+	f.chunks[chunk.VideoID] = append(f.chunks[chunk.VideoID], *chunk)
+	f.appends++
+	return nil
+}
+
+func (f *fakeJournalSink) LoadJournal(_ context.Context, videoID string) ([]port.JournalChunk, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.loadJournal != nil {
+		return nil, f.loadJournal
+	}
+	return append([]port.JournalChunk{}, f.chunks[videoID]...), nil
+}
+
+func (f *fakeJournalSink) DeleteJournal(_ context.Context, videoID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.chunks, videoID)
+	return nil
+}
+
+func (f *fakeJournalSink) journal(videoID string) []port.JournalChunk {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]port.JournalChunk{}, f.chunks[videoID]...)
+}
+
+func (f *fakeJournalSink) getAppends() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.appends
+}
+
+func (f *fakeJournalSink) setLoadJournalError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.loadJournal = err
+}
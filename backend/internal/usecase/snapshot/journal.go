@@ -0,0 +1,191 @@
+package snapshot
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+const (
+	// DefaultJournalInterval は dirty なときに journal chunk を書き込む間隔の既定値です。
+	DefaultJournalInterval = 10 * time.Second
+	// DefaultCompactInterval は journal を base snapshot に畳み込む (全量 save する) 間隔の既定値です。
+	DefaultCompactInterval = 10 * time.Minute
+)
+
+// WithJournal は journal chunk の書き込み間隔と compaction の間隔を差し替えます (0 なら既定値)。
+// journal は sink が port.JournalSink を実装し、snapshot に載せる repo がすべて変更を記録できる
+// (port.UserChangeSource / port.ChangeSource) ときだけ使われ、そうでなければ従来どおり throttle ごとに全量 save します。
+func WithJournal(interval, compactEvery time.Duration) Option {
+	return func(c *coordinator) {
+		if interval > 0 {
+			c.journalInterval = interval
+		}
+		if compactEvery > 0 {
+			c.compactEvery = compactEvery
+		}
+	}
+}
+
+// changeSources は journal chunk に載せる変更の取り出し元です。付随データは設定されているものだけ非 nil です。
+type changeSources struct {
+	users     port.UserChangeSource
+	comments  port.ChangeSource[domain.Comment]
+	reactions port.ChangeSource[domain.ReactionMinute]
+	viewers   port.ChangeSource[domain.ViewerSample]
+	metadata  port.ChangeSource[domain.MetadataRevision]
+	channels  port.ChangeSource[domain.ChannelInfo]
+}
+
+// newChangeSources は c の repo がすべて変更を記録できるときだけ ok=true を返します。
+func newChangeSources(c *coordinator) (s changeSources, ok bool) {
+	if s.users, ok = c.userRepo.(port.UserChangeSource); !ok {
+		return s, false
+	}
+	ok = asChangeSource(c.commentRepo, &s.comments) &&
+		asChangeSource(c.reactionRepo, &s.reactions) &&
+		asChangeSource(c.viewerRepo, &s.viewers) &&
+		asChangeSource(c.metadataRepo, &s.metadata) &&
+		asChangeSource(c.channels, &s.channels)
+	return s, ok
+}
+
+// asChangeSource は src が未設定 (nil) なら true を、変更を記録できれば dst に設定して true を返します。
+func asChangeSource[T any](src any, dst *port.ChangeSource[T]) bool {
+	if src == nil {
+		return true
+	}
+	cs, ok := src.(port.ChangeSource[T])
+	*dst = cs
+	return ok
+}
+
+// take は各 repo が前回の取り出し以降に記録した変更を chunk にまとめます。
+// Clear / LoadFrom で置き換えられた repo があれば reset=true を返し、呼び出し側は全量 save します。
+func (s changeSources) take() (chunk port.JournalChunk, reset bool) {
+	uc := s.users.TakeChanges()
+	chunk.Users, chunk.ProcessedMsgs, reset = uc.Users, uc.ProcessedMsgs, uc.Reset
+	reset = takeInto(s.comments, &chunk.Comments) || reset
+	reset = takeInto(s.reactions, &chunk.Reactions) || reset
+	reset = takeInto(s.viewers, &chunk.Viewers) || reset
+	reset = takeInto(s.metadata, &chunk.Metadata) || reset
+	reset = takeInto(s.channels, &chunk.Channels) || reset
+	return chunk, reset
+}
+
+func takeInto[T any](src port.ChangeSource[T], dst *[]T) (reset bool) {
+	if src == nil {
+		return false
+	}
+	changes := src.TakeChanges()
+	*dst = changes.Items
+	return changes.Reset
+}
+
+// mergeChunk は src の変更を dst に重ねます。同じ key の要素は src の値で置き換えます。
+// 追記に失敗した変更の持ち越しと、replay で複数の chunk をまとめるのに使います。
+func mergeChunk(dst *port.JournalChunk, src port.JournalChunk) {
+	dst.Users = mergeByKey(dst.Users, src.Users, func(u domain.User) string { return u.ChannelID })
+	dst.Comments = mergeByKey(dst.Comments, src.Comments, func(c domain.Comment) string { return c.ID })
+	dst.ProcessedMsgs = mergeByKey(dst.ProcessedMsgs, src.ProcessedMsgs, func(id string) string { return id })
+	if src.State != nil {
+		dst.State = src.State
+	}
+	dst.Reactions = mergeByKey(dst.Reactions, src.Reactions, func(m domain.ReactionMinute) int64 { return m.Minute.Unix() })
+	// compaction の直前に記録されたサンプル・履歴は base と次の chunk の両方に載りうるため、At で重複を除く
+	dst.Viewers = mergeByKey(dst.Viewers, src.Viewers, func(v domain.ViewerSample) int64 { return v.At.UnixNano() })
+	dst.Metadata = mergeByKey(dst.Metadata, src.Metadata, func(m domain.MetadataRevision) int64 { return m.At.UnixNano() })
+	dst.Channels = mergeByKey(dst.Channels, src.Channels, func(c domain.ChannelInfo) string { return c.ChannelID })
+}
+
+// mergeByKey は dst に src を重ね、同じ key の要素は置き換え、新しい要素は末尾に追加します。
+func mergeByKey[T any, K comparable](dst, src []T, key func(T) K) []T {
+	if len(src) == 0 {
+		return dst
+	}
+	index := make(map[K]int, len(dst)+len(src))
+	for i, v := range dst {
+		index[key(v)] = i
+	}
+	for _, v := range src {
+		if i, ok := index[key(v)]; ok {
+			dst[i] = v
+			continue
+		}
+		index[key(v)] = len(dst)
+		dst = append(dst, v)
+	}
+	return dst
+}
+
+// emptyChunk は chunk に書く差分が無いかを返します。
+func emptyChunk(chunk port.JournalChunk) bool {
+	return len(chunk.Users) == 0 && len(chunk.Comments) == 0 && len(chunk.ProcessedMsgs) == 0 && chunk.State == nil &&
+		len(chunk.Reactions) == 0 && len(chunk.Viewers) == 0 && len(chunk.Metadata) == 0 && len(chunk.Channels) == 0
+}
+
+// Load は videoID の base snapshot を読み込み、sink が journal に対応していれば未取り込みの chunk を適用して返します。
+// 履歴の閲覧など repo に復元しない読み取り用です。journal を読み込めなければ base だけを返します。
+// snapshot が存在しない場合は nil を返します。
+func Load(ctx context.Context, sink port.SnapshotSink, videoID string) (*port.Snapshot, error) {
+	snap, err := sink.Load(ctx, videoID)
+	if err != nil || snap == nil {
+		return snap, err
+	}
+	journal, ok := sink.(port.JournalSink)
+	if !ok {
+		return snap, nil
+	}
+	chunks, err := journal.LoadJournal(ctx, videoID)
+	if err != nil {
+		log.Printf("[WARN] snapshot: load journal %s failed, returning base only: %v", videoID, err)
+		return snap, nil
+	}
+	replayJournal(snap, chunks)
+	return snap, nil
+}
+
+// replayJournal は base snapshot に、まだ取り込まれていない (Seq > snap.JournalSeq) chunk を順に適用し、
+// 適用後の最終 Seq を返します。compaction 後に削除し損ねた古い chunk は Seq で読み飛ばします。
+func replayJournal(snap *port.Snapshot, chunks []port.JournalChunk) int {
+	lastSeq := snap.JournalSeq
+	var merged port.JournalChunk
+	applied := 0
+	for _, chunk := range chunks {
+		if chunk.Seq <= lastSeq {
+			continue
+		}
+		mergeChunk(&merged, chunk)
+		lastSeq = chunk.Seq
+		applied++
+	}
+	if applied == 0 {
+		return lastSeq
+	}
+
+	base := port.JournalChunk{
+		Users:         snap.Users,
+		Comments:      snap.Comments,
+		ProcessedMsgs: snap.ProcessedMsgs,
+		State:         snap.State,
+		Reactions:     snap.Reactions,
+		Viewers:       snap.Viewers,
+		Metadata:      snap.Metadata,
+		Channels:      snap.Channels,
+	}
+	mergeChunk(&base, merged)
+	snap.Users, snap.Comments, snap.ProcessedMsgs, snap.State = base.Users, base.Comments, base.ProcessedMsgs, base.State
+	snap.Reactions, snap.Viewers, snap.Channels = base.Reactions, base.Viewers, base.Channels
+	// 履歴は古い順で、MetadataRepo と同じ上限で切り詰める
+	slices.SortStableFunc(base.Metadata, func(a, b domain.MetadataRevision) int { return a.At.Compare(b.At) })
+	if n := len(base.Metadata); n > domain.MaxMetadataRevisions {
+		base.Metadata = base.Metadata[n-domain.MaxMetadataRevisions:]
+	}
+	snap.Metadata = base.Metadata
+	snap.JournalSeq = lastSeq
+	return lastSeq
+}
@@ -0,0 +1,276 @@
+package snapshot_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// TestJournal_appendsOnlyDelta: JournalSink 対応の sink では background save が差分だけを chunk として追記し、
+// base snapshot は書き換えない。Flush で base に畳み込まれ、journal は削除される
+func TestJournal_appendsOnlyDelta(t *testing.T) {
+	sink := newFakeJournalSink()
+	ur, cr := newTestRepos()
+	at := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	_, _ = ur.UpsertWithMessageUpdated("UC1", "User1", at, "m1")
+	_ = cr.Add(domain.Comment{ID: "m1", ChannelID: "UC1", Message: "こんにちは", PublishedAt: at})
+
+	c := snapshot.NewCoordinator(sink, ur, cr, nil, time.Hour, snapshot.WithJournal(time.Millisecond, time.Hour))
+	c.SetVideo("vid-j", "chat-j", "", "")
+	if err := c.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	_, _ = ur.UpsertWithMessageUpdated("UC2", "User2", at.Add(time.Minute), "m2")
+	_ = cr.Add(domain.Comment{ID: "m2", ChannelID: "UC2", Message: "初見です", PublishedAt: at.Add(time.Minute)})
+	c.MarkDirty()
+	c.Start(context.Background())
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) && sink.getAppends() < 1 {
+		time.Sleep(50 * time.Millisecond)
+	}
+	c.Stop()
+
+	if got := sink.getSaveCount(); got != 1 {
+		t.Errorf("base saves = %d, want 1 (background save must append instead of rewriting)", got)
+	}
+	chunks := sink.journal("vid-j")
+	if len(chunks) != 1 {
+		t.Fatalf("journal = %+v, want 1 chunk", chunks)
+	}
+	ch := chunks[0]
+	if ch.Seq != 1 || len(ch.Comments) != 1 || ch.Comments[0].ID != "m2" || len(ch.Users) != 1 || ch.Users[0].ChannelID != "UC2" ||
+		len(ch.ProcessedMsgs) != 1 || ch.ProcessedMsgs[0] != "m2" {
+		t.Errorf("chunk = %+v, want only m2 / UC2", ch)
+	}
+
+	if err := c.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	saved, _ := sink.Load(context.Background(), "vid-j")
+	if saved == nil || len(saved.Comments) != 2 || saved.JournalSeq != 1 {
+		t.Errorf("compacted snapshot = %+v, want 2 comments and journalSeq 1", saved)
+	}
+	if left := sink.journal("vid-j"); len(left) != 0 {
+		t.Errorf("journal after compaction = %+v, want deleted", left)
+	}
+}
+
+// TestJournal_restoreReplaysBaseAndJournal: RestoreFor は base に未取り込みの chunk を Seq 順に適用し、
+// compaction 済み (Seq <= JournalSeq) の chunk は読み飛ばす
+func TestJournal_restoreReplaysBaseAndJournal(t *testing.T) {
+	ctx := context.Background()
+	sink := newFakeJournalSink()
+	at := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	_ = sink.Save(ctx, &port.Snapshot{
+		VideoID:       "vid-r",
+		JournalSeq:    2,
+		Users:         []domain.User{{ChannelID: "UC1", DisplayName: "User1", CommentCount: 1}},
+		Comments:      []domain.Comment{{ID: "m1", ChannelID: "UC1", PublishedAt: at}},
+		ProcessedMsgs: []string{"m1"},
+		State:         &domain.LiveState{Status: domain.StatusActive, VideoID: "vid-r"},
+	})
+	chunks := []port.JournalChunk{
+		{VideoID: "vid-r", Seq: 2, Comments: []domain.Comment{{ID: "stale"}}},
+		{VideoID: "vid-r", Seq: 3, Users: []domain.User{{ChannelID: "UC1", DisplayName: "User1", CommentCount: 2}},
+			Comments: []domain.Comment{{ID: "m2", ChannelID: "UC1", PublishedAt: at.Add(time.Minute)}}, ProcessedMsgs: []string{"m2"}},
+		{VideoID: "vid-r", Seq: 4, Users: []domain.User{{ChannelID: "UC2", DisplayName: "User2", CommentCount: 1}},
+			Comments: []domain.Comment{{ID: "m3", ChannelID: "UC2", PublishedAt: at.Add(2 * time.Minute)}}, ProcessedMsgs: []string{"m3"},
+			State: &domain.LiveState{Status: domain.StatusPaused, VideoID: "vid-r"}},
+	}
+	for i := range chunks {
+		_ = sink.AppendJournal(ctx, &chunks[i])
+	}
+
+	ur, cr := newTestRepos()
+	c := snapshot.NewCoordinator(sink, ur, cr, nil, time.Hour)
+	restored, err := c.RestoreFor(ctx, "vid-r")
+	if err != nil || !restored {
+		t.Fatalf("RestoreFor = %v, %v", restored, err)
+	}
+
+	if cr.Count() != 3 {
+		t.Errorf("comments = %d, want 3 (m1 from base, m2 / m3 from journal, stale skipped)", cr.Count())
+	}
+	users := map[string]domain.User{}
+	for _, u := range ur.ListUsersSortedByJoinTime() {
+		users[u.ChannelID] = u
+	}
+	if len(users) != 2 || users["UC1"].CommentCount != 2 {
+		t.Errorf("users = %+v, want UC1 updated to 2 comments and UC2 added", users)
+	}
+	// 処理済みメッセージIDも復元され、同じメッセージは再集計されない
+	if updated, _ := ur.UpsertWithMessageUpdated("UC2", "User2", at, "m3"); updated {
+		t.Error("m3 was processed again after replay")
+	}
+
+	// 次の compaction の JournalSeq は replay 済みの Seq を引き継ぐ
+	c.SetVideo("vid-r", "", "", "")
+	if err := c.Flush(ctx); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if saved, _ := sink.Load(ctx, "vid-r"); saved == nil || saved.JournalSeq != 4 {
+		t.Errorf("compacted snapshot = %+v, want journalSeq 4", saved)
+	}
+}
+
+// runUntilAppended は background save を動かし、journal の追記が want 件になるまで待ちます。
+func runUntilAppended(c snapshot.Coordinator, sink *fakeJournalSink, want int) {
+	c.MarkDirty()
+	c.Start(context.Background())
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) && sink.getAppends() < want {
+		time.Sleep(20 * time.Millisecond)
+	}
+	c.Stop()
+}
+
+// TestJournal_includesAuxiliaryData: リアクション・視聴者数・メタデータ・チャンネルも書き込み時に記録された分だけ chunk に載り、
+// 再起動後の RestoreFor で base と合わせて復元される
+func TestJournal_includesAuxiliaryData(t *testing.T) {
+	ctx := context.Background()
+	sink := newFakeJournalSink()
+	at := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	newCoordinator := func() (snapshot.Coordinator, *memory.ReactionRepo, *memory.ViewerRepo, *memory.MetadataRepo, *memory.ChannelCache) {
+		ur, cr := newTestRepos()
+		rr, vr, mr := memory.NewReactionRepo(), memory.NewViewerRepo(), memory.NewMetadataRepo()
+		cache := memory.NewChannelCache(0, 0, nil)
+		c := snapshot.NewCoordinator(sink, ur, cr, nil, time.Hour,
+			snapshot.WithReactions(rr), snapshot.WithViewers(vr), snapshot.WithMetadata(mr), snapshot.WithChannels(cache.NewCursor()),
+			snapshot.WithJournal(time.Millisecond, time.Hour))
+		return c, rr, vr, mr, cache
+	}
+
+	c, rr, vr, mr, cache := newCoordinator()
+	rr.Record(at, []string{"laugh"})
+	c.SetVideo("vid-aux", "chat-aux", "", "")
+	if err := c.Flush(ctx); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	rr.Record(at.Add(time.Minute), []string{"applause"})
+	vr.Record(domain.ViewerSample{At: at.Add(time.Minute), ConcurrentViewers: 120})
+	mr.Record(domain.MetadataRevision{At: at.Add(time.Minute), Title: "【歌枠】"})
+	cache.Put(domain.ChannelInfo{ChannelID: "UC1", Handle: "@user1"})
+	runUntilAppended(c, sink, 1)
+
+	if got := sink.getSaveCount(); got != 1 {
+		t.Errorf("base saves = %d, want 1 (auxiliary changes must be appended)", got)
+	}
+	chunks := sink.journal("vid-aux")
+	if len(chunks) != 1 {
+		t.Fatalf("journal = %+v, want 1 chunk", chunks)
+	}
+	ch := chunks[0]
+	if len(ch.Reactions) != 1 || ch.Reactions[0].Counts["applause"] != 1 || len(ch.Viewers) != 1 ||
+		len(ch.Metadata) != 1 || len(ch.Channels) != 1 || len(ch.Users) != 0 || len(ch.Comments) != 0 {
+		t.Errorf("chunk = %+v, want only the new minute, sample, revision and channel", ch)
+	}
+
+	restarted, rr2, vr2, mr2, cache2 := newCoordinator()
+	if restored, err := restarted.RestoreFor(ctx, "vid-aux"); err != nil || !restored {
+		t.Fatalf("RestoreFor = %v, %v", restored, err)
+	}
+	if got := rr2.Timeline(); len(got) != 2 {
+		t.Errorf("reactions = %+v, want the base minute and the journaled minute", got)
+	}
+	if got := vr2.Samples(); len(got) != 1 || got[0].ConcurrentViewers != 120 {
+		t.Errorf("viewers = %+v", got)
+	}
+	if got := mr2.Revisions(); len(got) != 1 || got[0].Title != "【歌枠】" {
+		t.Errorf("metadata = %+v", got)
+	}
+	if info, ok := cache2.Get("UC1"); !ok || info.Handle != "@user1" {
+		t.Errorf("channel = %+v, %v", info, ok)
+	}
+}
+
+// TestJournal_unreadableJournalIsKept: journal を読み込めなければ base だけで復元し、その journal は compaction で
+// 上書き・削除せず、既存の chunk の後ろに追記する。読み込めるようになった後の RestoreFor ですべて取り込まれる
+func TestJournal_unreadableJournalIsKept(t *testing.T) {
+	ctx := context.Background()
+	sink := newFakeJournalSink()
+	at := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	_ = sink.Save(ctx, &port.Snapshot{
+		VideoID:       "vid-u",
+		Users:         []domain.User{{ChannelID: "UC1", DisplayName: "User1", CommentCount: 1}},
+		Comments:      []domain.Comment{{ID: "m1", ChannelID: "UC1", PublishedAt: at}},
+		ProcessedMsgs: []string{"m1"},
+	})
+	_ = sink.AppendJournal(ctx, &port.JournalChunk{VideoID: "vid-u", Seq: 1,
+		Comments: []domain.Comment{{ID: "m2", ChannelID: "UC1", PublishedAt: at.Add(time.Minute)}}, ProcessedMsgs: []string{"m2"}})
+	sink.setLoadJournalError(errors.New("transient"))
+
+	ur, cr := newTestRepos()
+	c := snapshot.NewCoordinator(sink, ur, cr, nil, time.Hour, snapshot.WithJournal(time.Millisecond, time.Millisecond))
+	restored, err := c.RestoreFor(ctx, "vid-u")
+	if err != nil || !restored || cr.Count() != 1 {
+		t.Fatalf("RestoreFor = %v, %v comments=%d; want the base alone restored", restored, err, cr.Count())
+	}
+
+	c.SetVideo("vid-u", "", "", "")
+	_ = cr.Add(domain.Comment{ID: "m3", ChannelID: "UC2", PublishedAt: at.Add(2 * time.Minute)})
+	// 既存 chunk の最終 Seq が分かるまでは書かない (同じ Seq で上書きしない)
+	if err := c.Flush(ctx); err == nil {
+		t.Error("Flush succeeded while the journal is unreadable, want an error to keep the changes")
+	}
+	sink.setLoadJournalError(nil)
+	if err := c.Flush(ctx); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if got := sink.getSaveCount(); got != 1 {
+		t.Errorf("base saves = %d, want 1 (must not compact over the unreplayed journal)", got)
+	}
+	chunks := sink.journal("vid-u")
+	if len(chunks) != 2 || chunks[1].Seq != 2 || len(chunks[1].Comments) != 1 || chunks[1].Comments[0].ID != "m3" {
+		t.Fatalf("journal = %+v, want the kept chunk and m3 appended as seq 2", chunks)
+	}
+
+	ur2, cr2 := newTestRepos()
+	restarted := snapshot.NewCoordinator(sink, ur2, cr2, nil, time.Hour, snapshot.WithJournal(time.Millisecond, time.Millisecond))
+	if restored, err := restarted.RestoreFor(ctx, "vid-u"); err != nil || !restored || cr2.Count() != 3 {
+		t.Fatalf("RestoreFor = %v, %v comments=%d; want m1..m3", restored, err, cr2.Count())
+	}
+	// replay できた後は通常どおり compaction する
+	restarted.SetVideo("vid-u", "", "", "")
+	if err := restarted.Flush(ctx); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if saved, _ := sink.Load(ctx, "vid-u"); saved == nil || len(saved.Comments) != 3 || saved.JournalSeq != 2 || len(sink.journal("vid-u")) != 0 {
+		t.Errorf("compacted snapshot = %+v, want 3 comments and journalSeq 2 with the journal deleted", saved)
+	}
+}
+
+// TestLoad_replaysJournal: 履歴の読み取り (Load) も compaction 前の chunk を base に適用して返す
+func TestLoad_replaysJournal(t *testing.T) {
+	ctx := context.Background()
+	sink := newFakeJournalSink()
+	at := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	_ = sink.Save(ctx, &port.Snapshot{VideoID: "vid-l", JournalSeq: 1, Comments: []domain.Comment{{ID: "m1", PublishedAt: at}}})
+	_ = sink.AppendJournal(ctx, &port.JournalChunk{VideoID: "vid-l", Seq: 1, Comments: []domain.Comment{{ID: "stale"}}})
+	_ = sink.AppendJournal(ctx, &port.JournalChunk{VideoID: "vid-l", Seq: 2, Comments: []domain.Comment{{ID: "m2", PublishedAt: at.Add(time.Minute)}}})
+
+	snap, err := snapshot.Load(ctx, sink, "vid-l")
+	if err != nil || snap == nil {
+		t.Fatalf("Load = %+v, %v", snap, err)
+	}
+	if len(snap.Comments) != 2 || snap.Comments[1].ID != "m2" || snap.JournalSeq != 2 {
+		t.Errorf("snapshot = %+v, want m1 and m2 with journalSeq 2", snap)
+	}
+
+	// journal を読み込めなくても base は返す
+	sink.setLoadJournalError(errors.New("transient"))
+	if snap, err := snapshot.Load(ctx, sink, "vid-l"); err != nil || snap == nil || len(snap.Comments) != 1 {
+		t.Errorf("Load with unreadable journal = %+v, %v; want the base", snap, err)
+	}
+
+	if snap, err := snapshot.Load(ctx, sink, "missing"); err != nil || snap != nil {
+		t.Errorf("Load(missing) = %+v, %v; want nil", snap, err)
+	}
+}
@@ -12,6 +12,8 @@ import (
 // StartOrReserveInput is the input for the StartOrReserve usecase.
 type StartOrReserveInput struct {
 	VideoID string
+	// Autonomous makes a live video start with AutonomousMonitoring=true (reservations are always autonomous).
+	Autonomous bool
 }
 
 // StartOrReserveOutput is the output for the StartOrReserve usecase.
@@ -56,7 +58,7 @@ func (uc *StartOrReserve) Execute(ctx context.Context, in StartOrReserveInput) (
 		return StartOrReserveOutput{State: out.State, Dispatched: "reserve"}, nil
 	}
 
-	out, serr := uc.SwitchVideo.Execute(ctx, SwitchVideoInput{VideoID: in.VideoID})
+	out, serr := uc.SwitchVideo.Execute(ctx, SwitchVideoInput{VideoID: in.VideoID, Autonomous: in.Autonomous})
 	if serr != nil {
 		return StartOrReserveOutput{}, serr
 	}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
@@ -17,13 +18,13 @@ func TestStartOrReserve_DispatchesToReserve_WhenNotStarted(t *testing.T) {
 	state := memory.NewStateRepo()
 	now := time.Date(2026, 6, 21, 10, 0, 0, 0, time.UTC)
 	scheduled := now.Add(6 * time.Hour)
-	yt := &fakeYTForReserve{
+	yt := &fakeYT{
 		details: port.VideoLiveDetails{
 			IsLiveContent:      true,
 			ScheduledStartTime: scheduled,
 		},
 	}
-	clock := fixedClock{t: now}
+	clock := system.NewManualClock(now)
 	uc := &usecase.StartOrReserve{
 		YT:          yt,
 		Clock:       clock,
@@ -47,7 +48,7 @@ func TestStartOrReserve_DispatchesToSwitch_WhenStarted(t *testing.T) {
 	ctx := context.Background()
 	state := memory.NewStateRepo()
 	now := time.Date(2026, 6, 21, 10, 0, 0, 0, time.UTC)
-	yt := &fakeYTForReserve{
+	yt := &fakeYT{
 		details: port.VideoLiveDetails{
 			LiveChatID:         "chat123",
 			IsLiveContent:      true,
@@ -55,7 +56,7 @@ func TestStartOrReserve_DispatchesToSwitch_WhenStarted(t *testing.T) {
 			ScheduledStartTime: now.Add(-15 * time.Minute),
 		},
 	}
-	clock := fixedClock{t: now}
+	clock := system.NewManualClock(now)
 	uc := &usecase.StartOrReserve{
 		YT:          yt,
 		Clock:       clock,
@@ -79,10 +80,10 @@ func TestStartOrReserve_NotLiveContent_ReturnsInvalidArgument(t *testing.T) {
 	ctx := context.Background()
 	state := memory.NewStateRepo()
 	now := time.Date(2026, 6, 21, 10, 0, 0, 0, time.UTC)
-	yt := &fakeYTForReserve{
+	yt := &fakeYT{
 		details: port.VideoLiveDetails{IsLiveContent: false},
 	}
-	clock := fixedClock{t: now}
+	clock := system.NewManualClock(now)
 	uc := &usecase.StartOrReserve{
 		YT:          yt,
 		Clock:       clock,
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+)
+
+// maxStateRetries は updateState が他の操作との競合時に再試行する回数です。
+const maxStateRetries = 3
+
+// updateState は State を読み、fn で作った新しい状態を CompareAndSet で保存して返します。
+// 読んでから保存するまでに他の操作が State を更新していた場合は、最新の State で fn をやり直します
+// (fn は前提条件の検査もやり直せるよう、cur だけから結果を決めること)。
+// maxStateRetries 回続けて競合したら domain.ErrCodeConflict を返します。fn のエラーはそのまま返します。
+func updateState(ctx context.Context, repo port.StateRepo, fn func(cur domain.LiveState) (domain.LiveState, error)) (domain.LiveState, error) {
+	var lastErr error
+	for range maxStateRetries {
+		cur, err := repo.Get(ctx)
+		if err != nil {
+			return domain.LiveState{}, fmt.Errorf("state_get: %w", err)
+		}
+		next, err := fn(cur)
+		if err != nil {
+			return domain.LiveState{}, err
+		}
+		next.Version = cur.Version
+		saved, err := repo.CompareAndSet(ctx, next)
+		if err == nil {
+			return saved, nil
+		}
+		if !isConflict(err) {
+			return domain.LiveState{}, fmt.Errorf("state_set: %w", err)
+		}
+		lastErr = err
+	}
+	return domain.LiveState{}, fmt.Errorf("state_set: %w", lastErr)
+}
+
+// clockNow は clock が nil なら time.Now を返します (Clock を任意依存とする usecase 用)。
+func clockNow(clock port.Clock) time.Time {
+	if clock == nil {
+		return time.Now()
+	}
+	return clock.Now()
+}
+
+// isConflict は err が StateRepo.CompareAndSet の競合 (domain.ErrCodeConflict) かを返します。
+func isConflict(err error) bool {
+	var apiErr *domain.APIError
+	return errors.As(err, &apiErr) && apiErr.Code == domain.ErrCodeConflict
+}
@@ -0,0 +1,153 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// resetDuringListYT は ListLiveChatMessages の途中で reset を実行する fakeYT を返す (Pull と Reset の競合を再現)。
+func resetDuringListYT(reset *usecase.Reset) *fakeYT {
+	return &fakeYT{listMessages: func(ctx context.Context, _ string, _ string) ([]port.ChatMessage, string, int64, int, bool, error) {
+		if _, err := reset.Execute(ctx); err != nil {
+			return nil, "", 0, 0, false, err
+		}
+		return []port.ChatMessage{{ID: "m1", ChannelID: "UC1", DisplayName: "User1", PublishedAt: time.Now()}}, "tok-next", 5000, 0, false, nil
+	}}
+}
+
+func TestPull_ResetDuringPullIsNotReverted(t *testing.T) {
+	ctx := context.Background()
+	users, comments, state := memory.NewUserRepo(), memory.NewCommentRepo(), memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v1", LiveChatID: "chat", NextPageToken: "tok-0"})
+	yt := resetDuringListYT(&usecase.Reset{Users: users, Comments: comments, State: state, Snap: &snapshot.NopCoordinator{}})
+	pull := &usecase.Pull{YT: yt, Users: users, Comments: comments, State: state, Clock: system.NewManualClock(time.Time{}), Snap: &snapshot.NopCoordinator{}}
+
+	_, err := pull.Execute(ctx)
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeConflict {
+		t.Fatalf("err = %v, want conflict", err)
+	}
+	st, _ := state.Get(ctx)
+	if st.Status != domain.StatusWaiting || st.NextPageToken != "" {
+		t.Errorf("state = %+v, want WAITING kept by Reset", st)
+	}
+	// Reset 前に取得したページをクリア済みの repo に書き戻さない
+	if users.Count() != 0 {
+		t.Errorf("users = %d, want 0 after Reset", users.Count())
+	}
+	if got := comments.Count(); got != 0 {
+		t.Errorf("comments = %d, want 0 after Reset", got)
+	}
+}
+
+// resetDuringStoreResolver はページ保存中 (State の確認後) に別 goroutine で Reset を始める ChannelResolveQueue。
+// Reset がロックで待たされなければ、保存が終わる前に repo のクリアと State の更新を終えてしまう。
+type resetDuringStoreResolver struct {
+	reset *usecase.Reset
+	done  chan struct{}
+}
+
+func (r *resetDuringStoreResolver) Lookup(_, _ []string) map[string]domain.ChannelInfo {
+	if r.done == nil {
+		r.done = make(chan struct{})
+		go func() {
+			defer close(r.done)
+			_, _ = r.reset.Execute(context.Background())
+		}()
+		select {
+		case <-r.done:
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	return nil
+}
+
+func TestPull_ResetDuringStoreWaitsForPage(t *testing.T) {
+	ctx := context.Background()
+	users, comments, state := memory.NewUserRepo(), memory.NewCommentRepo(), memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v1", LiveChatID: "chat"})
+	lock := &usecase.StoreLock{}
+	resolver := &resetDuringStoreResolver{reset: &usecase.Reset{Users: users, Comments: comments, State: state, Snap: &snapshot.NopCoordinator{}, Lock: lock}}
+	yt := &fakeYT{
+		items: []port.ChatMessage{{ID: "m1", ChannelID: "UC1", DisplayName: "User1", PublishedAt: time.Now()}},
+	}
+	pull := &usecase.Pull{YT: yt, Users: users, Comments: comments, State: state, Clock: system.NewManualClock(time.Time{}), Snap: &snapshot.NopCoordinator{}, Resolver: resolver, Lock: lock}
+
+	// Pull の State 更新は Reset と前後しうるため、競合で終わってもよい
+	var apiErr *domain.APIError
+	if _, err := pull.Execute(ctx); err != nil && (!errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeConflict) {
+		t.Fatalf("Pull: %v", err)
+	}
+	<-resolver.done
+
+	// Reset はページの保存を待ってからクリアするため、旧配信のコメントは残らない
+	st, _ := state.Get(ctx)
+	if st.Status != domain.StatusWaiting {
+		t.Errorf("state = %+v, want WAITING", st)
+	}
+	if users.Count() != 0 || comments.Count() != 0 {
+		t.Errorf("users = %d comments = %d, want 0 after Reset", users.Count(), comments.Count())
+	}
+}
+
+// racingStateRepo は最初の n 回の CompareAndSet の直前に別の書き込みを割り込ませる StateRepo。
+type racingStateRepo struct {
+	*memory.StateRepo
+	races int
+	with  domain.LiveState
+}
+
+func (r *racingStateRepo) CompareAndSet(ctx context.Context, st domain.LiveState) (domain.LiveState, error) {
+	if r.races > 0 {
+		r.races--
+		_ = r.Set(ctx, r.with)
+	}
+	return r.StateRepo.CompareAndSet(ctx, st)
+}
+
+func TestReserve_RechecksLatestStateOnConflict(t *testing.T) {
+	ctx := context.Background()
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true}}
+	clock := system.NewManualClock(time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	t.Run("割り込みが WAITING なら再試行して予約できる", func(t *testing.T) {
+		repo := &racingStateRepo{StateRepo: memory.NewStateRepo(), races: 1, with: domain.LiveState{Status: domain.StatusWaiting}}
+		uc := &usecase.Reserve{YT: yt, State: repo, Clock: clock, Snap: &snapshot.NopCoordinator{}}
+		out, err := uc.Execute(ctx, usecase.ReserveInput{VideoID: "v1"})
+		if err != nil || out.State.Status != domain.StatusReserved {
+			t.Fatalf("Reserve = %+v, %v", out, err)
+		}
+	})
+
+	t.Run("割り込みで ACTIVE になったら conflict", func(t *testing.T) {
+		repo := &racingStateRepo{StateRepo: memory.NewStateRepo(), races: 1, with: domain.LiveState{Status: domain.StatusActive, VideoID: "other"}}
+		uc := &usecase.Reserve{YT: yt, State: repo, Clock: clock, Snap: &snapshot.NopCoordinator{}}
+		_, err := uc.Execute(ctx, usecase.ReserveInput{VideoID: "v1"})
+		var apiErr *domain.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeConflict {
+			t.Fatalf("err = %v, want conflict", err)
+		}
+		if st, _ := repo.Get(ctx); st.VideoID != "other" {
+			t.Errorf("ACTIVE session overwritten: %+v", st)
+		}
+	})
+
+	t.Run("競合が続いたら諦めて conflict", func(t *testing.T) {
+		repo := &racingStateRepo{StateRepo: memory.NewStateRepo(), races: 10, with: domain.LiveState{Status: domain.StatusWaiting}}
+		uc := &usecase.Reserve{YT: yt, State: repo, Clock: clock, Snap: &snapshot.NopCoordinator{}}
+		_, err := uc.Execute(ctx, usecase.ReserveInput{VideoID: "v1"})
+		var apiErr *domain.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeConflict {
+			t.Fatalf("err = %v, want conflict after retries", err)
+		}
+	})
+}
@@ -19,11 +19,19 @@ type StatusOutput struct {
 	ReservedAt           time.Time
 	ScheduledStartTime   time.Time
 	AutonomousMonitoring bool
+	Quota                *domain.QuotaUsage    // Quota 未設定なら nil
+	APIKeys              []domain.APIKeyState  // APIKeys 未設定なら nil
+	Breaker              *domain.BreakerStatus // Breaker 未設定なら nil
+	Transitions          []domain.StateTransition
+	EventStreams         []domain.EventStream // イベントに追加した配信 (主配信のみなら空)
 }
 
 type Status struct {
-	Users port.UserRepo
-	State port.StateRepo
+	Users   port.UserRepo
+	State   port.StateRepo
+	Quota   port.QuotaReporter   // 任意
+	APIKeys port.APIKeyReporter  // 任意
+	Breaker port.BreakerReporter // 任意
 }
 
 func (uc *Status) Execute(ctx context.Context) (StatusOutput, error) {
@@ -36,7 +44,7 @@ func (uc *Status) Execute(ctx context.Context) (StatusOutput, error) {
 	// ユーザー数を取得
 	count := uc.Users.Count()
 
-	return StatusOutput{
+	out := StatusOutput{
 		Status:               state.Status,
 		Count:                count,
 		VideoID:              state.VideoID,
@@ -47,5 +55,19 @@ func (uc *Status) Execute(ctx context.Context) (StatusOutput, error) {
 		ReservedAt:           state.ReservedAt,
 		ScheduledStartTime:   state.ScheduledStartTime,
 		AutonomousMonitoring: state.AutonomousMonitoring,
-	}, nil
+		Transitions:          state.Transitions,
+		EventStreams:         state.EventStreams,
+	}
+	if uc.Quota != nil {
+		usage := uc.Quota.Usage()
+		out.Quota = &usage
+	}
+	if uc.APIKeys != nil {
+		out.APIKeys = uc.APIKeys.KeyStates()
+	}
+	if uc.Breaker != nil {
+		breaker := uc.Breaker.BreakerStatus()
+		out.Breaker = &breaker
+	}
+	return out, nil
 }
@@ -47,3 +47,52 @@ func TestStatus_ReturnsCurrentStateAndUserCount(t *testing.T) {
 		t.Errorf("StartedAt = %v, want %v", out.StartedAt, startedAt)
 	}
 }
+
+type fakeQuotaReporter struct{ usage domain.QuotaUsage }
+
+func (f *fakeQuotaReporter) Usage() domain.QuotaUsage { return f.usage }
+
+type fakeAPIKeyReporter struct{ states []domain.APIKeyState }
+
+func (f *fakeAPIKeyReporter) KeyStates() []domain.APIKeyState { return f.states }
+
+func TestStatus_IncludesQuotaUsage(t *testing.T) {
+	ctx := context.Background()
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusWaiting})
+
+	out, err := (&usecase.Status{Users: memory.NewUserRepo(), State: state}).Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if out.Quota != nil {
+		t.Errorf("Quota = %+v, want nil when reporter is not set", out.Quota)
+	}
+
+	reporter := &fakeQuotaReporter{usage: domain.QuotaUsage{Day: "2026-06-01", Used: 120, Budget: 10000}}
+	out, err = (&usecase.Status{Users: memory.NewUserRepo(), State: state, Quota: reporter}).Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if out.Quota == nil || out.Quota.Used != 120 || out.Quota.Budget != 10000 {
+		t.Errorf("Quota = %+v", out.Quota)
+	}
+}
+
+func TestStatus_IncludesAPIKeyStates(t *testing.T) {
+	ctx := context.Background()
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusWaiting})
+
+	reporter := &fakeAPIKeyReporter{states: []domain.APIKeyState{
+		{Key: "AIza****0001", Healthy: false, LastError: string(domain.ErrCodeQuotaExceeded)},
+		{Key: "AIza****0002", Healthy: true, Active: true},
+	}}
+	out, err := (&usecase.Status{Users: memory.NewUserRepo(), State: state, APIKeys: reporter}).Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if len(out.APIKeys) != 2 || out.APIKeys[0].Healthy || !out.APIKeys[1].Active {
+		t.Errorf("APIKeys = %+v", out.APIKeys)
+	}
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import "sync"
+
+// StoreLock は Pull のページ保存と、Reset / SwitchVideo による repo の置き換え (クリア・復元と State の更新) を直列化します。
+// Pull が State を確認してから保存するまでの間に repo がクリアされ、旧配信のコメントが書き戻されるのを防ぐ。
+// 同じセッションの usecase で 1 つを共有する。nil なら排他しない。
+type StoreLock struct {
+	mu sync.Mutex
+}
+
+// acquire はロックを取り、解放する関数を返します。
+func (l *StoreLock) acquire() (unlock func()) {
+	if l == nil {
+		return func() {}
+	}
+	l.mu.Lock()
+	return l.mu.Unlock
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// DefaultStreamEndGrace は actualEndTime が立ってから ENDED にするまでの猶予の既定値です。
+// 配信終了直後に届く末尾のコメントを取りこぼさないよう、その間は Pull を続ける。
+const DefaultStreamEndGrace = 2 * time.Minute
+
+type CheckStreamEndOutput struct {
+	Ended         bool      // 今回 ENDED に遷移した
+	ActualEndTime time.Time // 配信終了時刻 (配信中なら zero)
+	EndsAt        time.Time // 猶予中なら ENDED にする予定時刻 (それ以外は zero)
+	State         domain.LiveState
+}
+
+// CheckStreamEnd は配信中 (ACTIVE / PAUSED) の動画の liveStreamingDetails.actualEndTime を確認し、
+// 配信が終わっていれば猶予 (Grace) の後に ENDED に遷移する。
+// アーカイブ用にチャットが開いたままになり Pull が終了を検知できない配信でも、quota を使い続けないようにする。
+type CheckStreamEnd struct {
+	YT    port.YouTubePort
+	State port.StateRepo
+	Snap  snapshot.Coordinator
+	Clock port.Clock
+	Grace time.Duration // 負なら DefaultStreamEndGrace、0 なら actualEndTime に達した時点で ENDED にする
+}
+
+// Execute: 配信中でなければ何もしない。actualEndTime + Grace に達していれば snapshot を flush して ENDED にする。
+// 確認中に切替・終了されていたら State は触らない。
+func (uc *CheckStreamEnd) Execute(ctx context.Context, in LiveDetailsInput) (CheckStreamEndOutput, error) {
+	st, err := uc.State.Get(ctx)
+	if err != nil {
+		return CheckStreamEndOutput{}, fmt.Errorf("state_get: %w", err)
+	}
+	if !st.Status.InSession() || st.VideoID == "" {
+		return CheckStreamEndOutput{State: st}, nil
+	}
+	details, err := in.liveDetails(ctx, uc.YT, st.VideoID)
+	if err != nil {
+		return CheckStreamEndOutput{}, err
+	}
+	out := CheckStreamEndOutput{ActualEndTime: details.ActualEndTime, State: st}
+	if details.ActualEndTime.IsZero() {
+		return out, nil
+	}
+
+	grace := uc.Grace
+	if grace < 0 {
+		grace = DefaultStreamEndGrace
+	}
+	now := clockNow(uc.Clock)
+	if endsAt := details.ActualEndTime.Add(grace); now.Before(endsAt) {
+		out.EndsAt = endsAt
+		return out, nil
+	}
+
+	// Pull の終了検知と同じく、users / comments は残したまま snapshot へ永続化する
+	uc.Snap.MarkDirty()
+	if err := uc.Snap.Flush(ctx); err != nil {
+		logging.Log(ctx, "warn", "SNAPSHOT", "stream end: snapshot flush failed: %v", err)
+	}
+	ended := false
+	next, err := updateState(ctx, uc.State, func(cur domain.LiveState) (domain.LiveState, error) {
+		ended = false
+		if cur.VideoID != st.VideoID || !cur.Status.InSession() {
+			return cur, nil
+		}
+		ended = true
+		next := cur
+		if next.ActualStartTime.IsZero() {
+			next.ActualStartTime = details.ActualStartTime // snapshot の spike を /analytics/spikes と同じ起点にする
+		}
+		next.Status = domain.StatusEnded
+		next.EndedAt = now
+		next.NextPageToken = ""
+		next.AutonomousMonitoring = false // monitor の Pull tick を停止
+		return cur.Transition(next, now, "stream_ended")
+	})
+	if err != nil {
+		return CheckStreamEndOutput{}, err
+	}
+	out.State = next
+	if ended {
+		out.Ended = true
+		logging.Log(ctx, "info", "PULL", "stream %s ended at %s (grace %s) → ENDED",
+			st.VideoID, details.ActualEndTime.Format(time.RFC3339), grace)
+	}
+	return out, nil
+}
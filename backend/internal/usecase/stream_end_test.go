@@ -0,0 +1,72 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+func TestCheckStreamEnd_EndsAfterGrace(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 21, 0, 0, 0, time.UTC)
+	clock := system.NewManualClock(t0)
+	users := memory.NewUserRepo()
+	_ = users.UpsertWithJoinTime("ch1", "Alice", t0.Add(-time.Hour))
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v", LiveChatID: "chat", NextPageToken: "tok", AutonomousMonitoring: true})
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true, LiveChatID: "chat"}}
+	uc := &usecase.CheckStreamEnd{YT: yt, State: state, Snap: &snapshot.NopCoordinator{}, Clock: clock, Grace: 3 * time.Minute}
+
+	// 配信中: 何もしない
+	out, err := uc.Execute(ctx, usecase.LiveDetailsInput{})
+	if err != nil || out.Ended || !out.EndsAt.IsZero() {
+		t.Fatalf("live check = %+v, %v; want no end", out, err)
+	}
+
+	// 終了直後 (チャットはアーカイブ用に開いたまま): 猶予中は ACTIVE のまま
+	yt.details.ActualEndTime = t0.Add(-time.Minute)
+	out, err = uc.Execute(ctx, usecase.LiveDetailsInput{})
+	if err != nil || out.Ended || !out.EndsAt.Equal(t0.Add(2*time.Minute)) || out.State.Status != domain.StatusActive {
+		t.Fatalf("grace check = %+v, %v; want ENDED deferred to %s", out, err, t0.Add(2*time.Minute))
+	}
+
+	// 猶予後: ENDED、ユーザーは残す
+	clock.Set(t0.Add(2 * time.Minute))
+	out, err = uc.Execute(ctx, usecase.LiveDetailsInput{})
+	if err != nil || !out.Ended {
+		t.Fatalf("after grace = %+v, %v; want ended", out, err)
+	}
+	got, _ := state.Get(ctx)
+	if got.Status != domain.StatusEnded || got.AutonomousMonitoring || got.NextPageToken != "" || !got.EndedAt.Equal(clock.Now()) {
+		t.Errorf("state = %+v, want ENDED with monitoring stopped", got)
+	}
+	if users.Count() != 1 {
+		t.Errorf("users = %d, want 1 (kept after end)", users.Count())
+	}
+
+	// 終了済みなら再度は遷移しない
+	if out, _ := uc.Execute(ctx, usecase.LiveDetailsInput{}); out.Ended {
+		t.Errorf("second check ended again: %+v", out)
+	}
+}
+
+func TestCheckStreamEnd_ZeroGraceEndsImmediately(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 21, 0, 0, 0, time.UTC)
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusPaused, VideoID: "v", LiveChatID: "chat"})
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true, ActualEndTime: t0}}
+	uc := &usecase.CheckStreamEnd{YT: yt, State: state, Snap: &snapshot.NopCoordinator{}, Clock: system.NewManualClock(t0), Grace: 0}
+
+	out, err := uc.Execute(ctx, usecase.LiveDetailsInput{})
+	if err != nil || !out.Ended || out.State.Status != domain.StatusEnded {
+		t.Fatalf("zero grace = %+v, %v; want PAUSED stream ENDED at actualEndTime", out, err)
+	}
+}
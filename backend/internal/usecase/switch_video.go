@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
@@ -12,6 +13,8 @@ import (
 
 type SwitchVideoInput struct {
 	VideoID string
+	// Autonomous は切替後に monitor が自動 Pull するか (セッション API・チャンネル監視など人が Pull しない経路で true)
+	Autonomous bool
 }
 
 type SwitchVideoOutput struct {
@@ -25,6 +28,11 @@ type SwitchVideo struct {
 	State    port.StateRepo
 	Clock    port.Clock
 	Snap     snapshot.Coordinator // 必須 (GCS 不要な場合は NopCoordinator を渡す)
+
+	Reactions port.ReactionRepo // 任意: 設定時は users / comments と一緒にクリアする
+	Viewers   port.ViewerRepo   // 任意: 設定時は users / comments と一緒にクリアする
+	Metadata  port.MetadataRepo // 任意: 設定時は users / comments と一緒にクリアし、切替時点のタイトルなどを最初の履歴として記録する
+	Lock      *StoreLock        // 任意: Pull のページ保存と排他する (同じセッションの Pull / Reset と共有)
 }
 
 // Execute: videoId 切替、ユーザー初期化、State=ACTIVE に遷移。
@@ -33,28 +41,17 @@ func (uc *SwitchVideo) Execute(ctx context.Context, in SwitchVideoInput) (Switch
 	// 1. YouTube APIでliveChatIDを取得（失敗時はここで返るので snapshot 操作はしない）
 	meta, err := uc.YT.GetActiveLiveChatID(ctx, in.VideoID)
 	if err != nil {
+		unlock := uc.Lock.acquire()
+		defer unlock()
 		// 配信終了済 video への再切替で API error が発生した場合、同一 videoId かつ
-		// in-memory に users が残っていれば snapshot 復元データとして WAITING 状態で表示する。
+		// in-memory に users が残っていれば snapshot 復元データとして ENDED (読み取り専用) で表示する。
 		prevState, _ := uc.State.Get(ctx)
 		if prevState.VideoID == in.VideoID && uc.Users.Count() > 0 {
 			logging.Log(ctx, "info", "SNAPSHOT", "switch_video: API error on same videoId, restoring from in-memory snapshot (videoId=%s, users=%d): %v",
 				in.VideoID, uc.Users.Count(), err)
-			now := uc.Clock.Now()
-			startedAt := prevState.StartedAt
-			if startedAt.IsZero() {
-				startedAt = now
-			}
-			restoredState := domain.LiveState{
-				Status:               domain.StatusWaiting,
-				VideoID:              in.VideoID,
-				LiveChatID:           prevState.LiveChatID,
-				StartedAt:            startedAt,
-				EndedAt:              now,
-				NextPageToken:        "",
-				AutonomousMonitoring: false, // フォールバックで明示的に false (永続化漏れ防止)
-			}
-			if setErr := uc.State.Set(ctx, restoredState); setErr != nil {
-				return SwitchVideoOutput{}, fmt.Errorf("state_set: %w", setErr)
+			restoredState, setErr := uc.endRestored(ctx, in.VideoID)
+			if setErr != nil {
+				return SwitchVideoOutput{}, setErr
 			}
 			return SwitchVideoOutput{State: restoredState}, nil
 		}
@@ -66,6 +63,15 @@ func (uc *SwitchVideo) Execute(ctx context.Context, in SwitchVideoInput) (Switch
 			if uc.Comments != nil {
 				uc.Comments.Clear()
 			}
+			if uc.Reactions != nil {
+				uc.Reactions.Clear()
+			}
+			if uc.Viewers != nil {
+				uc.Viewers.Clear()
+			}
+			if uc.Metadata != nil {
+				uc.Metadata.Clear()
+			}
 		}
 		gcsRestored, rerr := uc.Snap.RestoreFor(ctx, in.VideoID)
 		if rerr != nil {
@@ -77,24 +83,10 @@ func (uc *SwitchVideo) Execute(ctx context.Context, in SwitchVideoInput) (Switch
 			return SwitchVideoOutput{}, fmt.Errorf("get_live_chat_id: %w", err)
 		}
 
-		// 復元成功 → WAITING で表示
-		now := uc.Clock.Now()
-		restoredState, _ := uc.State.Get(ctx)
-		startedAt := restoredState.StartedAt
-		if startedAt.IsZero() {
-			startedAt = now
-		}
-		finalState := domain.LiveState{
-			Status:               domain.StatusWaiting,
-			VideoID:              in.VideoID,
-			LiveChatID:           restoredState.LiveChatID,
-			StartedAt:            startedAt,
-			EndedAt:              now,
-			NextPageToken:        "",
-			AutonomousMonitoring: false, // フォールバックで明示的に false (永続化漏れ防止)
-		}
-		if setErr := uc.State.Set(ctx, finalState); setErr != nil {
-			return SwitchVideoOutput{}, fmt.Errorf("state_set: %w", setErr)
+		// 復元成功 → ENDED で表示
+		finalState, setErr := uc.endRestored(ctx, in.VideoID)
+		if setErr != nil {
+			return SwitchVideoOutput{}, setErr
 		}
 		logging.Log(ctx, "info", "SNAPSHOT", "switch_video: restored from GCS (videoId=%s, users=%d)", in.VideoID, uc.Users.Count())
 		return SwitchVideoOutput{State: finalState}, nil
@@ -107,6 +99,8 @@ func (uc *SwitchVideo) Execute(ctx context.Context, in SwitchVideoInput) (Switch
 	}
 
 	// 3. 同じ videoId への再切替は既存 users を維持する（別 videoId の場合のみクリア）
+	// repo の置き換えから State の更新 (4) までを Pull のページ保存と排他する
+	unlock := uc.Lock.acquire()
 	prevState, _ := uc.State.Get(ctx)
 	sameVideo := prevState.VideoID == in.VideoID
 	restored := false
@@ -115,6 +109,15 @@ func (uc *SwitchVideo) Execute(ctx context.Context, in SwitchVideoInput) (Switch
 		if uc.Comments != nil {
 			uc.Comments.Clear()
 		}
+		if uc.Reactions != nil {
+			uc.Reactions.Clear()
+		}
+		if uc.Viewers != nil {
+			uc.Viewers.Clear()
+		}
+		if uc.Metadata != nil {
+			uc.Metadata.Clear()
+		}
 		r, rerr := uc.Snap.RestoreFor(ctx, in.VideoID)
 		if rerr != nil {
 			logging.Log(ctx, "warn", "SNAPSHOT", "switch_video: restoreFor failed: %v", rerr)
@@ -124,31 +127,45 @@ func (uc *SwitchVideo) Execute(ctx context.Context, in SwitchVideoInput) (Switch
 	}
 
 	// 4. StateをACTIVEに更新
+	// 切替中に他の操作 (Pull の token 更新など) が State を書いても上書きで消さないよう、
+	// 保存直前の State を基に CompareAndSet する
 	now := uc.Clock.Now()
-	startedAt := now
-	if sameVideo && !prevState.StartedAt.IsZero() {
-		startedAt = prevState.StartedAt
-	} else if restored {
-		// RestoreFor が State を復元している場合、復元された StartedAt を引き継ぐ
-		if restoredState, err := uc.State.Get(ctx); err == nil && !restoredState.StartedAt.IsZero() {
-			startedAt = restoredState.StartedAt
+	newState, err := updateState(ctx, uc.State, func(cur domain.LiveState) (domain.LiveState, error) {
+		startedAt := now
+		if sameVideo && !prevState.StartedAt.IsZero() {
+			startedAt = prevState.StartedAt
+		} else if restored && !cur.StartedAt.IsZero() {
+			// RestoreFor が State を復元している場合、復元された StartedAt を引き継ぐ
+			startedAt = cur.StartedAt
 		}
-	}
-	newState := domain.LiveState{
-		Status:               domain.StatusActive,
-		VideoID:              in.VideoID,
-		LiveChatID:           meta.LiveChatID,
-		StartedAt:            startedAt,
-		NextPageToken:        "",
-		AutonomousMonitoring: prevState.AutonomousMonitoring, // Reserve 経由なら true を維持
-	}
-
-	if err := uc.State.Set(ctx, newState); err != nil {
-		return SwitchVideoOutput{}, fmt.Errorf("state_set: %w", err)
+		var actualStart time.Time
+		if cur.VideoID == in.VideoID {
+			actualStart = cur.ActualStartTime // 同じ配信 (再開・復元) なら取得済みの起点を引き継ぐ
+		}
+		return cur.Transition(domain.LiveState{
+			Status:               domain.StatusActive,
+			VideoID:              in.VideoID,
+			LiveChatID:           meta.LiveChatID,
+			StartedAt:            startedAt,
+			ActualStartTime:      actualStart,
+			NextPageToken:        "",
+			AutonomousMonitoring: prevState.AutonomousMonitoring || in.Autonomous, // Reserve 経由なら true を維持
+		}, now, "switch_video")
+	})
+	unlock()
+	if err != nil {
+		return SwitchVideoOutput{}, err
 	}
 
 	// 5. 新 videoId を Coordinator に設定し、current.json を即時更新
 	uc.Snap.SetVideo(in.VideoID, meta.LiveChatID, meta.Title, meta.ChannelTitle)
+	// 履歴が無ければ切替時点のタイトルなどを最初の履歴にする (monitor の定期確認を待たず、手動 Pull の配信にも残す)
+	if uc.Metadata != nil && len(uc.Metadata.Revisions()) == 0 {
+		refresh := &RefreshMetadata{YT: uc.YT, State: uc.State, Metadata: uc.Metadata, Snap: uc.Snap, Clock: uc.Clock}
+		if _, err := refresh.Execute(ctx, LiveDetailsInput{}); err != nil {
+			logging.Log(ctx, "warn", "METADATA", "switch_video: initial metadata failed: %v", err)
+		}
+	}
 	uc.Snap.MarkDirty()
 	if err := uc.Snap.Flush(ctx); err != nil {
 		logging.Log(ctx, "warn", "SNAPSHOT", "switch_video: snapshot flush (post-switch) failed: %v", err)
@@ -156,3 +173,29 @@ func (uc *SwitchVideo) Execute(ctx context.Context, in SwitchVideoInput) (Switch
 
 	return SwitchVideoOutput{State: newState}, nil
 }
+
+// endRestored は API で配信を取得できなかった video を、復元済みデータの閲覧用に ENDED とする。
+// StartedAt / LiveChatID は現在 (復元後) の State から引き継ぐ。
+func (uc *SwitchVideo) endRestored(ctx context.Context, videoID string) (domain.LiveState, error) {
+	now := uc.Clock.Now()
+	return updateState(ctx, uc.State, func(cur domain.LiveState) (domain.LiveState, error) {
+		startedAt := cur.StartedAt
+		if startedAt.IsZero() {
+			startedAt = now
+		}
+		var actualStart time.Time
+		if cur.VideoID == videoID {
+			actualStart = cur.ActualStartTime
+		}
+		return cur.Transition(domain.LiveState{
+			Status:               domain.StatusEnded,
+			VideoID:              videoID,
+			LiveChatID:           cur.LiveChatID,
+			StartedAt:            startedAt,
+			ActualStartTime:      actualStart,
+			EndedAt:              now,
+			NextPageToken:        "",
+			AutonomousMonitoring: false, // フォールバックで明示的に false (永続化漏れ防止)
+		}, now, "restore_ended")
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
 	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
@@ -18,47 +19,11 @@ func buildCoordWithSink(sink *fakeSinkForUsecase, users *memory.UserRepo, commen
 	return snapshot.NewCoordinator(sink, users, comments, state, 0)
 }
 
-type fakeYT struct{}
+// switchMeta は切替先の配信として GetActiveLiveChatID が返す動画メタデータ。
+var switchMeta = port.VideoMeta{LiveChatID: "live:abc", Title: "Test Video", ChannelTitle: "Test Channel"}
 
-func (f *fakeYT) GetActiveLiveChatID(ctx context.Context, videoID string) (port.VideoMeta, error) {
-	return port.VideoMeta{LiveChatID: "live:abc", Title: "Test Video", ChannelTitle: "Test Channel"}, nil
-}
-
-type failingYT struct{}
-
-func (f *failingYT) GetActiveLiveChatID(ctx context.Context, videoID string) (port.VideoMeta, error) {
-	return port.VideoMeta{}, errors.New("api error: live broadcast not found")
-}
-
-func (f *failingYT) ListLiveChatMessages(ctx context.Context, liveChatID string, pageToken string) ([]port.ChatMessage, string, int64, int, bool, error) {
-	return nil, "", 0, 0, false, nil
-}
-
-func (f *failingYT) GetChannelDisplayNames(ctx context.Context, channelIDs []string) (map[string]string, error) {
-	return nil, nil
-}
-func (f *failingYT) GetChannelHandles(ctx context.Context, channelIDs []string) (map[string]string, error) {
-	return nil, nil
-}
-func (f *failingYT) GetVideoLiveDetails(ctx context.Context, videoID string) (port.VideoLiveDetails, error) {
-	return port.VideoLiveDetails{}, nil
-}
-func (f *fakeYT) ListLiveChatMessages(ctx context.Context, liveChatID string, pageToken string) ([]port.ChatMessage, string, int64, int, bool, error) {
-	return nil, "", 0, 0, false, nil
-}
-func (f *fakeYT) GetChannelDisplayNames(ctx context.Context, channelIDs []string) (map[string]string, error) {
-	return nil, nil
-}
-func (f *fakeYT) GetChannelHandles(ctx context.Context, channelIDs []string) (map[string]string, error) {
-	return nil, nil
-}
-func (f *fakeYT) GetVideoLiveDetails(ctx context.Context, videoID string) (port.VideoLiveDetails, error) {
-	return port.VideoLiveDetails{}, nil
-}
-
-type fixedClock struct{ t time.Time }
-
-func (c fixedClock) Now() time.Time { return c.t }
+// errBroadcastNotFound は切替先の配信が見つからないときに GetActiveLiveChatID が返すエラー。
+var errBroadcastNotFound = errors.New("api error: live broadcast not found")
 
 func TestSwitchVideo_UsersClearedAndStateActive(t *testing.T) {
 	ctx := context.Background()
@@ -66,8 +31,8 @@ func TestSwitchVideo_UsersClearedAndStateActive(t *testing.T) {
 	users := memory.NewUserRepo()
 	_ = users.UpsertWithJoinTime("ch1", "to-be-cleared", time.Now())
 	state := memory.NewStateRepo()
-	yt := &fakeYT{}
-	clock := fixedClock{t: time.Unix(1000, 0)}
+	yt := &fakeYT{meta: switchMeta}
+	clock := system.NewManualClock(time.Unix(1000, 0))
 
 	uc := &usecase.SwitchVideo{YT: yt, Users: users, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 
@@ -93,8 +58,8 @@ func TestSwitchVideo_UsersClearedAndStateActive(t *testing.T) {
 	if currentState.LiveChatID != "live:abc" {
 		t.Errorf("State.LiveChatID = %v, want live:abc", currentState.LiveChatID)
 	}
-	if !currentState.StartedAt.Equal(clock.t) {
-		t.Errorf("State.StartedAt = %v, want %v", currentState.StartedAt, clock.t)
+	if !currentState.StartedAt.Equal(clock.Now()) {
+		t.Errorf("State.StartedAt = %v, want %v", currentState.StartedAt, clock.Now())
 	}
 
 	// 返り値の確認
@@ -120,8 +85,8 @@ func TestSwitchVideo_SameVideoID_PreservesUsersAndStartedAt(t *testing.T) {
 		EndedAt:    time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
 	})
 
-	yt := &fakeYT{}
-	clock := fixedClock{t: time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC)}
+	yt := &fakeYT{meta: switchMeta}
+	clock := system.NewManualClock(time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC))
 
 	uc := &usecase.SwitchVideo{YT: yt, Users: users, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 
@@ -160,9 +125,9 @@ func TestSwitchVideo_APIError_SameVideoID_RestoresFromMemory(t *testing.T) {
 		StartedAt:  originalStartedAt,
 	})
 
-	yt := &failingYT{}
+	yt := &fakeYT{metaErr: errBroadcastNotFound}
 	now := time.Date(2023, 1, 1, 14, 0, 0, 0, time.UTC)
-	clock := fixedClock{t: now}
+	clock := system.NewManualClock(now)
 
 	uc := &usecase.SwitchVideo{YT: yt, Users: users, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 
@@ -171,8 +136,8 @@ func TestSwitchVideo_APIError_SameVideoID_RestoresFromMemory(t *testing.T) {
 		t.Fatalf("Execute should not return error on fallback, got: %v", err)
 	}
 
-	if out.State.Status != domain.StatusWaiting {
-		t.Errorf("Output.State.Status = %v, want %v", out.State.Status, domain.StatusWaiting)
+	if out.State.Status != domain.StatusEnded {
+		t.Errorf("Output.State.Status = %v, want %v", out.State.Status, domain.StatusEnded)
 	}
 	if out.State.VideoID != "video123" {
 		t.Errorf("Output.State.VideoID = %v, want video123", out.State.VideoID)
@@ -201,8 +166,8 @@ func TestSwitchVideo_APIError_NewVideoID_ReturnsError(t *testing.T) {
 		VideoID: "video-old",
 	})
 
-	yt := &failingYT{}
-	clock := fixedClock{t: time.Now()}
+	yt := &fakeYT{metaErr: errBroadcastNotFound}
+	clock := system.NewManualClock(time.Now())
 
 	uc := &usecase.SwitchVideo{YT: yt, Users: users, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 
@@ -223,8 +188,8 @@ func TestSwitchVideo_APIError_SameVideoID_NoUsers_ReturnsError(t *testing.T) {
 		VideoID: "video123",
 	})
 
-	yt := &failingYT{}
-	clock := fixedClock{t: time.Now()}
+	yt := &fakeYT{metaErr: errBroadcastNotFound}
+	clock := system.NewManualClock(time.Now())
 
 	uc := &usecase.SwitchVideo{YT: yt, Users: users, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 
@@ -250,8 +215,8 @@ func TestSwitchVideo_PreservesAutonomousMonitoring(t *testing.T) {
 		ScheduledStartTime:   time.Date(2026, 6, 21, 10, 0, 0, 0, time.UTC),
 	})
 
-	yt := &fakeYT{}
-	clock := fixedClock{t: time.Date(2026, 6, 21, 10, 5, 0, 0, time.UTC)}
+	yt := &fakeYT{meta: switchMeta}
+	clock := system.NewManualClock(time.Date(2026, 6, 21, 10, 5, 0, 0, time.UTC))
 
 	uc := &usecase.SwitchVideo{YT: yt, Users: users, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 
@@ -278,8 +243,8 @@ func TestSwitchVideo_ManualSwitch_NoAutonomousMonitoring(t *testing.T) {
 	// WAITING (AM はゼロ値 = false)
 	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusWaiting})
 
-	yt := &fakeYT{}
-	clock := fixedClock{t: time.Now()}
+	yt := &fakeYT{meta: switchMeta}
+	clock := system.NewManualClock(time.Now())
 
 	uc := &usecase.SwitchVideo{YT: yt, Users: users, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 
@@ -310,8 +275,8 @@ func TestSwitchVideo_APIError_InMemoryFallback_ClearsAutonomousMonitoring(t *tes
 		AutonomousMonitoring: true,
 	})
 
-	yt := &failingYT{}
-	clock := fixedClock{t: time.Now()}
+	yt := &fakeYT{metaErr: errBroadcastNotFound}
+	clock := system.NewManualClock(time.Now())
 
 	uc := &usecase.SwitchVideo{YT: yt, Users: users, State: state, Clock: clock, Snap: &snapshot.NopCoordinator{}}
 
@@ -319,8 +284,8 @@ func TestSwitchVideo_APIError_InMemoryFallback_ClearsAutonomousMonitoring(t *tes
 	if err != nil {
 		t.Fatalf("Execute should succeed (in-memory fallback): %v", err)
 	}
-	if out.State.Status != domain.StatusWaiting {
-		t.Errorf("Status = %v, want WAITING", out.State.Status)
+	if out.State.Status != domain.StatusEnded {
+		t.Errorf("Status = %v, want ENDED", out.State.Status)
 	}
 	if out.State.AutonomousMonitoring {
 		t.Error("AutonomousMonitoring = true, want false (fallback should force clear)")
@@ -338,8 +303,8 @@ func TestSwitchVideo_DifferentVideo_ClearsComments(t *testing.T) {
 	state := memory.NewStateRepo()
 	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "video-old"})
 
-	yt := &fakeYT{}
-	clock := fixedClock{t: time.Now()}
+	yt := &fakeYT{meta: switchMeta}
+	clock := system.NewManualClock(time.Now())
 	sink := newFakeSinkForUsecase()
 	coord := buildCoordWithSink(sink, users, comments, state)
 
@@ -364,8 +329,8 @@ func TestSwitchVideo_V1_to_V2_to_V1_RestoresFromGCS(t *testing.T) {
 	state := memory.NewStateRepo()
 	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v1", LiveChatID: "chat-v1"})
 
-	yt := &fakeYT{}
-	clock := fixedClock{t: time.Now()}
+	yt := &fakeYT{meta: switchMeta}
+	clock := system.NewManualClock(time.Now())
 	sink := newFakeSinkForUsecase()
 	coord := buildCoordWithSink(sink, users, comments, state)
 
@@ -424,9 +389,9 @@ func TestSwitchVideo_APIError_RestoreFromGCS_succeeds(t *testing.T) {
 		VideoID: "v0",
 	})
 
-	yt := &failingYT{}
+	yt := &fakeYT{metaErr: errBroadcastNotFound}
 	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
-	clock := fixedClock{t: now}
+	clock := system.NewManualClock(now)
 
 	// GCS 側に v1 snapshot を事前保存
 	sink := newFakeSinkForUsecase()
@@ -458,8 +423,8 @@ func TestSwitchVideo_APIError_RestoreFromGCS_succeeds(t *testing.T) {
 		t.Fatalf("Execute should succeed (GCS restore), got: %v", err)
 	}
 
-	if out.State.Status != domain.StatusWaiting {
-		t.Errorf("State.Status = %v, want %v", out.State.Status, domain.StatusWaiting)
+	if out.State.Status != domain.StatusEnded {
+		t.Errorf("State.Status = %v, want %v", out.State.Status, domain.StatusEnded)
 	}
 	if out.State.VideoID != "v1" {
 		t.Errorf("State.VideoID = %v, want v1", out.State.VideoID)
@@ -496,8 +461,8 @@ func TestSwitchVideo_APIError_RestoreFromGCS_notFound(t *testing.T) {
 		VideoID: "v0",
 	})
 
-	yt := &failingYT{}
-	clock := fixedClock{t: time.Now()}
+	yt := &fakeYT{metaErr: errBroadcastNotFound}
+	clock := system.NewManualClock(time.Now())
 
 	// GCS に v1 snapshot なし (空の sink)
 	sink := newFakeSinkForUsecase()
@@ -528,9 +493,9 @@ func TestSwitchVideo_APIError_inMemoryFallback_priorityOverGCS(t *testing.T) {
 		StartedAt:  originalStartedAt,
 	})
 
-	yt := &failingYT{}
+	yt := &fakeYT{metaErr: errBroadcastNotFound}
 	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
-	clock := fixedClock{t: now}
+	clock := system.NewManualClock(now)
 
 	// GCS にも v1 snapshot あり（ただし 1st fallback が優先されるべき）
 	sink := newFakeSinkForUsecase()
@@ -563,8 +528,8 @@ func TestSwitchVideo_APIError_inMemoryFallback_priorityOverGCS(t *testing.T) {
 	if !out.State.StartedAt.Equal(originalStartedAt) {
 		t.Errorf("State.StartedAt = %v, want %v (in-memory, not GCS)", out.State.StartedAt, originalStartedAt)
 	}
-	if out.State.Status != domain.StatusWaiting {
-		t.Errorf("State.Status = %v, want %v", out.State.Status, domain.StatusWaiting)
+	if out.State.Status != domain.StatusEnded {
+		t.Errorf("State.Status = %v, want %v", out.State.Status, domain.StatusEnded)
 	}
 }
 
@@ -577,8 +542,8 @@ func TestSwitchVideo_SnapshotPreservedAcrossSwitch(t *testing.T) {
 	state := memory.NewStateRepo()
 	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v1", LiveChatID: "chat-v1"})
 
-	yt := &fakeYT{}
-	clock := fixedClock{t: time.Now()}
+	yt := &fakeYT{meta: switchMeta}
+	clock := system.NewManualClock(time.Now())
 	sink := newFakeSinkForUsecase()
 	coord := buildCoordWithSink(sink, users, comments, state)
 
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/analytics"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+const (
+	// DefaultTopTermsK は K 未指定時に返す term 数です。
+	DefaultTopTermsK = 20
+	// MaxTopTermsK は 1 リクエストで返す term 数の上限です。
+	MaxTopTermsK = 200
+)
+
+// TopTermsInput は TopTerms の入力です。
+type TopTermsInput struct {
+	VideoID   string    // 空または現在の videoId なら in-memory のコメント、それ以外は history snapshot を対象にする
+	K         int       // 0 なら DefaultTopTermsK
+	From      time.Time // publishedAt >= From (zero なら下限なし)
+	To        time.Time // publishedAt < To (zero なら上限なし)
+	Author    string    // channelId または @handle で投稿者を絞り込む (空なら全員)
+	Stopwords []string  // 既定の stopword に追加で除外する語
+}
+
+// TopTermsOutput は TopTerms の出力です。
+type TopTermsOutput struct {
+	VideoID      string
+	Source       string // "live" | "snapshot"
+	CommentCount int    // フィルタ後の集計対象コメント数
+	Terms        []domain.TermFrequency
+}
+
+// TopTerms は配信中または過去配信のコメントから頻出語を集計します。
+type TopTerms struct {
+	Comments  port.CommentRepo
+	State     port.StateRepo
+	Sink      port.SnapshotSink    // 任意: nil の場合は history snapshot を対象にできない
+	Tokenizer *analytics.Tokenizer // 任意: nil の場合は analytics.DefaultTokenizer を使う
+}
+
+// Execute は入力条件でコメントを絞り込み、上位 K 件の term を返します。
+func (uc *TopTerms) Execute(ctx context.Context, in TopTermsInput) (TopTermsOutput, error) {
+	k := in.K
+	if k == 0 {
+		k = DefaultTopTermsK
+	}
+	if k < 0 || k > MaxTopTermsK {
+		return TopTermsOutput{}, &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: fmt.Sprintf("k must be between 1 and %d", MaxTopTermsK)}
+	}
+	if !in.From.IsZero() && !in.To.IsZero() && !in.From.Before(in.To) {
+		return TopTermsOutput{}, &domain.APIError{Code: domain.ErrCodeInvalidArgument, Message: "from must be before to"}
+	}
+
+	state, err := uc.State.Get(ctx)
+	if err != nil {
+		return TopTermsOutput{}, fmt.Errorf("state_get: %w", err)
+	}
+
+	videoID := in.VideoID
+	source := "live"
+	var comments []domain.Comment
+	if videoID == "" || videoID == state.VideoID {
+		videoID = state.VideoID
+		comments = uc.Comments.ListSortedByPublishedAt()
+	} else {
+		if uc.Sink == nil {
+			return TopTermsOutput{}, domain.ErrNotFound
+		}
+		// compaction 前の journal に載っているコメントも集計する
+		snap, err := snapshot.Load(ctx, uc.Sink, videoID)
+		if err != nil {
+			return TopTermsOutput{}, fmt.Errorf("snapshot_load: %w", err)
+		}
+		if snap == nil {
+			return TopTermsOutput{}, domain.ErrNotFound
+		}
+		source = "snapshot"
+		comments = snap.Comments
+	}
+
+	filtered := make([]domain.Comment, 0, len(comments))
+	for _, c := range comments {
+		if !in.From.IsZero() && c.PublishedAt.Before(in.From) {
+			continue
+		}
+		if !in.To.IsZero() && !c.PublishedAt.Before(in.To) {
+			continue
+		}
+		if in.Author != "" && !matchesAuthor(c, in.Author) {
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+
+	tok := uc.Tokenizer
+	if tok == nil {
+		tok = analytics.DefaultTokenizer()
+	}
+	return TopTermsOutput{
+		VideoID:      videoID,
+		Source:       source,
+		CommentCount: len(filtered),
+		Terms:        analytics.TopTerms(filtered, k, tok.WithStopwords(in.Stopwords)),
+	}, nil
+}
+
+// matchesAuthor は author が channelId か @handle (@ の有無・大文字小文字は問わない) に一致するか判定します。
+func matchesAuthor(c domain.Comment, author string) bool {
+	if c.ChannelID == author {
+		return true
+	}
+	handle := strings.TrimPrefix(c.Handle, "@")
+	return handle != "" && strings.EqualFold(handle, strings.TrimPrefix(author, "@"))
+}
@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+)
+
+func TestTopTerms_Execute(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "live1"})
+	comments := memory.NewCommentRepo()
+	for i, c := range []domain.Comment{
+		{ChannelID: "ch1", Handle: "@Alice", Message: "草", PublishedAt: base},
+		{ChannelID: "ch2", Handle: "@bob", Message: "ゲーム", PublishedAt: base.Add(time.Minute)},
+		{ChannelID: "ch2", Handle: "@bob", Message: "ゲーム 草", PublishedAt: base.Add(2 * time.Minute)},
+	} {
+		c.ID = string(rune('a' + i))
+		_ = comments.Add(c)
+	}
+	sink := newFakeSinkForUsecase()
+	_ = sink.Save(ctx, &port.Snapshot{VideoID: "old1", Comments: []domain.Comment{{ChannelID: "ch9", Message: "神回"}}})
+
+	uc := &usecase.TopTerms{Comments: comments, State: state, Sink: sink}
+
+	t.Run("live のコメントを集計", func(t *testing.T) {
+		out, err := uc.Execute(ctx, usecase.TopTermsInput{})
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		if out.VideoID != "live1" || out.Source != "live" || out.CommentCount != 3 {
+			t.Errorf("out = %+v", out)
+		}
+		if len(out.Terms) != 2 || out.Terms[0].Count != 2 {
+			t.Errorf("Terms = %+v", out.Terms)
+		}
+	})
+
+	t.Run("期間と投稿者で絞り込む", func(t *testing.T) {
+		out, err := uc.Execute(ctx, usecase.TopTermsInput{From: base.Add(time.Minute), To: base.Add(2 * time.Minute), Author: "BOB"})
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		if out.CommentCount != 1 || len(out.Terms) != 1 || out.Terms[0].Term != "ゲーム" {
+			t.Errorf("out = %+v", out)
+		}
+	})
+
+	t.Run("history snapshot を集計", func(t *testing.T) {
+		out, err := uc.Execute(ctx, usecase.TopTermsInput{VideoID: "old1"})
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		if out.Source != "snapshot" || len(out.Terms) != 1 || out.Terms[0].Term != "神回" {
+			t.Errorf("out = %+v", out)
+		}
+	})
+
+	t.Run("snapshot なしは ErrNotFound", func(t *testing.T) {
+		if _, err := uc.Execute(ctx, usecase.TopTermsInput{VideoID: "missing"}); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("err = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("不正な k は invalid_argument", func(t *testing.T) {
+		_, err := uc.Execute(ctx, usecase.TopTermsInput{K: usecase.MaxTopTermsK + 1})
+		var apiErr *domain.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != domain.ErrCodeInvalidArgument {
+			t.Errorf("err = %v, want invalid_argument", err)
+		}
+	})
+}
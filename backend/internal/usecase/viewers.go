@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/logging"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/analytics"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+const (
+	// DefaultViewerSampleInterval は同時視聴者数をサンプリングする最短間隔です。
+	DefaultViewerSampleInterval = time.Minute
+	// DefaultViewerQuotaShare は次の quota リセットまでの残量のうち、視聴者数のサンプリングに使ってよい割合です。
+	// サンプリングは配信中、チャンネル監視の検出は配信が無い間だけ行うため、同じ割合を取り合わない。
+	// 複数セッションでは、この割合をセッション数で分け合う。
+	DefaultViewerQuotaShare = 0.10
+)
+
+type SampleViewersOutput struct {
+	Sampled bool                // 今回 videos.list を呼んでサンプルを記録した
+	Sample  domain.ViewerSample // 記録したサンプル (Sampled=false なら zero)
+}
+
+// SampleViewers は配信中 (ACTIVE / PAUSED) の動画の同時視聴者数・高評価数・再生数を videos.list で取得し、
+// 時系列として Viewers に記録する。monitor が Pull のたびに呼び、間隔に達したときだけ API を呼ぶ。
+// 間隔は Interval を下限に、quota 残量に応じて延ばす。
+type SampleViewers struct {
+	YT         port.YouTubePort
+	State      port.StateRepo
+	Viewers    port.ViewerRepo
+	Snap       snapshot.Coordinator
+	Clock      port.Clock
+	Quota      port.QuotaReporter // 任意: 設定時は残量に応じてサンプリング間隔を延ばす
+	Interval   time.Duration      // 0 なら DefaultViewerSampleInterval
+	QuotaShare float64            // 0 なら DefaultViewerQuotaShare
+	Sessions   SessionCounter     // 任意: 同じ quota を使うセッション数。設定時は QuotaShare をその数で分け合う
+}
+
+// Due は now に次のサンプルを取る間隔に達しているかを返す。monitor が詳細を取得するかの判断に使う。
+func (uc *SampleViewers) Due(now time.Time) bool {
+	samples := uc.Viewers.Samples()
+	return len(samples) == 0 || now.Sub(samples[len(samples)-1].At) >= uc.interval(now)
+}
+
+// Execute: 配信中でないか、直前のサンプルから間隔に達していなければ何もしない。
+func (uc *SampleViewers) Execute(ctx context.Context, in LiveDetailsInput) (SampleViewersOutput, error) {
+	st, err := uc.State.Get(ctx)
+	if err != nil {
+		return SampleViewersOutput{}, fmt.Errorf("state_get: %w", err)
+	}
+	if !st.Status.InSession() || st.VideoID == "" {
+		return SampleViewersOutput{}, nil
+	}
+	now := clockNow(uc.Clock)
+	if !uc.Due(now) {
+		return SampleViewersOutput{}, nil
+	}
+
+	details, err := in.liveDetails(ctx, uc.YT, st.VideoID)
+	if err != nil {
+		return SampleViewersOutput{}, err
+	}
+	sample := domain.ViewerSample{
+		At:                now,
+		ConcurrentViewers: details.ConcurrentViewers,
+		LikeCount:         details.LikeCount,
+		ViewCount:         details.ViewCount,
+	}
+	uc.Viewers.Record(sample)
+	uc.Snap.MarkDirty()
+	logging.Log(ctx, "info", "VIEWERS", "sampled %s: viewers=%d likes=%d views=%d",
+		st.VideoID, sample.ConcurrentViewers, sample.LikeCount, sample.ViewCount)
+	return SampleViewersOutput{Sampled: true, Sample: sample}, nil
+}
+
+// interval はサンプリング間隔を返す。quota 残量の QuotaShare 分で次のリセットまで続けられる間隔より短くはしない。
+func (uc *SampleViewers) interval(now time.Time) time.Duration {
+	interval := uc.Interval
+	if interval <= 0 {
+		interval = DefaultViewerSampleInterval
+	}
+	share := uc.QuotaShare
+	if share <= 0 {
+		share = DefaultViewerQuotaShare
+	}
+	return quotaPacedInterval(now, interval, uc.Quota, sessionShare(share, uc.Sessions), domain.QuotaCost["videos.list"])
+}
+
+// ViewerTimelineInput は ViewerTimeline の入力です。
+type ViewerTimelineInput struct {
+	Since time.Time // zero なら全期間
+}
+
+// ViewerTimelineOutput は ViewerTimeline の出力です。
+type ViewerTimelineOutput struct {
+	VideoID     string
+	Points      []domain.ViewerPoint // Since 以降のサンプルとコメント流量 (古い順)
+	Latest      *domain.ViewerSample // 最新のサンプル (未取得なら nil)
+	Correlation *float64             // 全期間の同時視聴者数と毎分コメント数の相関係数 (点が足りなければ nil)
+}
+
+// ViewerTimeline は SampleViewers が記録した視聴者数の時系列を、同じ区間のコメント流量と対応づけて返します。
+type ViewerTimeline struct {
+	Viewers  port.ViewerRepo
+	Comments port.CommentRepo
+	State    port.StateRepo
+}
+
+// Execute は現在の配信の視聴者数の時系列と、コメント流量との相関を返します。
+func (uc *ViewerTimeline) Execute(ctx context.Context, in ViewerTimelineInput) (ViewerTimelineOutput, error) {
+	state, err := uc.State.Get(ctx)
+	if err != nil {
+		return ViewerTimelineOutput{}, fmt.Errorf("state_get: %w", err)
+	}
+	samples := uc.Viewers.Samples()
+	// 区間は直前のサンプルから数えるため、全期間で対応づけてから Since で絞る
+	all := analytics.ViewerTimeline(samples, uc.Comments.ListSortedByPublishedAt())
+	points := make([]domain.ViewerPoint, 0, len(all))
+	for _, p := range all {
+		if in.Since.IsZero() || !p.At.Before(in.Since) {
+			points = append(points, p)
+		}
+	}
+	out := ViewerTimelineOutput{
+		VideoID:     state.VideoID,
+		Points:      points,
+		Correlation: analytics.ViewerCommentCorrelation(all),
+	}
+	if len(samples) > 0 {
+		latest := samples[len(samples)-1]
+		out.Latest = &latest
+	}
+	return out, nil
+}
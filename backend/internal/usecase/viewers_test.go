@@ -0,0 +1,123 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/memory"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/adapter/system"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/domain"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/port"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase"
+	"github.com/obsidian-engine/youtube-comment-user-list/backend/internal/usecase/snapshot"
+)
+
+// sessionCount は固定のセッション数を返す usecase.SessionCounter。
+type sessionCount int
+
+func (n sessionCount) Len() int { return int(n) }
+
+func TestSampleViewers_SamplesPerQuotaPacedInterval(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := system.NewManualClock(t0)
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v"})
+	viewers := memory.NewViewerRepo()
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true, ConcurrentViewers: 150, LikeCount: 10, ViewCount: 500}}
+	uc := &usecase.SampleViewers{YT: yt, State: state, Viewers: viewers, Snap: &snapshot.NopCoordinator{}, Clock: clock}
+
+	out, err := uc.Execute(ctx, usecase.LiveDetailsInput{})
+	if err != nil || !out.Sampled || out.Sample.ConcurrentViewers != 150 || out.Sample.LikeCount != 10 || !out.Sample.At.Equal(t0) {
+		t.Fatalf("first sample = %+v, %v", out, err)
+	}
+	clock.Set(t0.Add(30 * time.Second))
+	if out, _ := uc.Execute(ctx, usecase.LiveDetailsInput{}); out.Sampled || yt.callCount("GetVideoLiveDetails") != 1 {
+		t.Errorf("sample within interval = %+v (calls %d), want skipped", out, yt.callCount("GetVideoLiveDetails"))
+	}
+	clock.Set(t0.Add(usecase.DefaultViewerSampleInterval))
+	if out, _ := uc.Execute(ctx, usecase.LiveDetailsInput{}); !out.Sampled {
+		t.Errorf("sample after interval = %+v, want sampled", out)
+	}
+
+	// 残り 100 units の 10% = 10 回。リセットまで 10 時間なら 1 時間ごと
+	uc.Quota = fixedQuota{usage: domain.QuotaUsage{Budget: 10000, Used: 9900, ResetAt: clock.Now().Add(10 * time.Hour)}}
+	clock.Advance(30 * time.Minute)
+	if out, _ := uc.Execute(ctx, usecase.LiveDetailsInput{}); out.Sampled {
+		t.Errorf("sample after 30m with low quota = %+v, want skipped", out)
+	}
+	if got := len(viewers.Samples()); got != 2 {
+		t.Errorf("samples = %d, want 2", got)
+	}
+
+	// 2 セッションで分け合うと 5 回分 → 1 時間後 (リセットまで残り 9 時間 / 5 回 = 108 分) でもまだ取らない
+	uc.Sessions = sessionCount(2)
+	clock.Advance(30 * time.Minute)
+	if out, _ := uc.Execute(ctx, usecase.LiveDetailsInput{}); out.Sampled {
+		t.Errorf("sample after 1h shared by 2 sessions = %+v, want skipped", out)
+	}
+	uc.Sessions = sessionCount(1)
+	if out, _ := uc.Execute(ctx, usecase.LiveDetailsInput{}); !out.Sampled {
+		t.Errorf("sample after 1h with a single session = %+v, want sampled (9h / 10 runs = 54m)", out)
+	}
+
+	// 配信中でなければサンプリングしない
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusEnded, VideoID: "v"})
+	clock.Advance(24 * time.Hour)
+	if out, _ := uc.Execute(ctx, usecase.LiveDetailsInput{}); out.Sampled {
+		t.Errorf("sample after end = %+v, want skipped", out)
+	}
+}
+
+func TestSampleViewers_UsesPrefetchedDetails(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v"})
+	yt := &fakeYT{details: port.VideoLiveDetails{IsLiveContent: true, ConcurrentViewers: 150, LikeCount: 10, ViewCount: 500}}
+	uc := &usecase.SampleViewers{YT: yt, State: state, Viewers: memory.NewViewerRepo(), Snap: &snapshot.NopCoordinator{}, Clock: system.NewManualClock(t0)}
+
+	// 取得済みの詳細が同じ配信のものなら API を呼ばない
+	out, err := uc.Execute(ctx, usecase.LiveDetailsInput{VideoID: "v", Details: &port.VideoLiveDetails{ConcurrentViewers: 80}})
+	if err != nil || !out.Sampled || out.Sample.ConcurrentViewers != 80 || yt.callCount("GetVideoLiveDetails") != 0 {
+		t.Fatalf("prefetched sample = %+v, %v (calls %d); want 80 without API call", out, err, yt.callCount("GetVideoLiveDetails"))
+	}
+	// 別の配信の詳細なら取得し直す
+	uc.Clock = system.NewManualClock(t0.Add(usecase.DefaultViewerSampleInterval))
+	out, err = uc.Execute(ctx, usecase.LiveDetailsInput{VideoID: "other", Details: &port.VideoLiveDetails{ConcurrentViewers: 80}})
+	if err != nil || out.Sample.ConcurrentViewers != 150 || yt.callCount("GetVideoLiveDetails") != 1 {
+		t.Errorf("sample with other video's details = %+v, %v (calls %d); want refetched 150", out, err, yt.callCount("GetVideoLiveDetails"))
+	}
+}
+
+func TestViewerTimeline_CorrelatesWithComments(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	state := memory.NewStateRepo()
+	_ = state.Set(ctx, domain.LiveState{Status: domain.StatusActive, VideoID: "v"})
+	viewers := memory.NewViewerRepo()
+	comments := memory.NewCommentRepo()
+	for i, n := range []int{100, 200, 300, 400} {
+		at := t0.Add(time.Duration(i) * time.Minute)
+		viewers.Record(domain.ViewerSample{At: at, ConcurrentViewers: int64(n)})
+		for j := range n / 100 {
+			_ = comments.Add(domain.Comment{ID: at.String() + string(rune('a'+j)), PublishedAt: at.Add(-time.Second)})
+		}
+	}
+	uc := &usecase.ViewerTimeline{Viewers: viewers, Comments: comments, State: state}
+
+	out, err := uc.Execute(ctx, usecase.ViewerTimelineInput{Since: t0.Add(2 * time.Minute)})
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if out.VideoID != "v" || len(out.Points) != 2 || out.Points[0].Comments != 3 || out.Points[0].CommentsPerMinute != 3 {
+		t.Errorf("points = %+v, want the last 2 samples with 3 comments/min first", out.Points)
+	}
+	if out.Latest == nil || out.Latest.ConcurrentViewers != 400 {
+		t.Errorf("latest = %+v, want 400 viewers", out.Latest)
+	}
+	if out.Correlation == nil || *out.Correlation < 0.99 {
+		t.Errorf("correlation = %v, want ~1 (computed over all samples)", out.Correlation)
+	}
+}
@@ -114,6 +114,7 @@ export default function App() {
               onSwitch={handleSwitch}
               onPull={actions.onPull}
               onReset={actions.onReset}
+              onResume={actions.onResume}
             />
 
             {infoMsg && <Toast message={infoMsg} type="success" onClose={actions.clearInfoMsg} />}
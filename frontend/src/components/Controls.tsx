@@ -4,6 +4,7 @@ interface LoadingStates {
   switching: boolean
   pulling: boolean
   resetting: boolean
+  resuming: boolean
   refreshing: boolean
 }
 
@@ -16,6 +17,7 @@ interface ControlsProps {
   onSwitch: () => Promise<void>
   onPull: () => Promise<void>
   onReset: () => Promise<void>
+  onResume: () => Promise<void>
 }
 
 export function Controls({
@@ -27,23 +29,38 @@ export function Controls({
   onSwitch,
   onPull,
   onReset,
+  onResume,
 }: ControlsProps) {
   const isReserved = status === 'RESERVED'
   const isActive = status === 'ACTIVE'
+  const isPaused = status === 'PAUSED'
+  const isEnded = status === 'ENDED'
   // ACTIVE 中: 入力 videoId が空 or 現行と一致 → Pull、別 video 入力中 → 開始 (= 切替)
-  // WAITING / RESERVED → 開始 (SwitchVideo dispatcher が reserve/switch を自動判別)
+  // PAUSED 中: 入力 videoId が空 or 現行と一致 → 再開 (取得済みデータを維持)、別 video 入力中 → 開始
+  // WAITING / RESERVED / ENDED → 開始 (SwitchVideo dispatcher が reserve/switch を自動判別)
   const sameVideo = !videoId || videoId === currentVideoId
-  const action: 'pull' | 'start' = isActive && sameVideo ? 'pull' : 'start'
-  const actionLabel = action === 'pull' ? '今すぐ取得' : '開始'
-  const actionLoading = action === 'pull' ? loadingStates.pulling : loadingStates.switching
-  const actionLoadingText = action === 'pull' ? '取得中…' : '開始中…'
-  const actionHandler = action === 'pull' ? onPull : onSwitch
+  const action: 'pull' | 'resume' | 'start' =
+    isActive && sameVideo ? 'pull' : isPaused && sameVideo ? 'resume' : 'start'
+  const actionLabel = { pull: '今すぐ取得', resume: '再開', start: '開始' }[action]
+  const actionLoading = {
+    pull: loadingStates.pulling,
+    resume: loadingStates.resuming,
+    start: loadingStates.switching,
+  }[action]
+  const actionLoadingText = { pull: '取得中…', resume: '再開中…', start: '開始中…' }[action]
+  const actionHandler = { pull: onPull, resume: onResume, start: onSwitch }[action]
   const actionDisabled = isReserved
   return (
     <section aria-label="操作" className="card-editorial">
       <div className="eyebrow">
         OPERATIONS
         <div className="eyebrow__rule" />
+        {(isPaused || isEnded) && (
+          <div className="eyebrow__status" role="status">
+            <div className="eyebrow__status-dot" />
+            {isPaused ? '一時停止中' : '配信終了'}
+          </div>
+        )}
       </div>
 
       <div style={{ padding: '16px 20px 20px' }}>
@@ -12,11 +12,13 @@ describe('Controls コンポーネント', () => {
       switching: false,
       pulling: false,
       resetting: false,
+      resuming: false,
       refreshing: false,
     },
     onSwitch: vi.fn(),
     onPull: vi.fn(),
     onReset: vi.fn(),
+    onResume: vi.fn(),
   }
 
   beforeEach(() => {
@@ -84,6 +86,49 @@ describe('Controls コンポーネント', () => {
     expect(input).toHaveAttribute('placeholder', '予約中 (キャンセルは curl)')
   })
 
+  test('PAUSED 状態かつ入力 videoId が現行と一致なら「再開」になる', async () => {
+    const mockOnResume = vi.fn().mockResolvedValue(undefined)
+    render(
+      <Controls
+        {...mockProps}
+        status="PAUSED"
+        videoId="vid001"
+        currentVideoId="vid001"
+        onResume={mockOnResume}
+      />,
+    )
+
+    expect(screen.queryByRole('button', { name: '開始' })).not.toBeInTheDocument()
+    expect(screen.getByRole('status')).toHaveTextContent('一時停止中')
+    fireEvent.click(screen.getByRole('button', { name: '再開' }))
+
+    await waitFor(() => {
+      expect(mockOnResume).toHaveBeenCalled()
+    })
+    expect(mockProps.onSwitch).not.toHaveBeenCalled()
+  })
+
+  test('PAUSED 状態で別 videoId 入力中は「開始」(切替) になる', () => {
+    render(<Controls {...mockProps} status="PAUSED" videoId="vid_new" currentVideoId="vid_old" />)
+
+    expect(screen.getByRole('button', { name: '開始' })).toBeInTheDocument()
+    expect(screen.queryByRole('button', { name: '再開' })).not.toBeInTheDocument()
+  })
+
+  test('ENDED 状態では「配信終了」バッジと「開始」ボタンが表示される', () => {
+    render(<Controls {...mockProps} status="ENDED" videoId="" currentVideoId="vid001" />)
+
+    expect(screen.getByRole('status')).toHaveTextContent('配信終了')
+    expect(screen.getByRole('button', { name: '開始' })).toBeInTheDocument()
+    expect(screen.queryByRole('button', { name: '今すぐ取得' })).not.toBeInTheDocument()
+  })
+
+  test('WAITING 状態ではバッジを表示しない', () => {
+    render(<Controls {...mockProps} />)
+
+    expect(screen.queryByRole('status')).not.toBeInTheDocument()
+  })
+
   test('「開始」クリック時に onSwitch が呼ばれる', async () => {
     const mockOnSwitch = vi.fn().mockResolvedValue(undefined)
     render(<Controls {...mockProps} videoId="vid001" onSwitch={mockOnSwitch} />)
@@ -145,6 +190,7 @@ describe('Controls コンポーネント', () => {
       switching: true,
       pulling: false,
       resetting: false,
+      resuming: false,
       refreshing: false,
     }
     render(<Controls {...mockProps} loadingStates={loadingStates} />)
@@ -160,6 +206,7 @@ describe('Controls コンポーネント', () => {
       switching: false,
       pulling: true,
       resetting: false,
+      resuming: false,
       refreshing: false,
     }
     render(
@@ -78,6 +78,7 @@ describe('useAppState', () => {
         switching: false,
         pulling: false,
         resetting: false,
+        resuming: false,
         refreshing: false,
       },
     })
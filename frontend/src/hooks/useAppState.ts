@@ -4,6 +4,7 @@ import {
   getUsers,
   postPull,
   postReset,
+  postResume,
   postSwitchVideo,
   BackendError,
 } from '../utils/api'
@@ -54,6 +55,7 @@ interface LoadingStates {
   switching: boolean
   pulling: boolean
   resetting: boolean
+  resuming: boolean
   refreshing: boolean
 }
 
@@ -83,6 +85,7 @@ interface AppActions {
   onPull: () => Promise<void>
   onPullSilent: () => Promise<void>
   onReset: () => Promise<void>
+  onResume: () => Promise<void>
   clearInfoMsg: () => void
   clearSnapshotRestoreMsg: () => void
 }
@@ -113,6 +116,7 @@ export function useAppState(addEntry?: AddEntryFn) {
       switching: false,
       pulling: false,
       resetting: false,
+      resuming: false,
       refreshing: false,
     },
   })
@@ -122,6 +126,7 @@ export function useAppState(addEntry?: AddEntryFn) {
   const switchControllerRef = useRef<AbortController | null>(null)
   const pullControllerRef = useRef<AbortController | null>(null)
   const resetControllerRef = useRef<AbortController | null>(null)
+  const resumeControllerRef = useRef<AbortController | null>(null)
 
   const updateClock = useCallback(() => {
     const d = new Date()
@@ -383,6 +388,24 @@ export function useAppState(addEntry?: AddEntryFn) {
       )
     }, [handleAsyncAction, addEntry]),
 
+    onResume: useCallback(async () => {
+      await handleAsyncAction(
+        async (signal) => {
+          const res = await postResume(signal)
+          addEntry?.('info', '監視再開')
+          res.logs?.forEach((entry) => {
+            const level: LogLevel =
+              entry.level === 'warn' || entry.level === 'error' ? entry.level : 'info'
+            addEntry?.(level, `[${entry.source}] ${entry.message}`)
+          })
+        },
+        'resuming',
+        '再開しました',
+        '再開',
+        resumeControllerRef,
+      )
+    }, [handleAsyncAction, addEntry]),
+
     clearInfoMsg: useCallback(() => {
       setState((prev) => ({ ...prev, infoMsg: '' }))
     }, []),
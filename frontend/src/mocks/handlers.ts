@@ -8,8 +8,10 @@ type User = {
   firstCommentedAt?: string
 }
 
+type Status = 'WAITING' | 'ACTIVE' | 'RESERVED' | 'PAUSED' | 'ENDED'
+
 // 簡易なメモリ状態（各テストで server.use で上書き可）
-let state: 'WAITING' | 'ACTIVE' | 'RESERVED' = 'WAITING'
+let state: Status = 'WAITING'
 let users: User[] = []
 let videoId: string | undefined
 
@@ -57,6 +59,18 @@ export const handlers = [
     return new HttpResponse(null, { status: 200 })
   }),
 
+  // 再開 (PAUSED → ACTIVE、ユーザーは維持)
+  http.post('*/resume', () => {
+    if (state !== 'PAUSED') {
+      return HttpResponse.json(
+        { error: 'conflict', code: 'conflict', message: 'not paused', httpCode: 409 },
+        { status: 409 },
+      )
+    }
+    state = 'ACTIVE'
+    return HttpResponse.json({ status: state })
+  }),
+
   // リセット
   http.post('*/reset', () => {
     state = 'WAITING'
@@ -70,7 +84,7 @@ export const __mock = {
   get state() {
     return state
   },
-  set state(v: 'WAITING' | 'ACTIVE' | 'RESERVED') {
+  set state(v: Status) {
     state = v
   },
   get users() {
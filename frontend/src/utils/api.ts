@@ -66,7 +66,7 @@ async function throwIfError(res: Response): Promise<void> {
 }
 
 export type StatusResponse = {
-  status?: 'WAITING' | 'ACTIVE' | 'RESERVED'
+  status?: 'WAITING' | 'ACTIVE' | 'RESERVED' | 'PAUSED' | 'ENDED'
   count?: number
   videoId?: string
   startedAt?: string
@@ -89,6 +89,7 @@ export type User = {
   firstCommentedAt?: string
   commentCount?: number
   latestCommentedAt?: string
+  streams?: string[]
 }
 
 export async function getUsers(signal?: AbortSignal): Promise<User[] | null> {
@@ -97,7 +98,7 @@ export async function getUsers(signal?: AbortSignal): Promise<User[] | null> {
 }
 
 export type SwitchVideoResponse = {
-  status: 'WAITING' | 'ACTIVE' | 'RESERVED'
+  status: 'WAITING' | 'ACTIVE' | 'RESERVED' | 'PAUSED' | 'ENDED'
   videoId?: string
   liveChatId?: string
   startedAt?: string
@@ -143,6 +144,17 @@ export async function postReset(signal?: AbortSignal): Promise<void> {
   await throwIfError(res)
 }
 
+export type ResumeResponse = {
+  status: 'WAITING' | 'ACTIVE' | 'RESERVED' | 'PAUSED' | 'ENDED'
+  logs?: LogDetail[]
+}
+
+// PAUSED のセッションを ACTIVE に戻す (取得済みのユーザー・コメントは維持される)
+export async function postResume(signal?: AbortSignal): Promise<ResumeResponse> {
+  const res = await fetch(`${BASE}/resume`, { method: 'POST', signal })
+  return json<ResumeResponse>(res)
+}
+
 export type Comment = {
   id: string
   channelId: string
@@ -150,6 +162,7 @@ export type Comment = {
   handle?: string
   message: string
   publishedAt: string
+  videoId?: string
 }
 
 const MAX_RETRIES = 3